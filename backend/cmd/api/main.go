@@ -12,26 +12,36 @@ import (
 	"syscall"
 	"time"
 
-	binanceApi "github.com/adshao/go-binance/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/infra/config"
 	"github.com/jeancarlosdanese/crypto-bot/internal/infra/database"
 	"github.com/jeancarlosdanese/crypto-bot/internal/infra/repository/postgres"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/secrets"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
 	"github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
 	"github.com/jeancarlosdanese/crypto-bot/internal/server/routes"
 	"github.com/jeancarlosdanese/crypto-bot/internal/services"
 	"github.com/jeancarlosdanese/crypto-bot/internal/services/binance"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/paper"
 )
 
+// shutdownTimeout limita a espera pelo encerramento dos bots.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	logger.InitLogger()
 	log.Println("🚀 Iniciando Robô de Crypto...")
 
 	config.LoadEnv(".env")
 
+	// 🛑 Cancelado por SIGINT/SIGTERM: encerra o supervisor e os bots
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	// PostgreSQL
 	pool, err := database.NewPostgresPool()
 	if err != nil {
@@ -39,45 +49,87 @@ func main() {
 	}
 	defer pool.Close()
 
+	// 🔐 Chave mestra para os segredos das contas
+	secretsCipher, err := secrets.NewCipherFromEnv()
+	if err != nil {
+		log.Fatalf("Erro ao carregar chave mestra de segredos: %v", err)
+	}
+
 	// Repositórios
-	accountRepo := postgres.NewAccountRepository(pool)
+	accountRepo := postgres.NewAccountRepository(pool, secretsCipher)
 	botRepo := postgres.NewBotRepository(pool)
+	botConfigRepo := postgres.NewBotConfigRepository(pool)
 	positionRepo := postgres.NewPositionRepository(pool)
 	executionRepo := postgres.NewExecutionLogRepository(pool)
 	decisionRepo := postgres.NewDecisionLogRepository(pool)
 	otpRepo := postgres.NewAccountOTPRepository(pool)
+	paperLedgerRepo := postgres.NewPaperLedgerRepository(pool)
+	orderRepo := postgres.NewOrderRepository(pool)
+	riskLimitsRepo := postgres.NewRiskLimitsRepository(pool)
+	candleRepo := postgres.NewCandleRepository(pool)
+
+	// Exchange Service (Binance): um cliente por conta, com as credenciais da própria conta
+	exchangeFactory := binance.NewClientFactory()
+	exchangeService := exchangeFactory.Public()
+	go exchangeFactory.Symbols().Run(ctx)
 
-	// Exchange Service (Binance)
-	binanceClient := binanceApi.NewClient(os.Getenv("BINANCE_API_KEY"), os.Getenv("BINANCE_API_SECRET"))
-	exchangeService := binance.NewBinanceService(binanceClient)
+	// 🛡️ Limites de risco por conta, avaliados antes de cada entrada (exposição em USDT a preço atual)
+	riskGuard := usecases.NewRiskGuard(riskLimitsRepo, positionRepo, orderRepo, executionRepo, exchangeService)
+
+	// Paper trading: saldos virtuais por conta, com dados de mercado reais
+	paperFactory := paper.NewFactory(exchangeService, paper.ConfigFromEnv(), paperLedgerRepo)
 
 	// 🔁 Start bots em paralelo
+	// 📡 Uma assinatura upstream por par/intervalo, compartilhada entre os bots
+	marketHub := binance.NewMarketHub(nil)
+	defer marketHub.Close()
+
 	streamFactory := func(strategy *usecases.StrategyUseCase) services.StreamService {
-		return binance.NewBinanceStreamService(strategy, exchangeService)
+		return binance.NewBinanceStreamService(strategy, marketHub, candleRepo)
 	}
 
-	go startBots(
-		context.Background(),
-		accountRepo,
-		botRepo,
+	exchangeFor := func(account entity.Account, tradingMode string) (services.ExchangeService, error) {
+		if tradingMode == entity.TradingModeLive {
+			return exchangeFactory.ForAccount(&account)
+		}
+		return paperFactory.ForAccount(&account)
+	}
+
+	botManager := runtime.NewBotManager(
+		botConfigRepo,
 		positionRepo,
-		exchangeService,
-		streamFactory,
 		decisionRepo,
 		executionRepo,
+		usecases.NewOrderManager(orderRepo),
+		riskGuard,
+		exchangeFor,
+		streamFactory,
+		240,
 	)
 
+	botsDone := make(chan struct{})
+	go func() {
+		defer close(botsDone)
+		startBots(ctx, accountRepo, botRepo, botManager)
+	}()
+
 	// 🌐 Iniciar servidor HTTP com rotas REST
-	go startHTTPServer(accountRepo, botRepo, otpRepo, pool)
+	go startHTTPServer(accountRepo, botRepo, botConfigRepo, otpRepo, candleRepo, exchangeService, exchangeFactory, riskGuard, botManager, pool)
 
 	// 🛑 Aguardar sinal do SO para desligar
-	waitForShutdown()
+	waitForShutdown(ctx, botsDone)
 }
 
 func startHTTPServer(
 	accountRepo repository.AccountRepository,
 	botRepo repository.BotRepository,
+	botConfigRepo repository.BotConfigRepository,
 	otpRepo repository.AccountOTPRepository,
+	candleRepo repository.CandleRepository,
+	exchangeService services.ExchangeService,
+	exchangeFactory services.ExchangeFactory,
+	riskGuard *usecases.RiskGuard,
+	botManager *runtime.BotManager,
 	db *pgxpool.Pool,
 ) {
 	port := os.Getenv("APP_PORT")
@@ -91,6 +143,12 @@ func startHTTPServer(
 			otpRepo,
 			accountRepo,
 			botRepo,
+			botConfigRepo,
+			candleRepo,
+			exchangeService,
+			exchangeFactory,
+			riskGuard,
+			botManager,
 		),
 	)
 
@@ -108,12 +166,15 @@ func startHTTPServer(
 	}
 }
 
-func waitForShutdown() {
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
-	<-stop
-
+// waitForShutdown aguarda o sinal do SO e o encerramento dos bots (até shutdownTimeout).
+func waitForShutdown(ctx context.Context, botsDone <-chan struct{}) {
+	<-ctx.Done()
 	log.Println("⚠️ Encerrando servidor e bots...")
-	time.Sleep(2 * time.Second)
-	log.Println("✅ Encerrado com sucesso.")
+
+	select {
+	case <-botsDone:
+		log.Println("✅ Encerrado com sucesso.")
+	case <-time.After(shutdownTimeout):
+		log.Println("⚠️ Tempo esgotado aguardando os bots pararem.")
+	}
 }
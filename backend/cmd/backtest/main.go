@@ -0,0 +1,134 @@
+// cmd/backtest/main.go
+
+// backtest reproduz candles históricos por uma estratégia registrada e gera trades,
+// curva de patrimônio e métricas (JSON) além de um resumo no terminal.
+//
+// Exemplos:
+//
+//	go run ./cmd/backtest -symbol BTC/USDT -interval 1h -strategy EvaluateCrossover \
+//	    -from 2024-01-01 -to 2024-06-30
+//	go run ./cmd/backtest -symbol BTC/USDT -interval 1h -csv BTCUSDT-1h-2024-01.csv,BTCUSDT-1h-2024-02.csv \
+//	    -params '{"sizing_method":"balance_pct","sizing_balance_pct":50}' -fee 0.001 -slippage 0.0005
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/backtest"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/binance"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+func main() {
+	symbol := flag.String("symbol", "BTC/USDT", "par no formato BASE/QUOTE")
+	interval := flag.String("interval", "1h", "intervalo dos candles")
+	strategy := flag.String("strategy", "EvaluateCrossover", "estratégia registrada")
+	params := flag.String("params", "", "parâmetros da estratégia em JSON (sobrescrevem os padrões)")
+	csvFiles := flag.String("csv", "", "arquivos CSV de klines separados por vírgula (em vez da Binance)")
+	csvInterval := flag.String("csv-interval", "", "intervalo dos candles nos CSV, agregados em -interval (padrão: -interval, ou 1m se agregado)")
+	from := flag.String("from", "", "início do período na Binance (YYYY-MM-DD)")
+	to := flag.String("to", "", "fim do período na Binance (YYYY-MM-DD, padrão: agora)")
+	balance := flag.Float64("balance", 10000, "saldo inicial em moeda de cotação")
+	fee := flag.Float64("fee", 0.001, "taxa por execução (0.001 = 0,1%)")
+	slippage := flag.Float64("slippage", 0.0005, "slippage das ordens a mercado (0.0005 = 0,05%)")
+	window := flag.Int("window", backtest.DefaultWindowSize, "janela de candles da estratégia")
+	out := flag.String("out", "backtest.json", "arquivo JSON de saída (vazio para não gravar)")
+	verbose := flag.Bool("verbose", false, "exibe os logs da estratégia")
+	flag.Parse()
+
+	// Os logs de cada decisão poluem a saída: por padrão apenas avisos e erros
+	if os.Getenv("LOG_LEVEL") == "" && !*verbose {
+		os.Setenv("LOG_LEVEL", "warn")
+	}
+	logger.InitLogger()
+
+	if !utils.IsSupportedInterval(*interval) {
+		log.Fatalf("Intervalo inválido: %s", *interval)
+	}
+	if *csvInterval == "" {
+		*csvInterval = marketdata.SourceInterval(*interval)
+	}
+
+	var strategyParams map[string]any
+	if *params != "" {
+		if err := json.Unmarshal([]byte(*params), &strategyParams); err != nil {
+			log.Fatalf("Parâmetros inválidos: %v", err)
+		}
+	}
+
+	var (
+		candles    []entity.Candle
+		symbolInfo *entity.SymbolInfo
+		err        error
+	)
+	if *csvFiles != "" {
+		candles, err = backtest.LoadAggregatedCSV(*csvInterval, *interval, strings.Split(*csvFiles, ",")...)
+		if err != nil {
+			log.Fatalf("Erro ao ler candles: %v", err)
+		}
+	} else {
+		candles, symbolInfo, err = fetchCandles(*symbol, *interval, *from, *to)
+		if err != nil {
+			log.Fatalf("Erro ao baixar candles da Binance: %v", err)
+		}
+	}
+
+	result, err := backtest.Run(backtest.Config{
+		Symbol:         *symbol,
+		Interval:       *interval,
+		Strategy:       *strategy,
+		Params:         strategyParams,
+		InitialBalance: *balance,
+		Fee:            *fee,
+		Slippage:       *slippage,
+		WindowSize:     *window,
+		SymbolInfo:     symbolInfo,
+	}, candles)
+	if err != nil {
+		log.Fatalf("Erro no backtest: %v", err)
+	}
+
+	if *out != "" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Fatalf("Erro ao serializar resultado: %v", err)
+		}
+		if err := os.WriteFile(*out, data, 0o644); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+	}
+
+	fmt.Print(result.Summary())
+	if *out != "" {
+		fmt.Printf("   Resultado completo em %s\n", *out)
+	}
+}
+
+// fetchCandles baixa os candles fechados do período e os filtros do símbolo na Binance.
+func fetchCandles(symbol, interval, from, to string) ([]entity.Candle, *entity.SymbolInfo, error) {
+	if from == "" {
+		return nil, nil, fmt.Errorf("informe -csv ou -from")
+	}
+	start, err := utils.ParseDate(from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data inicial inválida: %w", err)
+	}
+	end := time.Now()
+	if to != "" {
+		if end, err = utils.ParseDate(to); err != nil {
+			return nil, nil, fmt.Errorf("data final inválida: %w", err)
+		}
+		end = end.Add(24*time.Hour - time.Millisecond) // inclui o dia informado
+	}
+
+	return backtest.FetchCandles(binance.NewClientFactory().Public(), symbol, interval, start, end)
+}
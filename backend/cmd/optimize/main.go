@@ -0,0 +1,315 @@
+// cmd/optimize/main.go
+
+// optimize busca os melhores parâmetros de uma estratégia (grade ou busca aleatória, em
+// paralelo) reproduzindo candles históricos pelo backtest. Com -is/-oos a busca é validada
+// por walk-forward. O ranking é gravado em JSON; o campo "config" de cada combinação pode
+// ser usado diretamente como config do bot, e -save-bot grava a melhor em bot_configs.
+//
+// Exemplo de espaço de busca (space.json):
+//
+//	{
+//	  "params": {
+//	    "ma_short": {"min": 5, "max": 20, "step": 1},
+//	    "ma_long": {"values": [26, 50, 100]},
+//	    "stop_atr_multiplier": {"min": 1, "max": 3, "step": 0.5}
+//	  },
+//	  "constraints": ["ma_short < ma_long"]
+//	}
+//
+// Exemplos:
+//
+//	go run ./cmd/optimize -symbol BTC/USDT -interval 1h -space space.json -from 2024-01-01 -objective sharpe
+//	go run ./cmd/optimize -csv BTCUSDT-1h-2024.csv -space space.json -method random -samples 200 \
+//	    -is 2000 -oos 500 -min-trades 5 -save-bot <bot_id>
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/backtest"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/config"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/database"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/repository/postgres"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/binance"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// optimizeOutput é o conteúdo do arquivo JSON gerado.
+type optimizeOutput struct {
+	Symbol      string                      `json:"symbol"`
+	Interval    string                      `json:"interval"`
+	Strategy    string                      `json:"strategy"`
+	Objective   backtest.Objective          `json:"objective"`
+	Method      string                      `json:"method"`
+	Candles     int                         `json:"candles"`
+	Start       int64                       `json:"start"` // ms
+	End         int64                       `json:"end"`   // ms
+	Ranking     []backtest.Candidate        `json:"ranking"`
+	WalkForward *backtest.WalkForwardResult `json:"walk_forward,omitempty"`
+}
+
+func main() {
+	symbol := flag.String("symbol", "BTC/USDT", "par no formato BASE/QUOTE")
+	interval := flag.String("interval", "1h", "intervalo dos candles")
+	strategy := flag.String("strategy", "EvaluateCrossover", "estratégia registrada")
+	params := flag.String("params", "", "parâmetros fixos em JSON (aplicados a todas as combinações)")
+	spaceArg := flag.String("space", "", "espaço de busca: arquivo JSON ou JSON inline")
+	method := flag.String("method", backtest.SearchGrid, "método de busca: grid ou random")
+	samples := flag.Int("samples", 100, "combinações sorteadas na busca aleatória")
+	seed := flag.Int64("seed", 0, "semente da busca aleatória (0 = aleatória)")
+	objective := flag.String("objective", string(backtest.ObjectiveNetPnL), "métrica a maximizar: net_pnl_pct, sharpe, sortino, profit_factor, calmar")
+	minTrades := flag.Int("min-trades", 1, "descarta combinações com menos trades")
+	workers := flag.Int("workers", 0, "backtests em paralelo (0 = número de CPUs)")
+	inSample := flag.Int("is", 0, "walk-forward: candles in-sample por fold (0 = sem walk-forward)")
+	outOfSample := flag.Int("oos", 0, "walk-forward: candles out-of-sample por fold")
+	anchored := flag.Bool("anchored", false, "walk-forward: in-sample sempre a partir do primeiro candle")
+	top := flag.Int("top", 5, "walk-forward: melhores combinações in-sample validadas por fold")
+	csvFiles := flag.String("csv", "", "arquivos CSV de klines separados por vírgula (em vez da Binance)")
+	csvInterval := flag.String("csv-interval", "", "intervalo dos candles nos CSV, agregados em -interval (padrão: -interval, ou 1m se agregado)")
+	from := flag.String("from", "", "início do período na Binance (YYYY-MM-DD)")
+	to := flag.String("to", "", "fim do período na Binance (YYYY-MM-DD, padrão: agora)")
+	limit := flag.Int("limit", 1000, "sem -from: últimos candles da Binance (máximo 1000)")
+	balance := flag.Float64("balance", 10000, "saldo inicial em moeda de cotação")
+	fee := flag.Float64("fee", 0.001, "taxa por execução (0.001 = 0,1%)")
+	slippage := flag.Float64("slippage", 0.0005, "slippage das ordens a mercado (0.0005 = 0,05%)")
+	window := flag.Int("window", backtest.DefaultWindowSize, "janela de candles da estratégia")
+	show := flag.Int("show", 10, "combinações exibidas no terminal")
+	out := flag.String("out", "optimize.json", "arquivo JSON de saída (vazio para não gravar)")
+	saveBot := flag.String("save-bot", "", "ID do bot que recebe a melhor combinação em bot_configs")
+	flag.Parse()
+
+	if os.Getenv("LOG_LEVEL") == "" {
+		os.Setenv("LOG_LEVEL", "warn")
+	}
+	logger.InitLogger()
+
+	space, err := loadSpace(*spaceArg)
+	if err != nil {
+		log.Fatalf("Espaço de busca inválido: %v", err)
+	}
+
+	var fixedParams map[string]any
+	if *params != "" {
+		if err := json.Unmarshal([]byte(*params), &fixedParams); err != nil {
+			log.Fatalf("Parâmetros inválidos: %v", err)
+		}
+	}
+
+	candles, symbolInfo, err := loadCandles(*symbol, *interval, *csvFiles, *csvInterval, *from, *to, *limit)
+	if err != nil {
+		log.Fatalf("Erro ao carregar candles: %v", err)
+	}
+	if len(candles) == 0 {
+		log.Fatalf("Nenhum candle para otimizar")
+	}
+
+	cfg := backtest.OptimizeConfig{
+		Base: backtest.Config{
+			Symbol:         *symbol,
+			Interval:       *interval,
+			Strategy:       *strategy,
+			Params:         fixedParams,
+			InitialBalance: *balance,
+			Fee:            *fee,
+			Slippage:       *slippage,
+			WindowSize:     *window,
+			SymbolInfo:     symbolInfo,
+		},
+		Space:     space,
+		Method:    *method,
+		Samples:   *samples,
+		Seed:      *seed,
+		Objective: backtest.Objective(*objective),
+		MinTrades: *minTrades,
+		Workers:   *workers,
+	}
+
+	output := optimizeOutput{
+		Symbol:    *symbol,
+		Interval:  *interval,
+		Strategy:  *strategy,
+		Objective: cfg.Objective,
+		Method:    *method,
+		Candles:   len(candles),
+		Start:     candles[0].Time * 1000,
+		End:       candles[len(candles)-1].Time * 1000,
+	}
+
+	started := time.Now()
+	if *inSample > 0 {
+		wf, err := backtest.WalkForward(cfg, backtest.WalkForwardConfig{
+			InSample:    *inSample,
+			OutOfSample: *outOfSample,
+			Anchored:    *anchored,
+			Top:         *top,
+		}, candles)
+		if err != nil {
+			log.Fatalf("Erro no walk-forward: %v", err)
+		}
+		output.WalkForward = wf
+		output.Ranking = wf.Ranking
+	} else {
+		output.Ranking, err = backtest.Optimize(cfg, candles)
+		if err != nil {
+			log.Fatalf("Erro na otimização: %v", err)
+		}
+	}
+
+	if *out != "" {
+		data, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			log.Fatalf("Erro ao serializar resultado: %v", err)
+		}
+		if err := os.WriteFile(*out, data, 0o644); err != nil {
+			log.Fatalf("Erro ao gravar %s: %v", *out, err)
+		}
+	}
+
+	printSummary(output, time.Since(started), *show)
+	if *out != "" {
+		fmt.Printf("Resultado completo em %s\n", *out)
+	}
+
+	if *saveBot != "" {
+		if len(output.Ranking) == 0 {
+			log.Fatalf("Nenhuma combinação para gravar no bot")
+		}
+		if err := saveBestConfig(*saveBot, output.Ranking[0].Config); err != nil {
+			log.Fatalf("Erro ao gravar configuração do bot: %v", err)
+		}
+		fmt.Printf("✅ Melhor combinação gravada em bot_configs do bot %s (o supervisor aplica a quente em até BOT_CONFIG_WATCH_INTERVAL, padrão 15s)\n", *saveBot)
+	}
+}
+
+// loadSpace lê o espaço de busca de um arquivo ou de JSON inline.
+func loadSpace(arg string) (backtest.Space, error) {
+	var space backtest.Space
+	if arg == "" {
+		return space, fmt.Errorf("informe -space")
+	}
+	data := []byte(arg)
+	if !strings.HasPrefix(strings.TrimSpace(arg), "{") {
+		var err error
+		if data, err = os.ReadFile(arg); err != nil {
+			return space, err
+		}
+	}
+	if err := json.Unmarshal(data, &space); err != nil {
+		return space, err
+	}
+	return space, space.Validate()
+}
+
+// loadCandles lê os candles de CSV, de um período na Binance ou dos últimos candles da Binance.
+func loadCandles(symbol, interval, csvFiles, csvInterval, from, to string, limit int) ([]entity.Candle, *entity.SymbolInfo, error) {
+	barDuration, ok := utils.IntervalDuration(interval)
+	if !ok {
+		return nil, nil, fmt.Errorf("intervalo inválido: %s", interval)
+	}
+	if csvFiles != "" {
+		if csvInterval == "" {
+			csvInterval = marketdata.SourceInterval(interval)
+		}
+		candles, err := backtest.LoadAggregatedCSV(csvInterval, interval, strings.Split(csvFiles, ",")...)
+		return candles, nil, err
+	}
+
+	market := binance.NewClientFactory().Public()
+	if from == "" && utils.IsAggregatedInterval(interval) {
+		// Intervalos agregados exigem limit x (intervalo / 1m) candles de 1m: busca paginada
+		end := time.Now()
+		return backtest.FetchCandles(market, symbol, interval, end.Add(-time.Duration(limit+1)*barDuration), end)
+	}
+	if from == "" {
+		binanceSymbol := utils.FormatForBinance(symbol)
+		info, err := market.GetSymbolInfo(binanceSymbol)
+		if err != nil {
+			return nil, nil, err
+		}
+		candles, err := market.GetHistoricalCandles(binanceSymbol, interval, limit)
+		if err != nil {
+			return nil, nil, err
+		}
+		// O último candle retornado ainda está em formação
+		if n := len(candles); n > 0 && candles[n-1].Time*1000 >= time.Now().UnixMilli() {
+			candles = candles[:n-1]
+		}
+		return candles, info, nil
+	}
+
+	start, err := utils.ParseDate(from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("data inicial inválida: %w", err)
+	}
+	end := time.Now()
+	if to != "" {
+		if end, err = utils.ParseDate(to); err != nil {
+			return nil, nil, fmt.Errorf("data final inválida: %w", err)
+		}
+		end = end.Add(24*time.Hour - time.Millisecond) // inclui o dia informado
+	}
+	return backtest.FetchCandles(market, symbol, interval, start, end)
+}
+
+// saveBestConfig grava uma nova versão de bot_configs com a combinação, preservando as
+// demais chaves da configuração atual (ex: trading_mode).
+func saveBestConfig(rawBotID string, params map[string]any) error {
+	botID, err := uuid.Parse(rawBotID)
+	if err != nil {
+		return fmt.Errorf("ID de bot inválido: %w", err)
+	}
+
+	config.LoadEnv(".env")
+	pool, err := database.NewPostgresPool()
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	repo := postgres.NewBotConfigRepository(pool)
+	current, err := repo.GetLatest(botID)
+	if err != nil {
+		return err
+	}
+	var currentConfig map[string]any
+	if current != nil {
+		currentConfig = current.Config
+	}
+
+	_, err = repo.Save(entity.BotConfig{BotID: botID, Config: usecases.MergeParams(currentConfig, params)})
+	return err
+}
+
+func printSummary(output optimizeOutput, elapsed time.Duration, show int) {
+	fmt.Printf("🔎 Otimização %s — %s %s | objetivo %s | %d candles | %s\n",
+		output.Strategy, output.Symbol, output.Interval, output.Objective, output.Candles, elapsed.Round(time.Millisecond))
+
+	if wf := output.WalkForward; wf != nil {
+		for _, fold := range wf.Folds {
+			if fold.Skipped {
+				fmt.Printf("   Fold %d: ignorado (%s)\n", fold.Index, fold.Reason)
+				continue
+			}
+			fmt.Printf("   Fold %d: in-sample %.3f → out-of-sample %+.2f%% (%d trades, eficiência %.2f)\n",
+				fold.Index, fold.Best.Score, fold.OutOfSample.NetPnLPct, fold.OutOfSample.Trades, fold.Efficiency)
+		}
+		fmt.Printf("   Retorno out-of-sample composto: %+.2f%%\n", wf.OutOfSampleReturnPct)
+	}
+
+	if len(output.Ranking) == 0 {
+		fmt.Println("   Nenhuma combinação atingiu o mínimo de trades")
+		return
+	}
+	fmt.Print(backtest.RankingTable(output.Ranking, show))
+}
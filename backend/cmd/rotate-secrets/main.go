@@ -0,0 +1,47 @@
+// cmd/rotate-secrets/main.go
+
+// rotate-secrets recifra os segredos das contas com a chave mestra ativa.
+//
+// Para rotacionar a chave mestra:
+//  1. mova a chave atual para SECRETS_PREVIOUS_KEYS (ex: "v1:<base64>");
+//  2. configure a nova chave em SECRETS_MASTER_KEY e o novo ID em SECRETS_MASTER_KEY_ID;
+//  3. execute: go run ./cmd/rotate-secrets
+//  4. depois de reiniciar a API com a nova chave, remova a chave antiga de SECRETS_PREVIOUS_KEYS.
+//
+// Segredos legados em texto puro também são cifrados.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/config"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/database"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/repository/postgres"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/secrets"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+)
+
+func main() {
+	logger.InitLogger()
+	config.LoadEnv(".env")
+
+	cipher, err := secrets.NewCipherFromEnv()
+	if err != nil {
+		log.Fatalf("Erro ao carregar chave mestra de segredos: %v", err)
+	}
+
+	pool, err := database.NewPostgresPool()
+	if err != nil {
+		log.Fatalf("Erro ao conectar no PostgreSQL: %v", err)
+	}
+	defer pool.Close()
+
+	accountRepo := postgres.NewAccountRepository(pool, cipher)
+	rotated, err := accountRepo.RotateSecrets(context.Background())
+	if err != nil {
+		log.Fatalf("Erro ao recifrar segredos (%d contas já atualizadas): %v", rotated, err)
+	}
+
+	logger.Info("🔐 Segredos recifrados", "contas", rotated, "key_id", cipher.ActiveKeyID())
+}
@@ -0,0 +1,122 @@
+// internal/app/exits/exits.go
+
+// Package exits implementa a política de saída de posições compradas (long), reutilizável
+// por qualquer estratégia: stop-loss e take-profit percentuais, stop por ATR, trailing stop
+// (chandelier ou percentual) sobre a máxima desde a entrada, break-even e tempo máximo.
+package exits
+
+import (
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// Reason identifica o motivo de encerramento de uma posição (registrado na execução).
+type Reason string
+
+const (
+	ReasonSignal       Reason = "signal"        // sinal de saída da própria estratégia
+	ReasonStopLoss     Reason = "stop_loss"     // stop percentual fixo abaixo da entrada
+	ReasonATRStop      Reason = "atr_stop"      // stop a N ATRs abaixo da entrada
+	ReasonTrailingStop Reason = "trailing_stop" // stop móvel sobre a máxima desde a entrada
+	ReasonBreakEven    Reason = "break_even"    // stop movido para o preço de entrada
+	ReasonTakeProfit   Reason = "take_profit"   // alvo percentual acima da entrada
+	ReasonMaxHolding   Reason = "max_holding"   // tempo máximo de permanência na posição
+	ReasonKillSwitch   Reason = "kill_switch"   // encerramento forçado pelo kill switch da conta
+)
+
+// Policy reúne as regras de saída. Valores zerados desativam a regra correspondente.
+type Policy struct {
+	StopLossPct           float64       // stop a X% abaixo da entrada
+	TakeProfitPct         float64       // alvo a X% acima da entrada
+	ATRStopMultiplier     float64       // stop a N × ATR abaixo da entrada
+	TrailingATRMultiplier float64       // chandelier: máxima desde a entrada − N × ATR
+	TrailingPct           float64       // trailing percentual: máxima desde a entrada − X%
+	BreakEvenTriggerPct   float64       // lucro (sobre a máxima) que move o stop para a entrada
+	BreakEvenOffsetPct    float64       // stop do break-even a X% acima da entrada (cobre taxas)
+	MaxHolding            time.Duration // tempo máximo com a posição aberta
+}
+
+// State é o acompanhamento da posição aberta, persistido junto com ela.
+type State struct {
+	EntryPrice   float64 // preço médio de entrada
+	EntryTime    int64   // timestamp da entrada (ms)
+	HighestPrice float64 // máxima desde a entrada
+	StopPrice    float64 // stop vigente (0 = sem stop)
+	StopReason   Reason  // regra que definiu o stop vigente
+}
+
+// Enabled informa se alguma regra está configurada.
+func (p Policy) Enabled() bool {
+	return p.StopLossPct > 0 || p.TakeProfitPct > 0 || p.ATRStopMultiplier > 0 ||
+		p.TrailingATRMultiplier > 0 || p.TrailingPct > 0 || p.BreakEvenTriggerPct > 0 || p.MaxHolding > 0
+}
+
+// Open inicia o acompanhamento de uma posição, com o stop inicial definido pela regra
+// mais próxima da entrada entre o stop percentual e o stop por ATR.
+func (p Policy) Open(entryPrice float64, entryTime int64, atr float64) State {
+	state := State{EntryPrice: entryPrice, EntryTime: entryTime, HighestPrice: entryPrice}
+	if p.StopLossPct > 0 {
+		state.raiseStop(entryPrice*(1-p.StopLossPct/100), ReasonStopLoss)
+	}
+	if p.ATRStopMultiplier > 0 && atr > 0 {
+		state.raiseStop(entryPrice-atr*p.ATRStopMultiplier, ReasonATRStop)
+	}
+	return state
+}
+
+// Update registra a máxima do candle e sobe o stop conforme trailing e break-even.
+// O stop nunca desce. Retorna true se o estado mudou (e precisa ser persistido).
+func (p Policy) Update(state *State, high, atr float64) bool {
+	changed := false
+	if high > state.HighestPrice {
+		state.HighestPrice = high
+		changed = true
+	}
+
+	if p.BreakEvenTriggerPct > 0 && state.HighestPrice >= state.EntryPrice*(1+p.BreakEvenTriggerPct/100) {
+		changed = state.raiseStop(state.EntryPrice*(1+p.BreakEvenOffsetPct/100), ReasonBreakEven) || changed
+	}
+	if p.TrailingATRMultiplier > 0 && atr > 0 {
+		changed = state.raiseStop(state.HighestPrice-atr*p.TrailingATRMultiplier, ReasonTrailingStop) || changed
+	}
+	if p.TrailingPct > 0 {
+		changed = state.raiseStop(state.HighestPrice*(1-p.TrailingPct/100), ReasonTrailingStop) || changed
+	}
+	return changed
+}
+
+// TakeProfitPrice retorna o alvo da posição (0 = sem alvo).
+func (p Policy) TakeProfitPrice(state State) float64 {
+	if p.TakeProfitPct <= 0 {
+		return 0
+	}
+	return state.EntryPrice * (1 + p.TakeProfitPct/100)
+}
+
+// Check avalia as regras de saída para o candle fechado. O stop é atingido pela mínima e o
+// alvo pela máxima do candle; com os dois no mesmo candle vale o stop (não se sabe qual veio
+// antes). Retorna o motivo, o preço de saída (o nível atingido, ou a abertura se o candle
+// abriu além dele; o fechamento no tempo máximo) e true se a posição deve ser encerrada.
+func (p Policy) Check(state State, candle entity.Candle, timestamp int64) (Reason, float64, bool) {
+	if state.StopPrice > 0 && candle.Low <= state.StopPrice {
+		return state.StopReason, min(candle.Open, state.StopPrice), true
+	}
+	if tp := p.TakeProfitPrice(state); tp > 0 && candle.High >= tp {
+		return ReasonTakeProfit, max(candle.Open, tp), true
+	}
+	if p.MaxHolding > 0 && timestamp-state.EntryTime >= p.MaxHolding.Milliseconds() {
+		return ReasonMaxHolding, candle.Close, true
+	}
+	return "", 0, false
+}
+
+// raiseStop sobe o stop para price, se ele for maior que o vigente.
+func (s *State) raiseStop(price float64, reason Reason) bool {
+	if price <= s.StopPrice {
+		return false
+	}
+	s.StopPrice = price
+	s.StopReason = reason
+	return true
+}
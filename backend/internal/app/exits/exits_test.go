@@ -0,0 +1,105 @@
+// internal/app/exits/exits_test.go
+
+package exits_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// bar monta um candle com abertura open e extremos low/high, fechando em close.
+func bar(open, high, low, close float64) entity.Candle {
+	return entity.Candle{Open: open, High: high, Low: low, Close: close}
+}
+
+func TestATRStopIsBelowEntry(t *testing.T) {
+	policy := exits.Policy{ATRStopMultiplier: 1.5}
+	state := policy.Open(100, 0, 2)
+
+	assert.Equal(t, 97.0, state.StopPrice)
+	assert.Equal(t, exits.ReasonATRStop, state.StopReason)
+
+	_, _, hit := policy.Check(state, bar(100, 101, 99, 99), 0)
+	assert.False(t, hit)
+
+	// A mínima toca o stop mesmo com o fechamento acima dele; a saída é no stop
+	reason, price, hit := policy.Check(state, bar(99, 99.5, 96.5, 98.5), 0)
+	assert.True(t, hit)
+	assert.Equal(t, exits.ReasonATRStop, reason)
+	assert.Equal(t, 97.0, price)
+
+	// Abertura abaixo do stop (gap): a saída é na abertura
+	_, price, _ = policy.Check(state, bar(95, 96, 94, 95.5), 0)
+	assert.Equal(t, 95.0, price)
+}
+
+func TestInitialStopUsesTightestRule(t *testing.T) {
+	policy := exits.Policy{StopLossPct: 2, ATRStopMultiplier: 1}
+	state := policy.Open(100, 0, 5)
+
+	assert.Equal(t, 98.0, state.StopPrice)
+	assert.Equal(t, exits.ReasonStopLoss, state.StopReason)
+}
+
+func TestChandelierStopTracksHighestAndNeverMovesDown(t *testing.T) {
+	policy := exits.Policy{TrailingATRMultiplier: 3}
+	state := policy.Open(100, 0, 1)
+	assert.Equal(t, 0.0, state.StopPrice)
+
+	assert.True(t, policy.Update(&state, 110, 2))
+	assert.Equal(t, 110.0, state.HighestPrice)
+	assert.Equal(t, 104.0, state.StopPrice)
+	assert.Equal(t, exits.ReasonTrailingStop, state.StopReason)
+
+	// ATR maior com máxima menor não baixa o stop
+	assert.False(t, policy.Update(&state, 105, 4))
+	assert.Equal(t, 104.0, state.StopPrice)
+
+	reason, _, hit := policy.Check(state, bar(105, 105, 103.5, 104.5), 0)
+	assert.True(t, hit)
+	assert.Equal(t, exits.ReasonTrailingStop, reason)
+}
+
+func TestBreakEvenMovesStopToEntry(t *testing.T) {
+	policy := exits.Policy{StopLossPct: 5, BreakEvenTriggerPct: 2, BreakEvenOffsetPct: 0.2}
+	state := policy.Open(100, 0, 0)
+
+	policy.Update(&state, 101, 0)
+	assert.Equal(t, exits.ReasonStopLoss, state.StopReason)
+
+	policy.Update(&state, 102.5, 0)
+	assert.InDelta(t, 100.2, state.StopPrice, 1e-9)
+	assert.Equal(t, exits.ReasonBreakEven, state.StopReason)
+}
+
+func TestTakeProfitAndMaxHolding(t *testing.T) {
+	policy := exits.Policy{TakeProfitPct: 3, MaxHolding: time.Hour}
+	state := policy.Open(100, 0, 0)
+
+	reason, price, hit := policy.Check(state, bar(101, 103.2, 100.5, 102), 0)
+	assert.True(t, hit)
+	assert.Equal(t, exits.ReasonTakeProfit, reason)
+	assert.Equal(t, 103.0, price)
+
+	_, _, hit = policy.Check(state, bar(101, 102, 100, 101), time.Hour.Milliseconds()-1)
+	assert.False(t, hit)
+
+	reason, price, hit = policy.Check(state, bar(101, 102, 100, 101), time.Hour.Milliseconds())
+	assert.True(t, hit)
+	assert.Equal(t, exits.ReasonMaxHolding, reason)
+	assert.Equal(t, 101.0, price)
+}
+
+func TestStopWinsWhenCandleHitsStopAndTarget(t *testing.T) {
+	policy := exits.Policy{StopLossPct: 2, TakeProfitPct: 2}
+	state := policy.Open(100, 0, 0)
+
+	reason, price, hit := policy.Check(state, bar(100, 103, 97, 101), 0)
+	assert.True(t, hit)
+	assert.Equal(t, exits.ReasonStopLoss, reason)
+	assert.Equal(t, 98.0, price)
+}
@@ -0,0 +1,119 @@
+// internal/app/indicators/bands.go
+
+package indicators
+
+import (
+	"math"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// Bands são as linhas de um canal em torno do preço, alinhadas aos candles.
+type Bands struct {
+	Upper  []float64
+	Middle []float64
+	Lower  []float64
+}
+
+// Bollinger configura as Bandas de Bollinger: SMA de Period fechamentos ± Multiplier desvios
+// padrão (populacionais).
+type Bollinger struct {
+	Period     int
+	Multiplier float64
+}
+
+// BollingerBands são as bandas com o %B ((close - lower) / (upper - lower)) e a largura
+// relativa ((upper - lower) / middle).
+type BollingerBands struct {
+	Bands
+	PercentB  []float64
+	Bandwidth []float64
+}
+
+// NewBollinger cria as Bandas de Bollinger com os parâmetros usuais (20, 2).
+func NewBollinger() Bollinger {
+	return Bollinger{Period: 20, Multiplier: 2}
+}
+
+func (b Bollinger) Warmup() int { return b.Period }
+
+func (b Bollinger) Calculate(candles []entity.Candle) BollingerBands {
+	_, _, closes, _ := splitCandles(candles)
+	middle := SMASeries(closes, b.Period)
+	stddev := StdDevSeries(closes, b.Period)
+
+	out := BollingerBands{
+		Bands:     Bands{Upper: nanSeries(len(closes)), Middle: middle, Lower: nanSeries(len(closes))},
+		PercentB:  nanSeries(len(closes)),
+		Bandwidth: nanSeries(len(closes)),
+	}
+	for i := range closes {
+		if math.IsNaN(middle[i]) {
+			continue
+		}
+		upper, lower := middle[i]+b.Multiplier*stddev[i], middle[i]-b.Multiplier*stddev[i]
+		out.Upper[i], out.Lower[i] = upper, lower
+		out.PercentB[i] = 0.5 // bandas sem largura: preço no meio
+		if width := upper - lower; width != 0 {
+			out.PercentB[i] = (closes[i] - lower) / width
+		}
+		if middle[i] != 0 {
+			out.Bandwidth[i] = (upper - lower) / middle[i]
+		}
+	}
+	return out
+}
+
+// Keltner configura o Canal de Keltner: EMA de Period fechamentos ± Multiplier ATRs de ATRPeriod.
+type Keltner struct {
+	Period     int
+	ATRPeriod  int
+	Multiplier float64
+}
+
+// NewKeltner cria o Canal de Keltner com os parâmetros usuais (20, 10, 2).
+func NewKeltner() Keltner {
+	return Keltner{Period: 20, ATRPeriod: 10, Multiplier: 2}
+}
+
+func (k Keltner) Warmup() int { return max(k.Period, k.ATRPeriod+1) }
+
+func (k Keltner) Calculate(candles []entity.Candle) Bands {
+	highs, lows, closes, _ := splitCandles(candles)
+	middle := EMASeries(closes, k.Period)
+	atr := ATRSeries(highs, lows, closes, k.ATRPeriod)
+
+	out := Bands{Upper: nanSeries(len(closes)), Middle: middle, Lower: nanSeries(len(closes))}
+	for i := range closes {
+		if math.IsNaN(atr[i]) {
+			// O canal só fica pronto quando a média e o ATR têm valor
+			out.Middle[i] = math.NaN()
+			continue
+		}
+		out.Upper[i] = middle[i] + k.Multiplier*atr[i]
+		out.Lower[i] = middle[i] - k.Multiplier*atr[i]
+	}
+	return out
+}
+
+// Donchian configura o Canal de Donchian: máxima e mínima de Period candles, incluindo o atual
+// (para rompimentos, compare o fechamento com o canal do candle anterior).
+type Donchian struct {
+	Period int
+}
+
+// NewDonchian cria o Canal de Donchian de 20 candles.
+func NewDonchian() Donchian {
+	return Donchian{Period: 20}
+}
+
+func (d Donchian) Warmup() int { return d.Period }
+
+func (d Donchian) Calculate(candles []entity.Candle) Bands {
+	highs, lows, _, _ := splitCandles(candles)
+	return Bands{
+		Upper:  HighestSeries(highs, d.Period),
+		Middle: midpointSeries(highs, lows, d.Period),
+		Lower:  LowestSeries(lows, d.Period),
+	}
+}
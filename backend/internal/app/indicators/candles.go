@@ -0,0 +1,77 @@
+// internal/app/indicators/candles.go
+
+package indicators
+
+import "github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+
+// CandleIndicator é um indicador calculado sobre candles. Warmup declara quantos candles ele
+// precisa para produzir o primeiro valor: as séries retornadas têm NaN nas primeiras
+// Warmup()-1 posições.
+type CandleIndicator interface {
+	Warmup() int
+}
+
+// Ready indica se candles são suficientes para o indicador produzir valor no candle mais recente.
+func Ready(indicator CandleIndicator, candles int) bool {
+	return candles >= indicator.Warmup()
+}
+
+// splitCandles separa máximos, mínimos, fechamentos e volumes dos candles.
+func splitCandles(candles []entity.Candle) (highs, lows, closes, volumes []float64) {
+	highs = make([]float64, len(candles))
+	lows = make([]float64, len(candles))
+	closes = make([]float64, len(candles))
+	volumes = make([]float64, len(candles))
+	for i, c := range candles {
+		highs[i], lows[i], closes[i], volumes[i] = c.High, c.Low, c.Close, c.Volume
+	}
+	return highs, lows, closes, volumes
+}
+
+// HighestSeries calcula o maior valor dos últimos period valores (incluindo o atual).
+func HighestSeries(values []float64, period int) []float64 {
+	return extremeSeries(values, period, func(a, b float64) bool { return a > b })
+}
+
+// LowestSeries calcula o menor valor dos últimos period valores (incluindo o atual).
+func LowestSeries(values []float64, period int) []float64 {
+	return extremeSeries(values, period, func(a, b float64) bool { return a < b })
+}
+
+// extremeSeries mantém uma fila monotônica com os índices da janela que ainda podem vir a ser
+// o extremo (beats(a, b): a supera b). Cada índice entra e sai da fila uma vez, então a série
+// custa O(n) qualquer que seja period.
+func extremeSeries(values []float64, period int, beats func(a, b float64) bool) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if period < 1 || len(values)-start < period {
+		return out
+	}
+	queue := make([]int, 0, len(values)-start)
+	head := 0
+	for i := start; i < len(values); i++ {
+		// Valores superados pelo atual nunca mais serão o extremo
+		for len(queue) > head && !beats(values[queue[len(queue)-1]], values[i]) {
+			queue = queue[:len(queue)-1]
+		}
+		queue = append(queue, i)
+		if queue[head] <= i-period {
+			head++
+		}
+		if i >= start+period-1 {
+			out[i] = values[queue[head]]
+		}
+	}
+	return out
+}
+
+// midpointSeries calcula o ponto médio entre a máxima e a mínima de period candles
+// (usado pelas linhas do Ichimoku e pelo canal de Donchian).
+func midpointSeries(highs, lows []float64, period int) []float64 {
+	highest, lowest := HighestSeries(highs, period), LowestSeries(lows, period)
+	out := make([]float64, len(highest))
+	for i := range out {
+		out[i] = (highest[i] + lowest[i]) / 2
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+// internal/app/indicators/candles_test.go
+
+package indicators_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// trendCandles gera candles de 1h a partir de 00:00 UTC com o fechamento variando step por
+// candle, amplitude de 1 e volume 10.
+func trendCandles(start, step float64, n int) []entity.Candle {
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	candles := make([]entity.Candle, n)
+	for i := range candles {
+		price := start + step*float64(i) + math.Sin(float64(i))*0.3
+		open := base.Add(time.Duration(i) * time.Hour).UnixMilli()
+		candles[i] = entity.Candle{
+			Open:      price - step/2,
+			High:      price + 0.5,
+			Low:       price - 0.5,
+			Close:     price,
+			Volume:    10,
+			OpenTime:  open,
+			CloseTime: open + time.Hour.Milliseconds() - 1,
+		}
+	}
+	return candles
+}
+
+func TestCandleIndicatorsDeclareWarmup(t *testing.T) {
+	candles := trendCandles(100, 0.2, 150)
+	cases := []struct {
+		name      string
+		indicator indicators.CandleIndicator
+		series    []float64
+	}{
+		{"bollinger", indicators.NewBollinger(), indicators.NewBollinger().Calculate(candles).PercentB},
+		{"keltner", indicators.NewKeltner(), indicators.NewKeltner().Calculate(candles).Upper},
+		{"donchian", indicators.NewDonchian(), indicators.NewDonchian().Calculate(candles).Middle},
+		{"stochastic", indicators.NewStochastic(), indicators.NewStochastic().Calculate(candles).D},
+		{"stochastic_rsi", indicators.NewStochasticRSI(), indicators.NewStochasticRSI().Calculate(candles).D},
+		{"adx", indicators.NewDMI(), indicators.NewDMI().Calculate(candles).ADX},
+		{"supertrend", indicators.NewSupertrend(), indicators.NewSupertrend().Calculate(candles).Value},
+		{"ichimoku", indicators.NewIchimoku(), indicators.NewIchimoku().Calculate(candles).SenkouB},
+		{"obv", indicators.OBV{}, indicators.OBV{}.Calculate(candles)},
+		{"session_vwap", indicators.NewSessionVWAP(), indicators.NewSessionVWAP().Calculate(candles)},
+		{"rolling_vwap", indicators.RollingVWAP{Period: 20}, indicators.RollingVWAP{Period: 20}.Calculate(candles)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Len(t, tc.series, len(candles))
+			warmup := tc.indicator.Warmup()
+			for i := 0; i < warmup-1; i++ {
+				assert.True(t, math.IsNaN(tc.series[i]), "posição %d antes do aquecimento", i)
+			}
+			assert.False(t, math.IsNaN(tc.series[warmup-1]), "primeiro valor no candle %d", warmup)
+			assert.False(t, indicators.Ready(tc.indicator, warmup-1))
+			assert.True(t, indicators.Ready(tc.indicator, warmup))
+		})
+	}
+}
+
+func TestBollingerBandsAroundSMA(t *testing.T) {
+	candles := trendCandles(100, 0.1, 60)
+	bands := indicators.NewBollinger().Calculate(candles)
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+
+	last := len(candles) - 1
+	assert.InDelta(t, indicators.MovingAverage(closes, 20), bands.Middle[last], 1e-9)
+	assert.InDelta(t, bands.Upper[last]-bands.Middle[last], bands.Middle[last]-bands.Lower[last], 1e-9)
+	assert.InDelta(t, (closes[last]-bands.Lower[last])/(bands.Upper[last]-bands.Lower[last]), bands.PercentB[last], 1e-9)
+	assert.Greater(t, bands.Bandwidth[last], 0.0)
+}
+
+func TestTrendIndicatorsFollowDirection(t *testing.T) {
+	up := trendCandles(100, 1, 80)
+	down := trendCandles(180, -1, 80)
+	last := 79
+
+	dmi := indicators.NewDMI().Calculate(up)
+	assert.Greater(t, dmi.PlusDI[last], dmi.MinusDI[last])
+	assert.Greater(t, dmi.ADX[last], 25.0)
+
+	stochastic := indicators.NewStochastic().Calculate(up)
+	assert.Greater(t, stochastic.K[last], 80.0)
+
+	assert.Equal(t, 1.0, indicators.NewSupertrend().Calculate(up).Direction[last])
+	assert.Equal(t, -1.0, indicators.NewSupertrend().Calculate(append(up, down...)).Direction[2*last+1])
+
+	obv := indicators.OBV{}.Calculate(down)
+	assert.Less(t, obv[last], obv[last-10])
+}
+
+func TestHighestLowestMatchBruteForce(t *testing.T) {
+	values := []float64{math.NaN(), math.NaN()}
+	for i := 0; i < 200; i++ {
+		values = append(values, math.Sin(float64(i)*0.7)*10+float64(i%7)) // com repetições e reversões
+	}
+
+	for _, period := range []int{1, 2, 5, 14, 52, 198} {
+		highest, lowest := indicators.HighestSeries(values, period), indicators.LowestSeries(values, period)
+		for i := range values {
+			if i < 2+period-1 {
+				assert.True(t, math.IsNaN(highest[i]) && math.IsNaN(lowest[i]), "período %d posição %d", period, i)
+				continue
+			}
+			high, low := values[i], values[i]
+			for _, v := range values[i-period+1 : i] {
+				high, low = math.Max(high, v), math.Min(low, v)
+			}
+			assert.Equal(t, high, highest[i], "máxima período %d posição %d", period, i)
+			assert.Equal(t, low, lowest[i], "mínima período %d posição %d", period, i)
+		}
+	}
+	assert.True(t, math.IsNaN(indicators.HighestSeries(values, 201)[201]), "janela maior que a série")
+}
+
+func TestSessionVWAPResetsEachDay(t *testing.T) {
+	candles := trendCandles(100, 1, 30) // 00:00 até 05:00 do dia seguinte
+	vwap := indicators.NewSessionVWAP().Calculate(candles)
+
+	typical := func(c entity.Candle) float64 { return (c.High + c.Low + c.Close) / 3 }
+	assert.InDelta(t, typical(candles[0]), vwap[0], 1e-9)
+	assert.InDelta(t, typical(candles[24]), vwap[24], 1e-9, "nova sessão às 00:00 UTC")
+	assert.InDelta(t, (typical(candles[24])+typical(candles[25]))/2, vwap[25], 1e-9)
+}
+
+func TestIchimokuCloudIsDisplaced(t *testing.T) {
+	candles := trendCandles(100, 0.5, 120)
+	ichimoku := indicators.NewIchimoku()
+	lines := ichimoku.Calculate(candles)
+
+	i := len(candles) - 1
+	from := i - ichimoku.Displacement
+	assert.InDelta(t, (lines.Tenkan[from]+lines.Kijun[from])/2, lines.SenkouA[i], 1e-9)
+	assert.Less(t, lines.SenkouA[i], candles[i].Close, "nuvem atrás do preço na alta")
+}
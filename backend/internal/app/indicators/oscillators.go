@@ -0,0 +1,80 @@
+// internal/app/indicators/oscillators.go
+
+package indicators
+
+import (
+	"math"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// StochasticLines são as linhas %K e %D (0 a 100) de um estocástico, alinhadas aos candles.
+type StochasticLines struct {
+	K []float64
+	D []float64
+}
+
+// Stochastic configura o Oscilador Estocástico lento: o %K bruto (posição do fechamento entre a
+// mínima e a máxima de KPeriod candles) é suavizado por uma SMA de KSmoothing, e o %D é a SMA
+// de DPeriod do %K.
+type Stochastic struct {
+	KPeriod    int
+	KSmoothing int
+	DPeriod    int
+}
+
+// NewStochastic cria o estocástico com os parâmetros usuais (14, 3, 3).
+func NewStochastic() Stochastic {
+	return Stochastic{KPeriod: 14, KSmoothing: 3, DPeriod: 3}
+}
+
+func (s Stochastic) Warmup() int { return s.KPeriod + s.KSmoothing + s.DPeriod - 2 }
+
+func (s Stochastic) Calculate(candles []entity.Candle) StochasticLines {
+	highs, lows, closes, _ := splitCandles(candles)
+	raw := stochasticSeries(closes, HighestSeries(highs, s.KPeriod), LowestSeries(lows, s.KPeriod))
+	k := SMASeries(raw, s.KSmoothing)
+	return StochasticLines{K: k, D: SMASeries(k, s.DPeriod)}
+}
+
+// StochasticRSI configura o Estocástico do RSI: o estocástico de StochPeriod aplicado ao RSI
+// (Wilder) de RSIPeriod, com %K suavizado por KSmoothing e %D de DPeriod.
+type StochasticRSI struct {
+	RSIPeriod   int
+	StochPeriod int
+	KSmoothing  int
+	DPeriod     int
+}
+
+// NewStochasticRSI cria o Estocástico do RSI com os parâmetros usuais (14, 14, 3, 3).
+func NewStochasticRSI() StochasticRSI {
+	return StochasticRSI{RSIPeriod: 14, StochPeriod: 14, KSmoothing: 3, DPeriod: 3}
+}
+
+func (s StochasticRSI) Warmup() int {
+	return s.RSIPeriod + s.StochPeriod + s.KSmoothing + s.DPeriod - 2
+}
+
+func (s StochasticRSI) Calculate(candles []entity.Candle) StochasticLines {
+	_, _, closes, _ := splitCandles(candles)
+	rsi := RSISeries(closes, s.RSIPeriod)
+	raw := stochasticSeries(rsi, HighestSeries(rsi, s.StochPeriod), LowestSeries(rsi, s.StochPeriod))
+	k := SMASeries(raw, s.KSmoothing)
+	return StochasticLines{K: k, D: SMASeries(k, s.DPeriod)}
+}
+
+// stochasticSeries posiciona cada valor entre a mínima e a máxima (0 a 100). Sem amplitude, o
+// valor fica em 50.
+func stochasticSeries(values, highest, lowest []float64) []float64 {
+	out := nanSeries(len(values))
+	for i, v := range values {
+		if math.IsNaN(highest[i]) || math.IsNaN(lowest[i]) {
+			continue
+		}
+		out[i] = 50
+		if rng := highest[i] - lowest[i]; rng != 0 {
+			out[i] = (v - lowest[i]) / rng * 100
+		}
+	}
+	return out
+}
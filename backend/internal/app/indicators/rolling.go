@@ -0,0 +1,234 @@
+// internal/app/indicators/rolling.go
+
+package indicators
+
+import "math"
+
+// Indicadores incrementais: cada valor (ou candle) fechado os atualiza em O(1), sem
+// recalcular a janela inteira. Antes de completar o período, SMA, EMA, desvio padrão e ATR
+// usam os valores disponíveis (como MovingAverage); o RSI retorna 0.
+
+// floatWindow é uma janela circular de tamanho fixo de valores.
+type floatWindow struct {
+	buf   []float64
+	start int
+	n     int
+}
+
+func newFloatWindow(size int) floatWindow {
+	return floatWindow{buf: make([]float64, max(size, 1))}
+}
+
+// push inclui o valor e, com a janela cheia, retorna o valor descartado.
+func (w *floatWindow) push(v float64) (evicted float64, ok bool) {
+	if w.n < len(w.buf) {
+		w.buf[(w.start+w.n)%len(w.buf)] = v
+		w.n++
+		return 0, false
+	}
+	evicted = w.buf[w.start]
+	w.buf[w.start] = v
+	w.start = (w.start + 1) % len(w.buf)
+	return evicted, true
+}
+
+// wrapped indica que a janela completou uma volta (momento de recalcular as somas acumuladas).
+func (w *floatWindow) wrapped() bool {
+	return w.n == len(w.buf) && w.start == 0
+}
+
+// RollingSMA é a Média Móvel Simples incremental dos últimos period valores.
+type RollingSMA struct {
+	window floatWindow
+	sum    float64
+}
+
+// NewRollingSMA cria a média dos últimos period valores.
+func NewRollingSMA(period int) *RollingSMA {
+	return &RollingSMA{window: newFloatWindow(period)}
+}
+
+func (m *RollingSMA) Update(v float64) float64 {
+	evicted, _ := m.window.push(v)
+	m.sum += v - evicted
+	if m.window.wrapped() {
+		// Recalcula a soma a cada volta para não acumular erro de arredondamento
+		m.sum = 0
+		for _, x := range m.window.buf {
+			m.sum += x
+		}
+	}
+	return m.Value()
+}
+
+func (m *RollingSMA) Value() float64 {
+	if m.window.n == 0 {
+		return 0
+	}
+	return m.sum / float64(m.window.n)
+}
+
+func (m *RollingSMA) Ready() bool {
+	return m.window.n == len(m.window.buf)
+}
+
+// RollingEMA é a Média Móvel Exponencial incremental, iniciada pela SMA dos primeiros period valores.
+type RollingEMA struct {
+	period int
+	alpha  float64
+	count  int
+	value  float64
+}
+
+// NewRollingEMA cria a EMA de período period.
+func NewRollingEMA(period int) *RollingEMA {
+	period = max(period, 1)
+	return &RollingEMA{period: period, alpha: 2.0 / (float64(period) + 1.0)}
+}
+
+func (m *RollingEMA) Update(v float64) float64 {
+	m.count++
+	if m.count <= m.period {
+		m.value += (v - m.value) / float64(m.count) // média dos valores até completar o período
+	} else {
+		m.value = m.alpha*v + (1-m.alpha)*m.value
+	}
+	return m.value
+}
+
+func (m *RollingEMA) Value() float64 { return m.value }
+func (m *RollingEMA) Ready() bool    { return m.count >= m.period }
+
+// RollingRSI é o Relative Strength Index incremental com a suavização de Wilder.
+type RollingRSI struct {
+	period    int
+	count     int // variações recebidas
+	prev      float64
+	avgGain   float64
+	avgLoss   float64
+	hasPrices bool
+}
+
+// NewRollingRSI cria o RSI de período period.
+func NewRollingRSI(period int) *RollingRSI {
+	return &RollingRSI{period: max(period, 1)}
+}
+
+func (m *RollingRSI) Update(price float64) float64 {
+	if !m.hasPrices {
+		m.prev, m.hasPrices = price, true
+		return m.Value()
+	}
+	change := price - m.prev
+	m.prev = price
+	gain, loss := math.Max(change, 0), math.Max(-change, 0)
+
+	m.count++
+	p := float64(m.period)
+	if m.count <= m.period {
+		// Média simples das primeiras variações
+		m.avgGain += (gain - m.avgGain) / float64(m.count)
+		m.avgLoss += (loss - m.avgLoss) / float64(m.count)
+	} else {
+		m.avgGain = (m.avgGain*(p-1) + gain) / p
+		m.avgLoss = (m.avgLoss*(p-1) + loss) / p
+	}
+	return m.Value()
+}
+
+func (m *RollingRSI) Value() float64 {
+	switch {
+	case !m.Ready():
+		return 0
+	case m.avgLoss == 0 && m.avgGain == 0:
+		return 50
+	case m.avgLoss == 0:
+		return 100
+	}
+	return 100 - 100/(1+m.avgGain/m.avgLoss)
+}
+
+func (m *RollingRSI) Ready() bool { return m.count >= m.period }
+
+// RollingATR é o Average True Range incremental com a suavização de Wilder. O primeiro candle
+// apenas fornece o fechamento anterior.
+type RollingATR struct {
+	period    int
+	count     int // true ranges recebidos
+	prevClose float64
+	value     float64
+	hasClose  bool
+}
+
+// NewRollingATR cria o ATR de período period.
+func NewRollingATR(period int) *RollingATR {
+	return &RollingATR{period: max(period, 1)}
+}
+
+func (m *RollingATR) Update(high, low, close float64) float64 {
+	if !m.hasClose {
+		m.prevClose, m.hasClose = close, true
+		return m.value
+	}
+	tr := math.Max(high-low, math.Max(math.Abs(high-m.prevClose), math.Abs(low-m.prevClose)))
+	m.prevClose = close
+
+	m.count++
+	if m.count <= m.period {
+		m.value += (tr - m.value) / float64(m.count)
+	} else {
+		p := float64(m.period)
+		m.value = (m.value*(p-1) + tr) / p
+	}
+	return m.value
+}
+
+func (m *RollingATR) Value() float64 { return m.value }
+func (m *RollingATR) Ready() bool    { return m.count >= m.period }
+
+// RollingStdDev é o desvio padrão populacional incremental dos últimos period valores.
+type RollingStdDev struct {
+	window floatWindow
+	sum    float64
+	sumSq  float64
+}
+
+// NewRollingStdDev cria o desvio padrão dos últimos period valores.
+func NewRollingStdDev(period int) *RollingStdDev {
+	return &RollingStdDev{window: newFloatWindow(period)}
+}
+
+func (m *RollingStdDev) Update(v float64) float64 {
+	evicted, _ := m.window.push(v)
+	m.sum += v - evicted
+	m.sumSq += v*v - evicted*evicted
+	if m.window.wrapped() {
+		m.sum, m.sumSq = 0, 0
+		for _, x := range m.window.buf {
+			m.sum += x
+			m.sumSq += x * x
+		}
+	}
+	return m.Value()
+}
+
+func (m *RollingStdDev) Value() float64 {
+	if m.window.n == 0 {
+		return 0
+	}
+	n := float64(m.window.n)
+	mean := m.sum / n
+	return math.Sqrt(math.Max(m.sumSq/n-mean*mean, 0))
+}
+
+// Mean retorna a média dos valores da janela.
+func (m *RollingStdDev) Mean() float64 {
+	if m.window.n == 0 {
+		return 0
+	}
+	return m.sum / float64(m.window.n)
+}
+
+func (m *RollingStdDev) Ready() bool {
+	return m.window.n == len(m.window.buf)
+}
@@ -0,0 +1,129 @@
+// internal/app/indicators/rolling_test.go
+
+package indicators_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// wave gera n preços oscilantes em torno de 100.
+func wave(n int) []float64 {
+	prices := make([]float64, n)
+	for i := range prices {
+		prices[i] = 100 + 5*math.Sin(float64(i)/7) + float64(i%5)*0.3
+	}
+	return prices
+}
+
+func TestRollingSMAMatchesMovingAverage(t *testing.T) {
+	prices := wave(500)
+	sma := indicators.NewRollingSMA(20)
+	for i, p := range prices {
+		got := sma.Update(p)
+		assert.InDelta(t, indicators.MovingAverage(prices[:i+1], 20), got, 1e-9)
+	}
+	assert.True(t, sma.Ready())
+}
+
+func TestRollingStdDevMatchesPopulationFormula(t *testing.T) {
+	prices := wave(300)
+	stddev := indicators.NewRollingStdDev(30)
+	for _, p := range prices {
+		stddev.Update(p)
+	}
+
+	window := prices[len(prices)-30:]
+	mean := indicators.SMA(window)
+	variance := 0.0
+	for _, p := range window {
+		variance += (p - mean) * (p - mean)
+	}
+	assert.InDelta(t, mean, stddev.Mean(), 1e-9)
+	assert.InDelta(t, math.Sqrt(variance/30), stddev.Value(), 1e-9)
+}
+
+func TestRollingRSIExtremes(t *testing.T) {
+	rsi := indicators.NewRollingRSI(14)
+	for i := 0; i < 14; i++ {
+		assert.Zero(t, rsi.Update(float64(100+i)), "sem período completo")
+	}
+	assert.Equal(t, 100.0, rsi.Update(114))
+
+	flat := indicators.NewRollingRSI(3)
+	for i := 0; i < 5; i++ {
+		flat.Update(100)
+	}
+	assert.Equal(t, 50.0, flat.Value())
+}
+
+func TestRollingATRConstantRange(t *testing.T) {
+	atr := indicators.NewRollingATR(14)
+	for i := 0; i < 50; i++ {
+		atr.Update(102, 98, 100)
+	}
+	assert.True(t, atr.Ready())
+	assert.InDelta(t, 4.0, atr.Value(), 1e-9)
+}
+
+// benchmarkWindow simula uma janela de 240 candles atualizada a cada candle fechado.
+const benchmarkWindow = 240
+
+func benchmarkSeries(n int) []entity.Candle {
+	prices := wave(n)
+	candles := make([]entity.Candle, n)
+	for i, p := range prices {
+		candles[i] = entity.Candle{Open: p, High: p + 1, Low: p - 1, Close: p}
+	}
+	return candles
+}
+
+func BenchmarkMovingAverageWindow(b *testing.B) {
+	prices := wave(benchmarkWindow)
+	for i := 0; i < b.N; i++ {
+		indicators.MovingAverage(prices, benchmarkWindow)
+	}
+}
+
+func BenchmarkRollingSMA(b *testing.B) {
+	prices := wave(benchmarkWindow)
+	sma := indicators.NewRollingSMA(benchmarkWindow)
+	for i := 0; i < b.N; i++ {
+		sma.Update(prices[i%benchmarkWindow])
+	}
+}
+
+func BenchmarkRSIWindow(b *testing.B) {
+	prices := wave(benchmarkWindow)
+	for i := 0; i < b.N; i++ {
+		indicators.RSI(prices, 14)
+	}
+}
+
+func BenchmarkRollingRSI(b *testing.B) {
+	prices := wave(benchmarkWindow)
+	rsi := indicators.NewRollingRSI(14)
+	for i := 0; i < b.N; i++ {
+		rsi.Update(prices[i%benchmarkWindow])
+	}
+}
+
+func BenchmarkATRFromCandlesWindow(b *testing.B) {
+	candles := benchmarkSeries(benchmarkWindow)
+	for i := 0; i < b.N; i++ {
+		indicators.ATRFromCandles(candles, 14)
+	}
+}
+
+func BenchmarkRollingATR(b *testing.B) {
+	candles := benchmarkSeries(benchmarkWindow)
+	atr := indicators.NewRollingATR(14)
+	for i := 0; i < b.N; i++ {
+		c := candles[i%benchmarkWindow]
+		atr.Update(c.High, c.Low, c.Close)
+	}
+}
@@ -0,0 +1,178 @@
+// internal/app/indicators/series.go
+
+package indicators
+
+import "math"
+
+// Funções de série: recebem a série completa e retornam um slice alinhado a ela (mesmo
+// tamanho, out[i] corresponde a values[i]), com math.NaN() nas posições em que o indicador
+// ainda não tem dados suficientes. NaNs no início da entrada (ex: a linha do MACD) são
+// ignorados. As convenções seguem o TA-Lib: EMA iniciada pela SMA do primeiro período, RSI e
+// ATR com a suavização de Wilder e desvio padrão populacional.
+
+// nanSeries cria uma série de tamanho n sem valores.
+func nanSeries(n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = math.NaN()
+	}
+	return out
+}
+
+// firstValid retorna o índice do primeiro valor que não é NaN (len(values) se não houver).
+func firstValid(values []float64) int {
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			return i
+		}
+	}
+	return len(values)
+}
+
+// Last retorna o valor mais recente da série, ou 0 se ela estiver vazia ou sem valor.
+func Last(series []float64) float64 {
+	if len(series) == 0 || math.IsNaN(series[len(series)-1]) {
+		return 0
+	}
+	return series[len(series)-1]
+}
+
+// SMASeries calcula a Média Móvel Simples de period valores.
+func SMASeries(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if period < 1 || len(values)-start < period {
+		return out
+	}
+	sum := 0.0
+	for i := start; i < len(values); i++ {
+		sum += values[i]
+		if i-start >= period {
+			sum -= values[i-period]
+		}
+		if i-start >= period-1 {
+			out[i] = sum / float64(period)
+		}
+	}
+	return out
+}
+
+// EMASeries calcula a Média Móvel Exponencial de período period, iniciada pela SMA dos
+// primeiros period valores.
+func EMASeries(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if period < 1 || len(values)-start < period {
+		return out
+	}
+	seed := start + period - 1
+	out[seed] = SMA(values[start : seed+1])
+	alpha := 2.0 / (float64(period) + 1.0)
+	for i := seed + 1; i < len(values); i++ {
+		out[i] = alpha*values[i] + (1-alpha)*out[i-1]
+	}
+	return out
+}
+
+// WMASeries calcula a Média Móvel Ponderada de period valores (pesos 1..period, o mais
+// recente com o maior peso).
+func WMASeries(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if period < 1 || len(values)-start < period {
+		return out
+	}
+	divisor := float64(period*(period+1)) / 2
+	for i := start + period - 1; i < len(values); i++ {
+		weighted := 0.0
+		for w := 1; w <= period; w++ {
+			weighted += float64(w) * values[i-period+w]
+		}
+		out[i] = weighted / divisor
+	}
+	return out
+}
+
+// StdDevSeries calcula o desvio padrão populacional de period valores.
+func StdDevSeries(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if period < 1 || len(values)-start < period {
+		return out
+	}
+	means := SMASeries(values, period)
+	for i := start + period - 1; i < len(values); i++ {
+		variance := 0.0
+		for _, v := range values[i-period+1 : i+1] {
+			variance += (v - means[i]) * (v - means[i])
+		}
+		out[i] = math.Sqrt(variance / float64(period))
+	}
+	return out
+}
+
+// RSISeries calcula o Relative Strength Index com a suavização de Wilder: as médias de
+// ganhos e perdas começam pela média simples das primeiras period variações. O primeiro
+// valor sai em values[start+period]; sem ganhos nem perdas, o RSI é 50.
+func RSISeries(values []float64, period int) []float64 {
+	out := nanSeries(len(values))
+	start := firstValid(values)
+	if period < 1 || len(values)-start < period+1 {
+		return out
+	}
+	p := float64(period)
+	avgGain, avgLoss := 0.0, 0.0
+	for i := start + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		gain, loss := math.Max(change, 0), math.Max(-change, 0)
+		if n := i - start; n <= period {
+			avgGain += gain / p
+			avgLoss += loss / p
+			if n < period {
+				continue
+			}
+		} else {
+			avgGain = (avgGain*(p-1) + gain) / p
+			avgLoss = (avgLoss*(p-1) + loss) / p
+		}
+		out[i] = rsiValue(avgGain, avgLoss)
+	}
+	return out
+}
+
+func rsiValue(avgGain, avgLoss float64) float64 {
+	switch {
+	case avgGain == 0 && avgLoss == 0:
+		return 50
+	case avgLoss == 0:
+		return 100
+	}
+	return 100 - 100/(1+avgGain/avgLoss)
+}
+
+// TrueRangeSeries calcula o True Range de cada candle. O primeiro não tem fechamento
+// anterior e fica sem valor.
+func TrueRangeSeries(highs, lows, closes []float64) []float64 {
+	out := nanSeries(len(closes))
+	for i := 1; i < len(closes); i++ {
+		prevClose := closes[i-1]
+		out[i] = math.Max(highs[i]-lows[i], math.Max(math.Abs(highs[i]-prevClose), math.Abs(lows[i]-prevClose)))
+	}
+	return out
+}
+
+// ATRSeries calcula o Average True Range com a suavização de Wilder, iniciado pela média
+// dos primeiros period true ranges. O primeiro valor sai no candle period.
+func ATRSeries(highs, lows, closes []float64, period int) []float64 {
+	trs := TrueRangeSeries(highs, lows, closes)
+	out := nanSeries(len(trs))
+	if period < 1 || len(trs) < period+1 {
+		return out
+	}
+	p := float64(period)
+	out[period] = SMA(trs[1 : period+1])
+	for i := period + 1; i < len(trs); i++ {
+		out[i] = (out[i-1]*(p-1) + trs[i]) / p
+	}
+	return out
+}
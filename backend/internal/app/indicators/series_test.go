@@ -0,0 +1,165 @@
+// internal/app/indicators/series_test.go
+
+package indicators_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referenceFixture são séries OHLC com os indicadores esperados, calculados com o TA-Lib
+// (null enquanto o indicador não tem dados suficientes). Gerado por
+// testdata/generate_reference.py, que indica a origem de cada série.
+type referenceFixture map[string][]*float64
+
+func loadReference(t *testing.T) referenceFixture {
+	t.Helper()
+	data, err := os.ReadFile("testdata/reference.json")
+	require.NoError(t, err)
+	var fixture referenceFixture
+	require.NoError(t, json.Unmarshal(data, &fixture))
+	return fixture
+}
+
+func (f referenceFixture) values(t *testing.T, key string) []float64 {
+	t.Helper()
+	series, ok := f[key]
+	require.True(t, ok, key)
+	values := make([]float64, len(series))
+	for i, v := range series {
+		values[i] = math.NaN()
+		if v != nil {
+			values[i] = *v
+		}
+	}
+	return values
+}
+
+func assertSeries(t *testing.T, expected, actual []float64, name string) {
+	t.Helper()
+	require.Len(t, actual, len(expected), name)
+	for i := range expected {
+		if math.IsNaN(expected[i]) {
+			assert.True(t, math.IsNaN(actual[i]), "%s[%d] deveria estar sem valor, veio %v", name, i, actual[i])
+			continue
+		}
+		assert.InDelta(t, expected[i], actual[i], 1e-8, "%s[%d]", name, i)
+	}
+}
+
+func TestSeriesMatchReferenceVectors(t *testing.T) {
+	fixture := loadReference(t)
+	highs, lows, closes := fixture.values(t, "high"), fixture.values(t, "low"), fixture.values(t, "close")
+
+	assertSeries(t, fixture.values(t, "sma_10"), indicators.SMASeries(closes, 10), "sma_10")
+	assertSeries(t, fixture.values(t, "ema_10"), indicators.EMASeries(closes, 10), "ema_10")
+	assertSeries(t, fixture.values(t, "wma_10"), indicators.WMASeries(closes, 10), "wma_10")
+	assertSeries(t, fixture.values(t, "stddev_10"), indicators.StdDevSeries(closes, 10), "stddev_10")
+	assertSeries(t, fixture.values(t, "rsi_14"), indicators.RSISeries(closes, 14), "rsi_14")
+	assertSeries(t, fixture.values(t, "atr_14"), indicators.ATRSeries(highs, lows, closes, 14), "atr_14")
+
+	macd, signal, histogram := indicators.MACD(closes, 12, 26, 9)
+	assertSeries(t, fixture.values(t, "macd_12_26_9"), macd, "macd")
+	assertSeries(t, fixture.values(t, "macd_signal_12_26_9"), signal, "macd_signal")
+	assertSeries(t, fixture.values(t, "macd_hist_12_26_9"), histogram, "macd_hist")
+}
+
+func TestCandleIndicatorsMatchReferenceVectors(t *testing.T) {
+	fixture := loadReference(t)
+	highs, lows, closes := fixture.values(t, "high"), fixture.values(t, "low"), fixture.values(t, "close")
+	candles := make([]entity.Candle, len(closes))
+	for i := range candles {
+		candles[i] = entity.Candle{Open: closes[i], High: highs[i], Low: lows[i], Close: closes[i]}
+	}
+
+	dmi := indicators.DMI{Period: 14}.Calculate(candles)
+	assertSeries(t, fixture.values(t, "plus_di_14"), dmi.PlusDI, "plus_di")
+	assertSeries(t, fixture.values(t, "minus_di_14"), dmi.MinusDI, "minus_di")
+	assertSeries(t, fixture.values(t, "adx_14"), dmi.ADX, "adx")
+
+	supertrend := indicators.Supertrend{Period: 10, Multiplier: 3}.Calculate(candles)
+	assertSeries(t, fixture.values(t, "supertrend_10_3"), supertrend.Value, "supertrend")
+	assertSeries(t, fixture.values(t, "supertrend_direction_10_3"), supertrend.Direction, "supertrend_direction")
+
+	stoch := indicators.Stochastic{KPeriod: 14, KSmoothing: 3, DPeriod: 3}.Calculate(candles)
+	assertSeries(t, fixture.values(t, "stoch_k_14_3_3"), stoch.K, "stoch_k")
+	assertSeries(t, fixture.values(t, "stoch_d_14_3_3"), stoch.D, "stoch_d")
+
+	stochRSI := indicators.StochasticRSI{RSIPeriod: 14, StochPeriod: 14, KSmoothing: 3, DPeriod: 3}.Calculate(candles)
+	assertSeries(t, fixture.values(t, "stoch_rsi_k_14_14_3_3"), stochRSI.K, "stoch_rsi_k")
+	assertSeries(t, fixture.values(t, "stoch_rsi_d_14_14_3_3"), stochRSI.D, "stoch_rsi_d")
+
+	keltner := indicators.Keltner{Period: 20, ATRPeriod: 10, Multiplier: 2}.Calculate(candles)
+	assertSeries(t, fixture.values(t, "keltner_upper_20_10_2"), keltner.Upper, "keltner_upper")
+	assertSeries(t, fixture.values(t, "keltner_middle_20_10_2"), keltner.Middle, "keltner_middle")
+	assertSeries(t, fixture.values(t, "keltner_lower_20_10_2"), keltner.Lower, "keltner_lower")
+
+	ichimoku := indicators.Ichimoku{TenkanPeriod: 9, KijunPeriod: 26, SenkouBPeriod: 52, Displacement: 26}.Calculate(candles)
+	assertSeries(t, fixture.values(t, "ichimoku_tenkan_9"), ichimoku.Tenkan, "ichimoku_tenkan")
+	assertSeries(t, fixture.values(t, "ichimoku_kijun_26"), ichimoku.Kijun, "ichimoku_kijun")
+	assertSeries(t, fixture.values(t, "ichimoku_senkou_a_9_26_26"), ichimoku.SenkouA, "ichimoku_senkou_a")
+	assertSeries(t, fixture.values(t, "ichimoku_senkou_b_52_26"), ichimoku.SenkouB, "ichimoku_senkou_b")
+}
+
+func TestRSIMatchesWilderExample(t *testing.T) {
+	// Exemplo clássico de RSI(14) de Wilder publicado pelo StockCharts. A planilha original usa
+	// fechamentos com mais casas decimais, daí a tolerância de 0.1
+	closes := []float64{
+		44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08, 45.89, 46.03, 45.61, 46.28,
+		46.28, 46.00, 46.03, 46.41, 46.22, 45.64, 46.21, 46.25, 45.71, 46.45, 45.78, 45.35, 44.03, 44.18,
+		44.22, 44.57, 43.42, 42.66, 43.13,
+	}
+	expected := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+
+	rsi := indicators.RSISeries(closes, 14)
+	for i, want := range expected {
+		assert.InDelta(t, want, rsi[14+i], 0.1, "rsi[%d]", 14+i)
+	}
+	assert.InDelta(t, 37.77, indicators.RSI(closes, 14), 0.1, "RSI usa os preços mais recentes")
+}
+
+func TestRollingIndicatorsMatchSeries(t *testing.T) {
+	fixture := loadReference(t)
+	highs, lows, closes := fixture.values(t, "high"), fixture.values(t, "low"), fixture.values(t, "close")
+
+	ema := indicators.NewRollingEMA(10)
+	rsi := indicators.NewRollingRSI(14)
+	atr := indicators.NewRollingATR(14)
+	emaSeries := indicators.EMASeries(closes, 10)
+	rsiSeries := indicators.RSISeries(closes, 14)
+	atrSeries := indicators.ATRSeries(highs, lows, closes, 14)
+	for i := range closes {
+		ema.Update(closes[i])
+		rsi.Update(closes[i])
+		atr.Update(highs[i], lows[i], closes[i])
+		if ema.Ready() {
+			assert.InDelta(t, emaSeries[i], ema.Value(), 1e-9, "ema[%d]", i)
+		}
+		if rsi.Ready() {
+			assert.InDelta(t, rsiSeries[i], rsi.Value(), 1e-9, "rsi[%d]", i)
+		}
+		if atr.Ready() {
+			assert.InDelta(t, atrSeries[i], atr.Value(), 1e-9, "atr[%d]", i)
+		}
+	}
+}
+
+func TestSeriesWithoutEnoughData(t *testing.T) {
+	closes := []float64{1, 2, 3}
+	for _, v := range indicators.EMASeries(closes, 5) {
+		assert.True(t, math.IsNaN(v))
+	}
+	assert.Zero(t, indicators.EMA(closes, 5))
+	assert.Zero(t, indicators.RSI(closes, 14))
+	assert.Zero(t, indicators.Last(nil))
+}
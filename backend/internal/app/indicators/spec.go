@@ -0,0 +1,226 @@
+// internal/app/indicators/spec.go
+
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// maxSpecPeriod limita os períodos (e o deslocamento do Ichimoku) aceitos por ParseSpec.
+const maxSpecPeriod = 1000
+
+// Lines são as linhas de um indicador calculadas sobre os candles, pelo nome (ex: "value",
+// "upper", "signal"), alinhadas aos candles.
+type Lines map[string][]float64
+
+// Spec é um indicador escolhido pelo nome e pelos parâmetros no formato nome:p1:p2 (ex:
+// "ema:20", "rsi:14", "bb:20:2"), usado pelos gráficos para montar overlays. Parâmetros
+// omitidos assumem os valores usuais.
+type Spec struct {
+	Name   string
+	Params []float64
+
+	warmup    int
+	calculate func(candles []entity.Candle) Lines
+}
+
+// specDefinition descreve um indicador disponível por Spec.
+type specDefinition struct {
+	defaults []float64 // parâmetros usuais (também limita quantos são aceitos)
+	periods  int       // quantos dos primeiros parâmetros são períodos (inteiros)
+	build    func(p []float64) (int, func([]entity.Candle) Lines)
+}
+
+var specDefinitions = map[string]specDefinition{
+	"sma":    closeSpec(20, SMASeries),
+	"ema":    closeSpec(20, EMASeries),
+	"wma":    closeSpec(20, WMASeries),
+	"stddev": closeSpec(20, StdDevSeries),
+	"rsi": {defaults: []float64{14}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		period := int(p[0])
+		return period + 1, func(candles []entity.Candle) Lines {
+			_, _, closes, _ := splitCandles(candles)
+			return Lines{"value": RSISeries(closes, period)}
+		}
+	}},
+	"atr": {defaults: []float64{14}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		period := int(p[0])
+		return period + 1, func(candles []entity.Candle) Lines {
+			highs, lows, closes, _ := splitCandles(candles)
+			return Lines{"value": ATRSeries(highs, lows, closes, period)}
+		}
+	}},
+	"macd": {defaults: []float64{12, 26, 9}, periods: 3, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		fast, slow, signal := int(p[0]), int(p[1]), int(p[2])
+		return max(fast, slow) + signal - 1, func(candles []entity.Candle) Lines {
+			_, _, closes, _ := splitCandles(candles)
+			macd, signalLine, histogram := MACD(closes, fast, slow, signal)
+			if macd == nil {
+				macd, signalLine, histogram = nanSeries(len(closes)), nanSeries(len(closes)), nanSeries(len(closes))
+			}
+			return Lines{"macd": macd, "signal": signalLine, "histogram": histogram}
+		}
+	}},
+	"bb": {defaults: []float64{20, 2}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		b := Bollinger{Period: int(p[0]), Multiplier: p[1]}
+		return b.Warmup(), func(candles []entity.Candle) Lines {
+			bands := b.Calculate(candles)
+			return Lines{
+				"upper": bands.Upper, "middle": bands.Middle, "lower": bands.Lower,
+				"percent_b": bands.PercentB, "bandwidth": bands.Bandwidth,
+			}
+		}
+	}},
+	"keltner": {defaults: []float64{20, 10, 2}, periods: 2, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		k := Keltner{Period: int(p[0]), ATRPeriod: int(p[1]), Multiplier: p[2]}
+		return k.Warmup(), func(candles []entity.Candle) Lines { return bandLines(k.Calculate(candles)) }
+	}},
+	"donchian": {defaults: []float64{20}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		d := Donchian{Period: int(p[0])}
+		return d.Warmup(), func(candles []entity.Candle) Lines { return bandLines(d.Calculate(candles)) }
+	}},
+	"stoch": {defaults: []float64{14, 3, 3}, periods: 3, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		s := Stochastic{KPeriod: int(p[0]), KSmoothing: int(p[1]), DPeriod: int(p[2])}
+		return s.Warmup(), func(candles []entity.Candle) Lines { return stochasticLines(s.Calculate(candles)) }
+	}},
+	"stochrsi": {defaults: []float64{14, 14, 3, 3}, periods: 4, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		s := StochasticRSI{RSIPeriod: int(p[0]), StochPeriod: int(p[1]), KSmoothing: int(p[2]), DPeriod: int(p[3])}
+		return s.Warmup(), func(candles []entity.Candle) Lines { return stochasticLines(s.Calculate(candles)) }
+	}},
+	"adx": {defaults: []float64{14}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		d := DMI{Period: int(p[0])}
+		return d.Warmup(), func(candles []entity.Candle) Lines {
+			dmi := d.Calculate(candles)
+			return Lines{"adx": dmi.ADX, "plus_di": dmi.PlusDI, "minus_di": dmi.MinusDI}
+		}
+	}},
+	"supertrend": {defaults: []float64{10, 3}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		s := Supertrend{Period: int(p[0]), Multiplier: p[1]}
+		return s.Warmup(), func(candles []entity.Candle) Lines {
+			line := s.Calculate(candles)
+			return Lines{"value": line.Value, "direction": line.Direction}
+		}
+	}},
+	"ichimoku": {defaults: []float64{9, 26, 52, 26}, periods: 4, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		ic := Ichimoku{TenkanPeriod: int(p[0]), KijunPeriod: int(p[1]), SenkouBPeriod: int(p[2]), Displacement: int(p[3])}
+		return ic.Warmup(), func(candles []entity.Candle) Lines {
+			lines := ic.Calculate(candles)
+			return Lines{"tenkan": lines.Tenkan, "kijun": lines.Kijun, "senkou_a": lines.SenkouA, "senkou_b": lines.SenkouB}
+		}
+	}},
+	"obv": {build: func([]float64) (int, func([]entity.Candle) Lines) {
+		return OBV{}.Warmup(), func(candles []entity.Candle) Lines { return Lines{"value": OBV{}.Calculate(candles)} }
+	}},
+	"vwap": {build: func([]float64) (int, func([]entity.Candle) Lines) {
+		v := NewSessionVWAP()
+		return v.Warmup(), func(candles []entity.Candle) Lines { return Lines{"value": v.Calculate(candles)} }
+	}},
+	"rvwap": {defaults: []float64{20}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		v := RollingVWAP{Period: int(p[0])}
+		return v.Warmup(), func(candles []entity.Candle) Lines { return Lines{"value": v.Calculate(candles)} }
+	}},
+}
+
+// closeSpec define um indicador de um período calculado sobre os fechamentos.
+func closeSpec(period float64, series func([]float64, int) []float64) specDefinition {
+	return specDefinition{defaults: []float64{period}, periods: 1, build: func(p []float64) (int, func([]entity.Candle) Lines) {
+		n := int(p[0])
+		return n, func(candles []entity.Candle) Lines {
+			_, _, closes, _ := splitCandles(candles)
+			return Lines{"value": series(closes, n)}
+		}
+	}}
+}
+
+func bandLines(b Bands) Lines {
+	return Lines{"upper": b.Upper, "middle": b.Middle, "lower": b.Lower}
+}
+
+func stochasticLines(s StochasticLines) Lines {
+	return Lines{"k": s.K, "d": s.D}
+}
+
+// SpecNames retorna os nomes dos indicadores disponíveis, em ordem alfabética.
+func SpecNames() []string {
+	names := make([]string, 0, len(specDefinitions))
+	for name := range specDefinitions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ParseSpec interpreta um indicador no formato nome:p1:p2. Períodos são inteiros entre 1 e
+// maxSpecPeriod; os demais parâmetros (multiplicadores), números positivos.
+func ParseSpec(text string) (Spec, error) {
+	parts := strings.Split(strings.TrimSpace(text), ":")
+	name := strings.ToLower(parts[0])
+	def, ok := specDefinitions[name]
+	if !ok {
+		return Spec{}, fmt.Errorf("indicador desconhecido: %s (disponíveis: %s)", parts[0], strings.Join(SpecNames(), ", "))
+	}
+	if len(parts)-1 > len(def.defaults) {
+		return Spec{}, fmt.Errorf("%s aceita no máximo %d parâmetros", name, len(def.defaults))
+	}
+
+	params := slices.Clone(def.defaults)
+	for i, raw := range parts[1:] {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value <= 0 || math.IsInf(value, 0) {
+			return Spec{}, fmt.Errorf("parâmetro inválido em %s: %q", text, raw)
+		}
+		if i < def.periods && (value != math.Trunc(value) || value > maxSpecPeriod) {
+			return Spec{}, fmt.Errorf("período deve ser inteiro entre 1 e %d em %s: %q", maxSpecPeriod, text, raw)
+		}
+		params[i] = value
+	}
+
+	spec := Spec{Name: name, Params: params}
+	spec.warmup, spec.calculate = def.build(params)
+	return spec, nil
+}
+
+// ParseSpecs interpreta uma lista de indicadores separados por vírgula, sem repetições.
+func ParseSpecs(list string) ([]Spec, error) {
+	var specs []Spec
+	seen := make(map[string]bool)
+	for _, text := range strings.Split(list, ",") {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		spec, err := ParseSpec(text)
+		if err != nil {
+			return nil, err
+		}
+		if key := spec.String(); !seen[key] {
+			seen[key] = true
+			specs = append(specs, spec)
+		}
+	}
+	return specs, nil
+}
+
+// String retorna o indicador no formato nome:p1:p2, com todos os parâmetros (ex: "bb:20:2").
+func (s Spec) String() string {
+	parts := []string{s.Name}
+	for _, p := range s.Params {
+		parts = append(parts, strconv.FormatFloat(p, 'f', -1, 64))
+	}
+	return strings.Join(parts, ":")
+}
+
+func (s Spec) Warmup() int { return s.warmup }
+
+// Calculate calcula as linhas do indicador sobre os candles.
+func (s Spec) Calculate(candles []entity.Candle) Lines {
+	if s.calculate == nil {
+		return Lines{}
+	}
+	return s.calculate(candles)
+}
@@ -0,0 +1,60 @@
+// internal/app/indicators/spec_test.go
+
+package indicators_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpecsAppliesDefaults(t *testing.T) {
+	specs, err := indicators.ParseSpecs("ema:20, rsi ,bb:20:2.5,ema:20,macd")
+	require.NoError(t, err)
+
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		names[i] = spec.String()
+	}
+	assert.Equal(t, []string{"ema:20", "rsi:14", "bb:20:2.5", "macd:12:26:9"}, names)
+	assert.Equal(t, 15, specs[1].Warmup())
+	assert.Equal(t, 34, specs[3].Warmup())
+}
+
+func TestParseSpecRejectsInvalid(t *testing.T) {
+	for _, text := range []string{"foo:3", "ema:abc", "ema:0", "ema:2.5", "bb:20:2:1", "rsi:-14",
+		"ema:1001", "ichimoku:9:26:52:1e19", "stochrsi:1e300", "bb:20:Inf"} {
+		_, err := indicators.ParseSpec(text)
+		assert.Error(t, err, text)
+	}
+}
+
+func TestSpecCalculatesNamedLines(t *testing.T) {
+	candles := trendCandles(100, 0.3, 80)
+	for _, text := range indicators.SpecNames() {
+		spec, err := indicators.ParseSpec(text)
+		require.NoError(t, err)
+
+		lines := spec.Calculate(candles)
+		require.NotEmpty(t, lines, text)
+		for name, series := range lines {
+			require.Len(t, series, len(candles), "%s.%s", text, name)
+			assert.False(t, math.IsNaN(series[len(series)-1]), "%s.%s pronto no último candle", text, name)
+		}
+	}
+
+	bb, err := indicators.ParseSpec("bb:20:2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"upper", "middle", "lower", "percent_b", "bandwidth"}, keys(bb.Calculate(candles)))
+}
+
+func keys(lines indicators.Lines) []string {
+	names := make([]string, 0, len(lines))
+	for name := range lines {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,211 @@
+#!/usr/bin/env python3
+# internal/app/indicators/testdata/generate_reference.py
+#
+# Gera reference.json, os vetores de referência usados por series_test.go.
+#
+# Os indicadores vêm do TA-Lib (pacote Python `TA-Lib`, import talib), de forma independente
+# do código Go. Indicadores que o TA-Lib não tem são montados a partir das funções dele; só o
+# laço do Supertrend é implementado aqui. Cada série indica a sua origem:
+#
+#   [talib]      função do TA-Lib, com os parâmetros padrão
+#   [composto]   combinação de funções do TA-Lib (a regra está na função)
+#   [manual]     implementado aqui, por não existir no TA-Lib
+#
+# Valores null enquanto o indicador não tem dados suficientes (NaN do TA-Lib).
+#
+# Dependências: pip install numpy TA-Lib
+# Uso (a partir de backend/internal/app/indicators):
+#   python3 testdata/generate_reference.py > testdata/reference.json
+
+import json
+import math
+
+import numpy as np
+import talib
+
+BARS = 120
+
+
+def make_series():
+    """Série OHLC determinística gerada por um LCG (semente 12345)."""
+    seed = 12345
+
+    def rnd():
+        nonlocal seed
+        seed = (seed * 1103515245 + 12345) % (2**31)
+        return seed / 2**31
+
+    closes, highs, lows = [], [], []
+    price = 100.0
+    for _ in range(BARS):
+        price = round(price * (1 + (rnd() - 0.5) * 0.04), 2)
+        high = round(price * (1 + rnd() * 0.01), 2)
+        low = round(price * (1 - rnd() * 0.01), 2)
+        closes.append(price)
+        highs.append(high)
+        lows.append(low)
+    return highs, lows, closes
+
+
+def ta(function, *inputs, **params):
+    """Chama a função do TA-Lib com listas (None como NaN) e devolve listas com None no lugar
+    de NaN. O TA-Lib ignora os NaN iniciais, como as séries do Go."""
+    arrays = [np.asarray([math.nan if v is None else v for v in values], dtype=float) for values in inputs]
+    result = function(*arrays, **params)
+
+    def to_list(array):
+        return [None if math.isnan(v) else float(v) for v in array.tolist()]
+
+    if isinstance(result, tuple):
+        return tuple(to_list(array) for array in result)
+    return to_list(result)
+
+
+def subtract(a, b):
+    return [x - y if x is not None and y is not None else None for x, y in zip(a, b)]
+
+
+def macd(closes, fast, slow, signal):
+    """[composto] EMA(fast) - EMA(slow) e a EMA(signal) do MACD, com talib.EMA. O talib.MACD
+    reinicia a EMA rápida no início da lenta e só publica a linha junto com o sinal; o MACD do
+    Go usa as duas EMAs completas."""
+    line = subtract(ta(talib.EMA, closes, timeperiod=fast), ta(talib.EMA, closes, timeperiod=slow))
+    signal_line = ta(talib.EMA, line, timeperiod=signal)
+    return line, signal_line, subtract(line, signal_line)
+
+
+def stochastic(highs, lows, closes, k_period, k_smoothing, d_period):
+    """[composto] Estocástico lento: o %D do talib.STOCHF (média simples do %K rápido) é o %K,
+    e o %D é a talib.SMA dele. O talib.STOCH só publica o %K junto com o %D."""
+    _, k = ta(talib.STOCHF, highs, lows, closes, fastk_period=k_period, fastd_period=k_smoothing, fastd_matype=talib.MA_Type.SMA)
+    return k, ta(talib.SMA, k, timeperiod=d_period)
+
+
+def supertrend(highs, lows, closes, period, multiplier):
+    """[manual] Supertrend como o ta.supertrend do TradingView, sobre o talib.ATR: bandas em
+    torno de (máxima+mínima)/2, começa em baixa. Direção 1 na alta (linha na banda inferior)
+    e -1 na baixa."""
+    atr_values = ta(talib.ATR, highs, lows, closes, timeperiod=period)
+    n = len(closes)
+    value, direction = [None] * n, [None] * n
+    upper = lower = trend = None
+    for i in range(period, n):
+        mid = (highs[i] + lows[i]) / 2
+        basic_upper = mid + multiplier * atr_values[i]
+        basic_lower = mid - multiplier * atr_values[i]
+        if i == period:
+            upper, lower, trend = basic_upper, basic_lower, -1
+        else:
+            if basic_upper < upper or closes[i - 1] > upper:
+                upper = basic_upper
+            if basic_lower > lower or closes[i - 1] < lower:
+                lower = basic_lower
+            if trend < 0 and closes[i] > upper:
+                trend = 1
+            elif trend > 0 and closes[i] < lower:
+                trend = -1
+        direction[i] = trend
+        value[i] = lower if trend > 0 else upper
+    return value, direction
+
+
+def stochastic_rsi(closes, rsi_period, stoch_period, k_smoothing, d_period):
+    """[composto] Estocástico do RSI como o Stoch RSI do TradingView: talib.RSI posicionado
+    entre talib.MIN e talib.MAX (50 sem amplitude), %K e %D com talib.SMA. O talib.STOCHRSI
+    não suaviza o %K."""
+    rsi = ta(talib.RSI, closes, timeperiod=rsi_period)
+    highest = ta(talib.MAX, rsi, timeperiod=stoch_period)
+    lowest = ta(talib.MIN, rsi, timeperiod=stoch_period)
+    raw = [None] * len(rsi)
+    for i, v in enumerate(rsi):
+        if highest[i] is None or lowest[i] is None:
+            continue
+        rng = highest[i] - lowest[i]
+        raw[i] = (v - lowest[i]) / rng * 100 if rng else 50
+    k = ta(talib.SMA, raw, timeperiod=k_smoothing)
+    return k, ta(talib.SMA, k, timeperiod=d_period)
+
+
+def keltner(highs, lows, closes, period, atr_period, multiplier):
+    """[composto] Canal de Keltner: talib.EMA de period ± multiplier talib.ATR de atr_period.
+    O canal só tem valor quando a média e o ATR têm."""
+    middle = ta(talib.EMA, closes, timeperiod=period)
+    atr_values = ta(talib.ATR, highs, lows, closes, timeperiod=atr_period)
+    upper, lower = [None] * len(closes), [None] * len(closes)
+    for i in range(len(closes)):
+        if middle[i] is None or atr_values[i] is None:
+            middle[i] = None
+            continue
+        upper[i] = middle[i] + multiplier * atr_values[i]
+        lower[i] = middle[i] - multiplier * atr_values[i]
+    return upper, middle, lower
+
+
+def ichimoku(highs, lows, tenkan_period, kijun_period, senkou_b_period, displacement):
+    """[composto] Tenkan, Kijun e Senkou B são o talib.MIDPRICE (ponto médio entre a máxima e
+    a mínima do período); as Senkou Spans são deslocadas displacement candles para frente (o
+    valor em i foi calculado em i-displacement)."""
+    tenkan = ta(talib.MIDPRICE, highs, lows, timeperiod=tenkan_period)
+    kijun = ta(talib.MIDPRICE, highs, lows, timeperiod=kijun_period)
+    senkou_b = ta(talib.MIDPRICE, highs, lows, timeperiod=senkou_b_period)
+    n = len(highs)
+    span_a, span_b = [None] * n, [None] * n
+    for i in range(displacement, n):
+        source = i - displacement
+        if tenkan[source] is not None and kijun[source] is not None:
+            span_a[i] = (tenkan[source] + kijun[source]) / 2
+        span_b[i] = senkou_b[source]
+    return tenkan, kijun, span_a, span_b
+
+
+def main():
+    highs, lows, closes = make_series()
+
+    macd_line, macd_signal, macd_hist = macd(closes, 12, 26, 9)
+    supertrend_value, supertrend_direction = supertrend(highs, lows, closes, 10, 3)
+    stoch_k, stoch_d = stochastic(highs, lows, closes, 14, 3, 3)
+    stoch_rsi_k, stoch_rsi_d = stochastic_rsi(closes, 14, 14, 3, 3)
+    keltner_upper, keltner_middle, keltner_lower = keltner(highs, lows, closes, 20, 10, 2)
+    tenkan, kijun, senkou_a, senkou_b = ichimoku(highs, lows, 9, 26, 52, 26)
+
+    fixture = {
+        "high": highs,
+        "low": lows,
+        "close": closes,
+        "sma_10": ta(talib.SMA, closes, timeperiod=10),  # [talib]
+        "ema_10": ta(talib.EMA, closes, timeperiod=10),  # [talib]
+        "wma_10": ta(talib.WMA, closes, timeperiod=10),  # [talib]
+        "stddev_10": ta(talib.STDDEV, closes, timeperiod=10, nbdev=1),  # [talib] populacional
+        "rsi_14": ta(talib.RSI, closes, timeperiod=14),  # [talib]
+        "atr_14": ta(talib.ATR, highs, lows, closes, timeperiod=14),  # [talib]
+        "macd_12_26_9": macd_line,  # [composto]
+        "macd_signal_12_26_9": macd_signal,  # [composto]
+        "macd_hist_12_26_9": macd_hist,  # [composto]
+        "plus_di_14": ta(talib.PLUS_DI, highs, lows, closes, timeperiod=14),  # [talib]
+        "minus_di_14": ta(talib.MINUS_DI, highs, lows, closes, timeperiod=14),  # [talib]
+        "adx_14": ta(talib.ADX, highs, lows, closes, timeperiod=14),  # [talib]
+        "supertrend_10_3": supertrend_value,  # [manual]
+        "supertrend_direction_10_3": supertrend_direction,  # [manual]
+        "stoch_k_14_3_3": stoch_k,  # [composto]
+        "stoch_d_14_3_3": stoch_d,  # [composto]
+        "stoch_rsi_k_14_14_3_3": stoch_rsi_k,  # [composto]
+        "stoch_rsi_d_14_14_3_3": stoch_rsi_d,  # [composto]
+        "keltner_upper_20_10_2": keltner_upper,  # [composto]
+        "keltner_middle_20_10_2": keltner_middle,  # [composto]
+        "keltner_lower_20_10_2": keltner_lower,  # [composto]
+        "ichimoku_tenkan_9": tenkan,  # [composto]
+        "ichimoku_kijun_26": kijun,  # [composto]
+        "ichimoku_senkou_a_9_26_26": senkou_a,  # [composto]
+        "ichimoku_senkou_b_52_26": senkou_b,  # [composto]
+    }
+
+    # Uma série por linha, valores arredondados em 10 casas
+    lines = []
+    for key, values in fixture.items():
+        rounded = [None if v is None else round(v, 10) for v in values]
+        lines.append("  %s: %s" % (json.dumps(key), json.dumps(rounded)))
+    print("{\n" + ",\n".join(lines) + "\n}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,196 @@
+// internal/app/indicators/trend.go
+
+package indicators
+
+import (
+	"math"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// DMI configura o Directional Movement Index de Wilder (+DI, -DI e ADX) de Period candles,
+// com a inicialização do TA-Lib (PLUS_DI, MINUS_DI e ADX).
+type DMI struct {
+	Period int
+}
+
+// DMILines são as linhas do DMI alinhadas aos candles. +DI e -DI ficam prontos no candle
+// Period; DX e ADX precisam de mais Period candles para a média inicial.
+type DMILines struct {
+	PlusDI  []float64
+	MinusDI []float64
+	DX      []float64
+	ADX     []float64
+}
+
+// NewDMI cria o DMI de 14 candles.
+func NewDMI() DMI {
+	return DMI{Period: 14}
+}
+
+func (d DMI) Warmup() int { return 2 * d.Period }
+
+func (d DMI) Calculate(candles []entity.Candle) DMILines {
+	n := len(candles)
+	out := DMILines{PlusDI: nanSeries(n), MinusDI: nanSeries(n), DX: nanSeries(n), ADX: nanSeries(n)}
+	p := d.Period
+	if p < 1 || n < p+1 {
+		return out
+	}
+
+	highs, lows, closes, _ := splitCandles(candles)
+	trs := TrueRangeSeries(highs, lows, closes)
+
+	// Somas de Wilder como no TA-Lib: acumula os Period-1 primeiros movimentos e, a partir do
+	// candle Period, soma = soma - soma/Period + movimento
+	var sumTR, sumPlus, sumMinus, sumDX float64
+	for i := 1; i < n; i++ {
+		up, down := highs[i]-highs[i-1], lows[i-1]-lows[i]
+		plusDM, minusDM := 0.0, 0.0
+		if up > down && up > 0 {
+			plusDM = up
+		}
+		if down > up && down > 0 {
+			minusDM = down
+		}
+
+		if i < p {
+			sumTR += trs[i]
+			sumPlus += plusDM
+			sumMinus += minusDM
+			continue
+		}
+		sumTR += trs[i] - sumTR/float64(p)
+		sumPlus += plusDM - sumPlus/float64(p)
+		sumMinus += minusDM - sumMinus/float64(p)
+
+		plusDI, minusDI := 0.0, 0.0
+		if sumTR != 0 {
+			plusDI, minusDI = 100*sumPlus/sumTR, 100*sumMinus/sumTR
+		}
+		out.PlusDI[i], out.MinusDI[i] = plusDI, minusDI
+		out.DX[i] = 0
+		if total := plusDI + minusDI; total != 0 {
+			out.DX[i] = 100 * math.Abs(plusDI-minusDI) / total
+		}
+
+		// ADX: média simples dos primeiros Period DX, depois suavização de Wilder
+		switch first := 2*p - 1; {
+		case i < first:
+			sumDX += out.DX[i]
+		case i == first:
+			out.ADX[i] = (sumDX + out.DX[i]) / float64(p)
+		default:
+			out.ADX[i] = (out.ADX[i-1]*float64(p-1) + out.DX[i]) / float64(p)
+		}
+	}
+	return out
+}
+
+// Supertrend configura o Supertrend: bandas de Multiplier ATRs (de Period candles) em torno do
+// ponto médio do candle, que só se aproximam do preço enquanto a tendência se mantém. Segue o
+// ta.supertrend do TradingView: começa em baixa e vira quando o fechamento rompe a banda atual.
+type Supertrend struct {
+	Period     int
+	Multiplier float64
+}
+
+// SupertrendLine é a linha do Supertrend (banda inferior na alta, superior na baixa) e a
+// direção da tendência em cada candle (1 alta, -1 baixa), alinhadas aos candles.
+type SupertrendLine struct {
+	Value     []float64
+	Direction []float64
+}
+
+// NewSupertrend cria o Supertrend com os parâmetros usuais (10, 3).
+func NewSupertrend() Supertrend {
+	return Supertrend{Period: 10, Multiplier: 3}
+}
+
+func (s Supertrend) Warmup() int { return s.Period + 1 }
+
+func (s Supertrend) Calculate(candles []entity.Candle) SupertrendLine {
+	highs, lows, closes, _ := splitCandles(candles)
+	atr := ATRSeries(highs, lows, closes, s.Period)
+	out := SupertrendLine{Value: nanSeries(len(closes)), Direction: nanSeries(len(closes))}
+
+	first := firstValid(atr)
+	var upper, lower, direction float64
+	for i := first; i < len(closes); i++ {
+		mid := (highs[i] + lows[i]) / 2
+		basicUpper, basicLower := mid+s.Multiplier*atr[i], mid-s.Multiplier*atr[i]
+
+		if i == first {
+			upper, lower, direction = basicUpper, basicLower, -1
+		} else {
+			// A banda só recua se o fechamento anterior a rompeu
+			if basicUpper < upper || closes[i-1] > upper {
+				upper = basicUpper
+			}
+			if basicLower > lower || closes[i-1] < lower {
+				lower = basicLower
+			}
+			switch {
+			case direction < 0 && closes[i] > upper:
+				direction = 1
+			case direction > 0 && closes[i] < lower:
+				direction = -1
+			}
+		}
+
+		out.Direction[i] = direction
+		out.Value[i] = lower
+		if direction < 0 {
+			out.Value[i] = upper
+		}
+	}
+	return out
+}
+
+// Ichimoku configura o Ichimoku Kinko Hyo. As Senkou Spans são deslocadas Displacement candles
+// para frente, ou seja, a nuvem em cada candle foi calculada Displacement candles antes (sem
+// lookahead). A Chikou Span (o fechamento deslocado para trás) não é exposta: compare
+// closes[i] com closes[i-Displacement].
+type Ichimoku struct {
+	TenkanPeriod  int
+	KijunPeriod   int
+	SenkouBPeriod int
+	Displacement  int
+}
+
+// IchimokuLines são as linhas do Ichimoku alinhadas aos candles.
+type IchimokuLines struct {
+	Tenkan  []float64
+	Kijun   []float64
+	SenkouA []float64
+	SenkouB []float64
+}
+
+// NewIchimoku cria o Ichimoku com os parâmetros usuais (9, 26, 52, 26).
+func NewIchimoku() Ichimoku {
+	return Ichimoku{TenkanPeriod: 9, KijunPeriod: 26, SenkouBPeriod: 52, Displacement: 26}
+}
+
+func (ic Ichimoku) Warmup() int {
+	return max(ic.TenkanPeriod, ic.KijunPeriod, ic.SenkouBPeriod) + ic.Displacement
+}
+
+func (ic Ichimoku) Calculate(candles []entity.Candle) IchimokuLines {
+	highs, lows, _, _ := splitCandles(candles)
+	tenkan := midpointSeries(highs, lows, ic.TenkanPeriod)
+	kijun := midpointSeries(highs, lows, ic.KijunPeriod)
+	senkouB := midpointSeries(highs, lows, ic.SenkouBPeriod)
+
+	out := IchimokuLines{
+		Tenkan:  tenkan,
+		Kijun:   kijun,
+		SenkouA: nanSeries(len(candles)),
+		SenkouB: nanSeries(len(candles)),
+	}
+	for i := ic.Displacement; i < len(candles); i++ {
+		from := i - ic.Displacement
+		out.SenkouA[i] = (tenkan[from] + kijun[from]) / 2
+		out.SenkouB[i] = senkouB[from]
+	}
+	return out
+}
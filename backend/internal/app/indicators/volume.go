@@ -0,0 +1,100 @@
+// internal/app/indicators/volume.go
+
+package indicators
+
+import (
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// OBV configura o On-Balance Volume: o volume acumulado, somado nos candles de alta e
+// subtraído nos de baixa. A série começa em 0 no primeiro candle.
+type OBV struct{}
+
+func (OBV) Warmup() int { return 1 }
+
+func (OBV) Calculate(candles []entity.Candle) []float64 {
+	out := make([]float64, len(candles))
+	for i := 1; i < len(candles); i++ {
+		out[i] = out[i-1]
+		switch {
+		case candles[i].Close > candles[i-1].Close:
+			out[i] += candles[i].Volume
+		case candles[i].Close < candles[i-1].Close:
+			out[i] -= candles[i].Volume
+		}
+	}
+	return out
+}
+
+// SessionVWAP configura o VWAP de sessão: o preço típico ((high + low + close) / 3) médio
+// ponderado pelo volume desde a abertura da sessão. As sessões são alinhadas ao UTC (a
+// padrão é o dia) e identificadas pelo OpenTime dos candles.
+type SessionVWAP struct {
+	Session time.Duration
+}
+
+// NewSessionVWAP cria o VWAP diário (sessões de 00:00 UTC).
+func NewSessionVWAP() SessionVWAP {
+	return SessionVWAP{Session: 24 * time.Hour}
+}
+
+func (SessionVWAP) Warmup() int { return 1 }
+
+func (v SessionVWAP) Calculate(candles []entity.Candle) []float64 {
+	session := v.Session.Milliseconds()
+	if session <= 0 {
+		session = (24 * time.Hour).Milliseconds()
+	}
+
+	out := make([]float64, len(candles))
+	var current int64
+	var priceVolume, volume float64
+	for i, c := range candles {
+		if start := c.OpenTime - c.OpenTime%session; i == 0 || start != current {
+			current, priceVolume, volume = start, 0, 0
+		}
+		typical := (c.High + c.Low + c.Close) / 3
+		priceVolume += typical * c.Volume
+		volume += c.Volume
+		out[i] = typical // sem volume na sessão, o VWAP é o preço típico
+		if volume != 0 {
+			out[i] = priceVolume / volume
+		}
+	}
+	return out
+}
+
+// RollingVWAP configura o VWAP móvel: o preço típico médio ponderado pelo volume dos últimos
+// Period candles.
+type RollingVWAP struct {
+	Period int
+}
+
+func (v RollingVWAP) Warmup() int { return v.Period }
+
+func (v RollingVWAP) Calculate(candles []entity.Candle) []float64 {
+	out := nanSeries(len(candles))
+	if v.Period < 1 {
+		return out
+	}
+	var priceVolume, volume float64
+	for i, c := range candles {
+		priceVolume += (c.High + c.Low + c.Close) / 3 * c.Volume
+		volume += c.Volume
+		if i >= v.Period {
+			old := candles[i-v.Period]
+			priceVolume -= (old.High + old.Low + old.Close) / 3 * old.Volume
+			volume -= old.Volume
+		}
+		if i < v.Period-1 {
+			continue
+		}
+		out[i] = (c.High + c.Low + c.Close) / 3
+		if volume > 0 {
+			out[i] = priceVolume / volume
+		}
+	}
+	return out
+}
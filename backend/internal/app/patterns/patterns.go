@@ -0,0 +1,238 @@
+// internal/app/patterns/patterns.go
+
+// Package patterns reconhece padrões de candlestick (engolfo, martelo, estrela cadente, doji,
+// estrela da manhã/noite, inside/outside bar, três soldados/corvos) sobre candles fechados,
+// para uso das estratégias como entradas adicionais ao preço e ao volume.
+package patterns
+
+import (
+	"math"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// Nomes dos padrões reconhecidos.
+const (
+	BullishEngulfing   = "bullish_engulfing"
+	BearishEngulfing   = "bearish_engulfing"
+	Hammer             = "hammer"
+	ShootingStar       = "shooting_star"
+	Doji               = "doji"
+	MorningStar        = "morning_star"
+	EveningStar        = "evening_star"
+	InsideBar          = "inside_bar"
+	OutsideBar         = "outside_bar"
+	ThreeWhiteSoldiers = "three_white_soldiers"
+	ThreeBlackCrows    = "three_black_crows"
+)
+
+// Direções dos padrões.
+const (
+	Bearish = -1
+	Neutral = 0
+	Bullish = 1
+)
+
+// Pattern é um padrão que se completa em um candle.
+type Pattern struct {
+	Name      string  `json:"name"`
+	Direction int     `json:"direction"` // 1 alta, -1 baixa, 0 neutro (indecisão)
+	Strength  float64 `json:"strength"`  // 0 a 1: quão bem o candle se encaixa no padrão
+}
+
+// Rules são as proporções usadas para classificar os candles.
+type Rules struct {
+	DojiBody       float64 // corpo máximo do doji, em fração da amplitude
+	ShadowRatio    float64 // sombra mínima do martelo/estrela cadente, em múltiplos do corpo
+	OppositeShadow float64 // sombra oposta máxima do martelo/estrela cadente, em fração da amplitude
+	LongBody       float64 // corpo mínimo de um candle "longo", em fração da amplitude
+	StarBody       float64 // corpo máximo da estrela, em fração do corpo do primeiro candle
+	TrendCandles   int     // candles anteriores que definem a tendência dos padrões de reversão
+}
+
+// DefaultRules retorna as proporções usuais.
+func DefaultRules() Rules {
+	return Rules{
+		DojiBody:       0.1,
+		ShadowRatio:    2,
+		OppositeShadow: 0.15,
+		LongBody:       0.5,
+		StarBody:       0.3,
+		TrendCandles:   3,
+	}
+}
+
+// Detect retorna, alinhados aos candles, os padrões que se completam em cada um (regras padrão).
+func Detect(candles []entity.Candle) [][]Pattern {
+	return DefaultRules().Detect(candles)
+}
+
+// DetectLast retorna os padrões que se completam no candle mais recente (regras padrão).
+func DetectLast(candles []entity.Candle) []Pattern {
+	return DefaultRules().DetectLast(candles)
+}
+
+// Lookback é a quantidade de candles necessária para avaliar todos os padrões no candle mais
+// recente (os de reversão exigem a tendência dos candles anteriores).
+func (r Rules) Lookback() int {
+	return max(r.TrendCandles+1, 3)
+}
+
+// Detect retorna, alinhados aos candles, os padrões que se completam em cada um.
+func (r Rules) Detect(candles []entity.Candle) [][]Pattern {
+	out := make([][]Pattern, len(candles))
+	for i := range candles {
+		out[i] = r.detectAt(candles, i)
+	}
+	return out
+}
+
+// DetectLast retorna os padrões que se completam no candle mais recente.
+func (r Rules) DetectLast(candles []entity.Candle) []Pattern {
+	if len(candles) == 0 {
+		return nil
+	}
+	return r.detectAt(candles, len(candles)-1)
+}
+
+// Score soma as direções ponderadas pela força: positivo favorece alta, negativo baixa.
+func Score(patterns []Pattern) float64 {
+	score := 0.0
+	for _, p := range patterns {
+		score += float64(p.Direction) * p.Strength
+	}
+	return score
+}
+
+// shape são as medidas de um candle.
+type shape struct {
+	body, rng, upper, lower float64
+	bullish, bearish        bool
+}
+
+func shapeOf(c entity.Candle) shape {
+	return shape{
+		body:    math.Abs(c.Close - c.Open),
+		rng:     c.High - c.Low,
+		upper:   c.High - math.Max(c.Open, c.Close),
+		lower:   math.Min(c.Open, c.Close) - c.Low,
+		bullish: c.Close > c.Open,
+		bearish: c.Close < c.Open,
+	}
+}
+
+func (r Rules) detectAt(candles []entity.Candle, i int) []Pattern {
+	var found []Pattern
+	add := func(name string, direction int, strength float64) {
+		found = append(found, Pattern{Name: name, Direction: direction, Strength: clamp(strength)})
+	}
+
+	c := candles[i]
+	cur := shapeOf(c)
+	if cur.rng <= 0 {
+		return nil
+	}
+
+	// 🕯️ Um candle
+	if cur.body <= r.DojiBody*cur.rng {
+		add(Doji, Neutral, 1-cur.body/(r.DojiBody*cur.rng))
+	}
+	if cur.body > 0 && cur.lower >= r.ShadowRatio*cur.body && cur.upper <= r.OppositeShadow*cur.rng && r.trend(candles, i) < 0 {
+		add(Hammer, Bullish, cur.lower/cur.rng)
+	}
+	if cur.body > 0 && cur.upper >= r.ShadowRatio*cur.body && cur.lower <= r.OppositeShadow*cur.rng && r.trend(candles, i) > 0 {
+		add(ShootingStar, Bearish, cur.upper/cur.rng)
+	}
+	if i < 1 {
+		return found
+	}
+
+	// 🕯️🕯️ Dois candles
+	prev := candles[i-1]
+	p := shapeOf(prev)
+	switch {
+	case p.bearish && cur.bullish && c.Open <= prev.Close && c.Close >= prev.Open && cur.body > p.body:
+		add(BullishEngulfing, Bullish, 1-p.body/cur.body)
+	case p.bullish && cur.bearish && c.Open >= prev.Close && c.Close <= prev.Open && cur.body > p.body:
+		add(BearishEngulfing, Bearish, 1-p.body/cur.body)
+	}
+	if p.rng > 0 && c.High < prev.High && c.Low > prev.Low {
+		add(InsideBar, Neutral, 1-cur.rng/p.rng)
+	}
+	if c.High > prev.High && c.Low < prev.Low {
+		direction := Neutral
+		if cur.bullish {
+			direction = Bullish
+		} else if cur.bearish {
+			direction = Bearish
+		}
+		add(OutsideBar, direction, 1-p.rng/cur.rng)
+	}
+	if i < 2 {
+		return found
+	}
+
+	// 🕯️🕯️🕯️ Três candles
+	first, star := candles[i-2], candles[i-1]
+	f, s := shapeOf(first), shapeOf(star)
+	isStar := f.rng > 0 && f.body >= r.LongBody*f.rng && s.body <= r.StarBody*f.body
+	// A estrela abre um gap: seu corpo fica inteiro além do fechamento do primeiro candle
+	mid := (first.Open + first.Close) / 2
+	starLow, starHigh := math.Min(star.Open, star.Close), math.Max(star.Open, star.Close)
+	if isStar && f.bearish && cur.bullish && starHigh < first.Close && c.Close > mid {
+		add(MorningStar, Bullish, (c.Close-mid)/(first.Open-mid))
+	}
+	if isStar && f.bullish && cur.bearish && starLow > first.Close && c.Close < mid {
+		add(EveningStar, Bearish, (mid-c.Close)/(mid-first.Open))
+	}
+	if strength, ok := r.threeCandles(candles[i-2:i+1], true); ok {
+		add(ThreeWhiteSoldiers, Bullish, strength)
+	}
+	if strength, ok := r.threeCandles(candles[i-2:i+1], false); ok {
+		add(ThreeBlackCrows, Bearish, strength)
+	}
+	return found
+}
+
+// threeCandles reconhece três soldados (alta) ou três corvos (baixa): três candles longos na
+// mesma direção, cada um abrindo dentro do corpo do anterior, fechando além dele e perto do
+// extremo. A força é a fração média do corpo na amplitude.
+func (r Rules) threeCandles(three []entity.Candle, up bool) (float64, bool) {
+	strength := 0.0
+	for k, c := range three {
+		sh := shapeOf(c)
+		if sh.rng <= 0 || sh.body < r.LongBody*sh.rng || (up && !sh.bullish) || (!up && !sh.bearish) {
+			return 0, false
+		}
+		if (up && sh.upper > r.OppositeShadow*2*sh.rng) || (!up && sh.lower > r.OppositeShadow*2*sh.rng) {
+			return 0, false
+		}
+		if k > 0 {
+			prev := three[k-1]
+			low, high := math.Min(prev.Open, prev.Close), math.Max(prev.Open, prev.Close)
+			if c.Open < low || c.Open > high || (up && c.Close <= prev.Close) || (!up && c.Close >= prev.Close) {
+				return 0, false
+			}
+		}
+		strength += sh.body / sh.rng / 3
+	}
+	return strength, true
+}
+
+// trend retorna a tendência dos candles anteriores a i: 1 alta, -1 baixa e 0 sem candles suficientes.
+func (r Rules) trend(candles []entity.Candle, i int) int {
+	if i < r.TrendCandles {
+		return 0
+	}
+	switch from, to := candles[i-r.TrendCandles].Close, candles[i-1].Close; {
+	case to > from:
+		return 1
+	case to < from:
+		return -1
+	}
+	return 0
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,105 @@
+// internal/app/patterns/patterns_test.go
+
+package patterns_test
+
+import (
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/patterns"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func candle(open, high, low, close float64) entity.Candle {
+	return entity.Candle{Open: open, High: high, Low: low, Close: close}
+}
+
+// declining e rising são candles de tendência (corpos pequenos, sem padrão próprio) que
+// antecedem os padrões de reversão.
+var (
+	declining = []entity.Candle{candle(110, 110.5, 108.5, 109), candle(108.5, 109, 107, 107.5), candle(107, 107.5, 105.5, 106)}
+	rising    = []entity.Candle{candle(100, 101.5, 99.5, 101), candle(101.5, 103, 101, 102.5), candle(103, 104.5, 102.5, 104)}
+)
+
+func find(t *testing.T, found []patterns.Pattern, name string) patterns.Pattern {
+	t.Helper()
+	for _, p := range found {
+		if p.Name == name {
+			return p
+		}
+	}
+	require.Failf(t, "padrão não encontrado", "%s em %+v", name, found)
+	return patterns.Pattern{}
+}
+
+func names(found []patterns.Pattern) []string {
+	out := make([]string, len(found))
+	for i, p := range found {
+		out[i] = p.Name
+	}
+	return out
+}
+
+func TestDetectSingleCandlePatterns(t *testing.T) {
+	hammer := find(t, patterns.DetectLast(append(declining, candle(105.2, 105.6, 101, 105.5))), patterns.Hammer)
+	assert.Equal(t, patterns.Bullish, hammer.Direction)
+	assert.Greater(t, hammer.Strength, 0.8)
+
+	star := find(t, patterns.DetectLast(append(rising, candle(104.3, 108.5, 104, 104))), patterns.ShootingStar)
+	assert.Equal(t, patterns.Bearish, star.Direction)
+
+	// A mesma forma do martelo em alta não é um martelo
+	assert.NotContains(t, names(patterns.DetectLast(append(rising, candle(104.2, 104.6, 100, 104.5)))), patterns.Hammer)
+
+	doji := find(t, patterns.DetectLast([]entity.Candle{candle(100, 102, 98, 100)}), patterns.Doji)
+	assert.Equal(t, patterns.Neutral, doji.Direction)
+	assert.Equal(t, 1.0, doji.Strength)
+}
+
+func TestDetectTwoCandlePatterns(t *testing.T) {
+	bullish := patterns.DetectLast([]entity.Candle{candle(102, 102.5, 99.5, 100), candle(99.8, 103.5, 99.5, 103)})
+	assert.Equal(t, patterns.Bullish, find(t, bullish, patterns.BullishEngulfing).Direction)
+
+	bearish := patterns.DetectLast([]entity.Candle{candle(100, 102.5, 99.5, 102), candle(102.2, 102.8, 98.5, 99)})
+	assert.Equal(t, patterns.Bearish, find(t, bearish, patterns.BearishEngulfing).Direction)
+	assert.Equal(t, patterns.Bearish, find(t, bearish, patterns.OutsideBar).Direction)
+
+	inside := find(t, patterns.DetectLast([]entity.Candle{candle(100, 110, 90, 105), candle(102, 104, 98, 101)}), patterns.InsideBar)
+	assert.InDelta(t, 0.7, inside.Strength, 1e-9)
+}
+
+func TestDetectThreeCandlePatterns(t *testing.T) {
+	morning := []entity.Candle{candle(110, 110.5, 101.5, 102), candle(101.5, 102, 100.5, 101.2), candle(102, 109, 101.8, 108.5)}
+	assert.Equal(t, patterns.Bullish, find(t, patterns.DetectLast(morning), patterns.MorningStar).Direction)
+
+	evening := []entity.Candle{candle(100, 108.5, 99.5, 108), candle(108.5, 109.5, 108, 108.8), candle(108, 108.2, 100.5, 101)}
+	assert.Equal(t, patterns.Bearish, find(t, patterns.DetectLast(evening), patterns.EveningStar).Direction)
+
+	// Sem gap entre os corpos do primeiro candle e da estrela não há estrela da manhã/noite
+	overlapping := []entity.Candle{morning[0], candle(102.5, 103, 101, 101.5), morning[2]}
+	assert.NotContains(t, names(patterns.DetectLast(overlapping)), patterns.MorningStar)
+	overlapping = []entity.Candle{evening[0], candle(107.5, 108.5, 107, 108.3), evening[2]}
+	assert.NotContains(t, names(patterns.DetectLast(overlapping)), patterns.EveningStar)
+
+	soldiers := []entity.Candle{candle(100, 103.2, 99.8, 103), candle(102, 106.2, 101.8, 106), candle(105, 109.2, 104.8, 109)}
+	assert.Greater(t, find(t, patterns.DetectLast(soldiers), patterns.ThreeWhiteSoldiers).Strength, 0.9)
+
+	crows := []entity.Candle{candle(109, 109.2, 105.8, 106), candle(107, 107.2, 102.8, 103), candle(104, 104.2, 99.8, 100)}
+	assert.Equal(t, patterns.Bearish, find(t, patterns.DetectLast(crows), patterns.ThreeBlackCrows).Direction)
+}
+
+func TestDetectAlignsWithCandles(t *testing.T) {
+	candles := append(declining, candle(105.2, 105.6, 101, 105.5))
+	detected := patterns.Detect(candles)
+	require.Len(t, detected, len(candles))
+	assert.Empty(t, detected[0])
+	assert.Contains(t, names(detected[3]), patterns.Hammer)
+
+	assert.Empty(t, patterns.DetectLast(nil))
+	assert.InDelta(t, 0.5-0.25, patterns.Score([]patterns.Pattern{
+		{Name: patterns.Hammer, Direction: patterns.Bullish, Strength: 0.5},
+		{Name: patterns.ShootingStar, Direction: patterns.Bearish, Strength: 0.25},
+		{Name: patterns.Doji, Direction: patterns.Neutral, Strength: 1},
+	}), 1e-9)
+}
@@ -0,0 +1,110 @@
+// internal/app/sizing/sizing.go
+
+// Package sizing calcula a quantidade (em ativo base) de uma nova posição.
+package sizing
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Métodos de dimensionamento, configurados por bot em "sizing_method".
+const (
+	MethodFixedQuote       = "fixed_quote"       // valor fixo em moeda de cotação
+	MethodBalancePercent   = "balance_pct"       // percentual do saldo disponível
+	MethodFixedFractional  = "fixed_fractional"  // risco fixo do saldo até o stop por ATR
+	MethodVolatilityTarget = "volatility_target" // exposição inversamente proporcional ao ATR%
+)
+
+// Methods lista os métodos disponíveis.
+var Methods = []string{MethodFixedQuote, MethodBalancePercent, MethodFixedFractional, MethodVolatilityTarget}
+
+// IsValidMethod informa se o método de dimensionamento existe.
+func IsValidMethod(method string) bool {
+	for _, m := range Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoSize indica que não é possível abrir posição com os dados atuais.
+var ErrNoSize = errors.New("tamanho de posição indisponível")
+
+// Input reúne os dados de mercado e de conta usados no dimensionamento.
+type Input struct {
+	Price   float64 // preço estimado de entrada
+	Balance float64 // saldo disponível em moeda de cotação
+	ATR     float64 // Average True Range atual
+}
+
+// Sizer calcula a quantidade em ativo base para a entrada.
+type Sizer interface {
+	Size(in Input) (float64, error)
+}
+
+// FixedQuote investe sempre o mesmo valor em moeda de cotação (ex: 100 USDT).
+type FixedQuote struct {
+	Amount float64
+}
+
+func (s FixedQuote) Size(in Input) (float64, error) {
+	if in.Price <= 0 || s.Amount <= 0 {
+		return 0, ErrNoSize
+	}
+	return s.Amount / in.Price, nil
+}
+
+// BalancePercent investe um percentual do saldo disponível.
+type BalancePercent struct {
+	Percent float64
+}
+
+func (s BalancePercent) Size(in Input) (float64, error) {
+	if in.Price <= 0 || in.Balance <= 0 || s.Percent <= 0 {
+		return 0, fmt.Errorf("%w: saldo %.8f", ErrNoSize, in.Balance)
+	}
+	return in.Balance * s.Percent / 100 / in.Price, nil
+}
+
+// FixedFractional arrisca RiskPercent do saldo entre a entrada e o stop, colocado a
+// ATRMultiplier × ATR do preço. A exposição é limitada a MaxPositionPercent do saldo.
+type FixedFractional struct {
+	RiskPercent        float64
+	ATRMultiplier      float64
+	MaxPositionPercent float64
+}
+
+func (s FixedFractional) Size(in Input) (float64, error) {
+	stopDistance := in.ATR * s.ATRMultiplier
+	if in.Price <= 0 || in.Balance <= 0 || stopDistance <= 0 || s.RiskPercent <= 0 {
+		return 0, fmt.Errorf("%w: saldo %.8f, distância do stop %.8f", ErrNoSize, in.Balance, stopDistance)
+	}
+	quantity := in.Balance * s.RiskPercent / 100 / stopDistance
+	return capToBalance(quantity, in, s.MaxPositionPercent), nil
+}
+
+// VolatilityTarget ajusta a exposição para que a volatilidade da posição (ATR em % do preço)
+// corresponda a TargetPercent do saldo: quanto mais volátil o ativo, menor a posição.
+type VolatilityTarget struct {
+	TargetPercent      float64
+	MaxPositionPercent float64
+}
+
+func (s VolatilityTarget) Size(in Input) (float64, error) {
+	if in.Price <= 0 || in.Balance <= 0 || in.ATR <= 0 || s.TargetPercent <= 0 {
+		return 0, fmt.Errorf("%w: saldo %.8f, ATR %.8f", ErrNoSize, in.Balance, in.ATR)
+	}
+	atrPct := in.ATR / in.Price * 100
+	notional := in.Balance * s.TargetPercent / atrPct
+	return capToBalance(notional/in.Price, in, s.MaxPositionPercent), nil
+}
+
+// capToBalance limita a exposição a maxPct do saldo (sem limite se maxPct <= 0).
+func capToBalance(quantity float64, in Input, maxPct float64) float64 {
+	if maxPct <= 0 {
+		return quantity
+	}
+	return min(quantity, in.Balance*maxPct/100/in.Price)
+}
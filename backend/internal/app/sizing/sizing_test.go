@@ -0,0 +1,41 @@
+// internal/app/sizing/sizing_test.go
+
+package sizing_test
+
+import (
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/sizing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizers(t *testing.T) {
+	in := sizing.Input{Price: 100, Balance: 10000, ATR: 2}
+
+	qty, err := sizing.FixedQuote{Amount: 500}.Size(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 5, qty, 1e-9)
+
+	qty, err = sizing.BalancePercent{Percent: 10}.Size(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 10, qty, 1e-9)
+
+	// Risco de 1% (100) com stop a 2×ATR (4) => 25 unidades (2500, dentro do limite de 50%)
+	qty, err = sizing.FixedFractional{RiskPercent: 1, ATRMultiplier: 2, MaxPositionPercent: 50}.Size(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 25, qty, 1e-9)
+
+	// Limite de exposição de 10% do saldo
+	qty, err = sizing.FixedFractional{RiskPercent: 1, ATRMultiplier: 2, MaxPositionPercent: 10}.Size(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 10, qty, 1e-9)
+
+	// ATR de 2% e alvo de 0.5% => exposição de 25% do saldo
+	qty, err = sizing.VolatilityTarget{TargetPercent: 0.5, MaxPositionPercent: 100}.Size(in)
+	require.NoError(t, err)
+	assert.InDelta(t, 25, qty, 1e-9)
+
+	_, err = sizing.BalancePercent{Percent: 10}.Size(sizing.Input{Price: 100})
+	assert.ErrorIs(t, err, sizing.ErrNoSize)
+}
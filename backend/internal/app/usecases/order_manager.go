@@ -0,0 +1,167 @@
+// internal/app/usecases/order_manager.go
+
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	service "github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+// maxPendingOrderAge é o tempo máximo que uma ordem fica pendente antes de ser cancelada
+// (ou dada como expirada, se a exchange não responder).
+const maxPendingOrderAge = 15 * time.Minute
+
+// OrderManager envia as ordens dos bots autônomos e persiste o ciclo de vida de cada uma
+// (NEW → PARTIALLY_FILLED → FILLED, CANCELED, REJECTED ou EXPIRED), consultando a exchange
+// até a ordem ser finalizada.
+type OrderManager struct {
+	orderRepo repository.OrderRepository
+}
+
+// NewOrderManager cria o gerenciador de ordens.
+func NewOrderManager(orderRepo repository.OrderRepository) *OrderManager {
+	return &OrderManager{orderRepo: orderRepo}
+}
+
+// Submit envia a ordem e a grava. Ordens recusadas pela exchange também são gravadas
+// (status REJECTED) e o erro é retornado junto com a ordem.
+func (m *OrderManager) Submit(exchange service.OrderService, accountID uuid.UUID, bot entity.Bot, req entity.OrderRequest) (*entity.Order, error) {
+	if req.ClientOrderID == "" {
+		req.ClientOrderID = "cb" + strings.ReplaceAll(uuid.NewString(), "-", "")[:30]
+	}
+
+	order, placeErr := exchange.PlaceOrder(req)
+	if order == nil {
+		order = &entity.Order{
+			Symbol:        req.Symbol,
+			Side:          req.Side,
+			Type:          req.Type,
+			Status:        entity.OrderStatusRejected,
+			Quantity:      req.Quantity,
+			Price:         req.Price,
+			ClientOrderID: req.ClientOrderID,
+		}
+	}
+	order.AccountID = accountID
+	order.BotID = bot.ID
+
+	saved, err := m.orderRepo.Save(*order)
+	if err != nil {
+		logger.Error("Erro ao gravar ordem", err, "bot_id", bot.ID.String(), "exchange_order_id", order.ExchangeOrderID)
+		saved = order
+		saved.CreatedAt = time.Now()
+	}
+
+	if placeErr != nil {
+		logger.Error("❌ Ordem recusada", placeErr,
+			"bot_id", bot.ID.String(),
+			"symbol", req.Symbol,
+			"side", req.Side,
+			"quantity", req.Quantity,
+		)
+		return saved, placeErr
+	}
+
+	logger.Info("🧾 Ordem enviada",
+		"bot_id", bot.ID.String(),
+		"symbol", saved.Symbol,
+		"side", saved.Side,
+		"type", saved.Type,
+		"status", saved.Status,
+		"quantity", saved.Quantity,
+		"executed", saved.ExecutedQuantity,
+		"paper", saved.Paper,
+	)
+	return saved, nil
+}
+
+// Refresh consulta a ordem na exchange e grava as alterações de execução. Ordens que a
+// exchange não conhece são finalizadas como REJECTED; ordens pendentes há mais de
+// maxPendingOrderAge são canceladas ou, se nem isso for possível, finalizadas como EXPIRED.
+func (m *OrderManager) Refresh(exchange service.OrderService, order *entity.Order) (*entity.Order, error) {
+	if order.Status.IsFinal() {
+		return order, nil
+	}
+
+	current, err := exchange.GetOrder(order.Symbol, order.ExchangeOrderID)
+	if errors.Is(err, service.ErrOrderNotFound) {
+		logger.Warn("⚠️ Ordem pendente desconhecida pela exchange, finalizando", "order_id", order.ID.String(), "exchange_order_id", order.ExchangeOrderID)
+		return m.finish(order, entity.OrderStatusRejected), nil
+	}
+	if err == nil {
+		if order, err = m.apply(order, current); order.Status.IsFinal() {
+			return order, err
+		}
+	}
+	if time.Since(order.CreatedAt) < maxPendingOrderAge {
+		if err != nil {
+			return order, fmt.Errorf("erro ao consultar ordem %s: %w", order.ExchangeOrderID, err)
+		}
+		return order, nil
+	}
+
+	if canceled, cancelErr := m.Cancel(exchange, order); cancelErr == nil && canceled.Status.IsFinal() {
+		return canceled, nil
+	}
+	logger.Warn("⚠️ Ordem pendente há tempo demais, finalizando como expirada", "order_id", order.ID.String(), "exchange_order_id", order.ExchangeOrderID)
+	return m.finish(order, entity.OrderStatusExpired), nil
+}
+
+// finish finaliza localmente uma ordem que a exchange não pode mais atualizar, mantendo a
+// execução já conhecida.
+func (m *OrderManager) finish(order *entity.Order, status entity.OrderStatus) *entity.Order {
+	finished := *order
+	finished.Status = status
+	if err := m.orderRepo.Update(finished); err != nil {
+		logger.Error("Erro ao atualizar ordem", err, "order_id", order.ID.String())
+	}
+	return &finished
+}
+
+// Cancel cancela a ordem na exchange e grava o novo estado.
+func (m *OrderManager) Cancel(exchange service.OrderService, order *entity.Order) (*entity.Order, error) {
+	current, err := exchange.CancelOrder(order.Symbol, order.ExchangeOrderID)
+	if err != nil {
+		return order, fmt.Errorf("erro ao cancelar ordem %s: %w", order.ExchangeOrderID, err)
+	}
+	return m.apply(order, current)
+}
+
+// OpenOrders retorna as ordens não finalizadas do bot (usado ao reiniciar o bot).
+func (m *OrderManager) OpenOrders(botID uuid.UUID) ([]entity.Order, error) {
+	return m.orderRepo.GetOpenByBot(botID)
+}
+
+func (m *OrderManager) apply(order, current *entity.Order) (*entity.Order, error) {
+	updated := *order
+	updated.Status = current.Status
+	updated.ExecutedQuantity = current.ExecutedQuantity
+	updated.QuoteQuantity = current.QuoteQuantity
+	if current.Fee > 0 {
+		updated.Fee = current.Fee
+		updated.FeeAsset = current.FeeAsset
+	}
+
+	if updated.Status == order.Status && updated.ExecutedQuantity == order.ExecutedQuantity {
+		return &updated, nil
+	}
+
+	if err := m.orderRepo.Update(updated); err != nil {
+		logger.Error("Erro ao atualizar ordem", err, "order_id", order.ID.String())
+	}
+	logger.Info("🧾 Ordem atualizada",
+		"bot_id", order.BotID.String(),
+		"exchange_order_id", order.ExchangeOrderID,
+		"status", updated.Status,
+		"executed", updated.ExecutedQuantity,
+	)
+	return &updated, nil
+}
@@ -0,0 +1,86 @@
+// internal/app/usecases/order_manager_test.go
+
+package usecases_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/paper"
+	"github.com/jeancarlosdanese/crypto-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderManagerTracksOrderUntilFilled(t *testing.T) {
+	logger.InitLogger()
+
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 100}}
+	exchange, err := paper.NewPaperExchange(uuid.New(), market, paper.DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	repo := mocks.NewMockOrderRepository()
+	manager := usecases.NewOrderManager(repo)
+	bot := entity.Bot{ID: uuid.New(), Symbol: "BTC/USDT"}
+
+	order, err := manager.Submit(exchange, uuid.New(), bot, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeLimit, Quantity: 1, Price: 90,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusNew, order.Status)
+	assert.NotEmpty(t, order.ClientOrderID)
+
+	open, _ := manager.OpenOrders(bot.ID)
+	assert.Len(t, open, 1)
+
+	exchange.OnCandle("BTCUSDT", entity.Candle{Open: 95, High: 96, Low: 89, Close: 92})
+
+	order, err = manager.Refresh(exchange, order)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusFilled, order.Status)
+	assert.Equal(t, entity.OrderStatusFilled, repo.Orders[order.ID].Status)
+
+	open, _ = manager.OpenOrders(bot.ID)
+	assert.Empty(t, open)
+
+	// Ordem recusada também fica registrada
+	rejected, err := manager.Submit(exchange, uuid.New(), bot, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeMarket, Quantity: 1000,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, entity.OrderStatusRejected, repo.Orders[rejected.ID].Status)
+}
+
+func TestOrderManagerFinishesLostAndStaleOrders(t *testing.T) {
+	logger.InitLogger()
+
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 100}}
+	exchange, err := paper.NewPaperExchange(uuid.New(), market, paper.DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	repo := mocks.NewMockOrderRepository()
+	manager := usecases.NewOrderManager(repo)
+
+	// Ordem simulada perdida no reinício: a exchange não a conhece mais
+	lost, _ := repo.Save(entity.Order{ExchangeOrderID: "paper-1-1", Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Status: entity.OrderStatusNew, CreatedAt: time.Now()})
+	order, err := manager.Refresh(exchange, lost)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusRejected, order.Status)
+	assert.Equal(t, entity.OrderStatusRejected, repo.Orders[lost.ID].Status)
+
+	// Exchange sem resposta: a ordem recente segue pendente, a antiga expira
+	recent, _ := repo.Save(entity.Order{ExchangeOrderID: "1", Symbol: "BTCUSDT", Status: entity.OrderStatusNew, CreatedAt: time.Now()})
+	order, err = manager.Refresh(market, recent)
+	assert.Error(t, err)
+	assert.Equal(t, entity.OrderStatusNew, order.Status)
+
+	stale, _ := repo.Save(entity.Order{ExchangeOrderID: "2", Symbol: "BTCUSDT", Status: entity.OrderStatusNew, CreatedAt: time.Now().Add(-time.Hour)})
+	order, err = manager.Refresh(market, stale)
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusExpired, order.Status)
+	assert.Equal(t, entity.OrderStatusExpired, repo.Orders[stale.ID].Status)
+}
@@ -0,0 +1,349 @@
+// internal/app/usecases/risk_guard.go
+
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	service "github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+// exposureAsset é a moeda em que exposição e limites da conta são medidos.
+const exposureAsset = "USDT"
+
+// Regras de risco da conta que podem bloquear uma entrada.
+const (
+	RiskRuleKillSwitch           = "kill_switch"
+	RiskRuleMaxOpenPositions     = "max_open_positions"
+	RiskRuleMaxTotalExposure     = "max_total_exposure"
+	RiskRuleMaxAssetExposure     = "max_asset_exposure"
+	RiskRuleMaxDailyLoss         = "max_daily_loss"
+	RiskRuleMaxConsecutiveLosses = "max_consecutive_losses"
+)
+
+// ErrEntryBlocked indica que a entrada foi barrada pelos limites de risco da conta.
+var ErrEntryBlocked = errors.New("entrada bloqueada pelos limites de risco")
+
+// RiskBlock detalha a regra que bloqueou a entrada.
+type RiskBlock struct {
+	Rule   string
+	Detail string
+}
+
+func (b *RiskBlock) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", ErrEntryBlocked, b.Rule, b.Detail)
+}
+
+func (b *RiskBlock) Unwrap() error { return ErrEntryBlocked }
+
+// RiskGuard avalia os limites de risco da conta antes de qualquer entrada dos seus bots.
+// As avaliações de uma mesma conta são serializadas, para que bots que entram ao mesmo
+// tempo não ultrapassem juntos os limites. Ordens de compra ainda abertas contam como
+// posições. A exposição é convertida para USDT ao preço atual de cada ativo, obtido de market.
+type RiskGuard struct {
+	limitsRepo    repository.RiskLimitsRepository
+	positionRepo  repository.PositionRepository
+	orderRepo     repository.OrderRepository
+	executionRepo repository.ExecutionLogRepository
+	market        service.ExchangeService
+	now           func() time.Time
+
+	mu    sync.Mutex
+	locks map[uuid.UUID]*sync.Mutex
+}
+
+// NewRiskGuard cria o avaliador de limites de risco.
+func NewRiskGuard(
+	limitsRepo repository.RiskLimitsRepository,
+	positionRepo repository.PositionRepository,
+	orderRepo repository.OrderRepository,
+	executionRepo repository.ExecutionLogRepository,
+	market service.ExchangeService,
+) *RiskGuard {
+	return &RiskGuard{
+		limitsRepo:    limitsRepo,
+		positionRepo:  positionRepo,
+		orderRepo:     orderRepo,
+		executionRepo: executionRepo,
+		market:        market,
+		now:           time.Now,
+		locks:         make(map[uuid.UUID]*sync.Mutex),
+	}
+}
+
+// CheckEntry avalia uma entrada de notional (em moeda de cotação) no par symbol ("BASE/QUOTE").
+// Se aprovada, retorna release, que deve ser chamada depois que a posição for aberta ou a
+// ordem enviada. Bloqueios retornam *RiskBlock; falhas ao consultar os dados também
+// bloqueiam a entrada.
+func (g *RiskGuard) CheckEntry(accountID uuid.UUID, symbol string, notional float64) (release func(), err error) {
+	lock := g.accountLock(accountID)
+	lock.Lock()
+
+	if err := g.check(accountID, symbol, notional); err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	return lock.Unlock, nil
+}
+
+func (g *RiskGuard) check(accountID uuid.UUID, symbol string, notional float64) error {
+	limits, err := g.limitsRepo.Get(accountID)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar limites de risco: %w", err)
+	}
+	if limits == nil {
+		return nil
+	}
+	if limits.KillSwitch {
+		return &RiskBlock{Rule: RiskRuleKillSwitch, Detail: "kill switch ativo"}
+	}
+
+	if limits.MaxOpenPositions > 0 || limits.MaxTotalExposure > 0 || limits.MaxAssetExposure > 0 {
+		positions, err := g.positionRepo.GetByAccount(accountID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar posições da conta: %w", err)
+		}
+		orders, err := g.orderRepo.GetOpenByAccount(accountID)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar ordens abertas da conta: %w", err)
+		}
+		if err := g.checkExposure(limits, positions, pendingEntries(positions, orders), symbol, notional); err != nil {
+			return err
+		}
+	}
+
+	if limits.MaxDailyLoss > 0 {
+		now := g.now().UTC()
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		executions, err := g.executionRepo.GetByAccountSince(accountID, startOfDay)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar execuções do dia: %w", err)
+		}
+		if err := g.checkDailyLoss(limits, executions); err != nil {
+			return err
+		}
+	}
+
+	if limits.MaxConsecutiveLosses > 0 {
+		// Basta olhar as últimas N execuções: a sequência é contada até o último ganho, sem limite de dias
+		executions, err := g.executionRepo.GetLatestByAccount(accountID, limits.MaxConsecutiveLosses)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar execuções recentes: %w", err)
+		}
+		if err := checkConsecutiveLosses(limits, executions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkExposure avalia posições abertas e exposição incluindo a nova entrada, com cada
+// posição valorizada em USDT ao preço atual do ativo base. As compras pendentes (entries)
+// contam como posições pela quantidade da ordem.
+func (g *RiskGuard) checkExposure(limits *entity.RiskLimits, positions []entity.OpenPosition, entries []entity.Order, symbol string, notional float64) error {
+	open := len(positions) + len(entries)
+	if limits.MaxOpenPositions > 0 && open >= limits.MaxOpenPositions {
+		return &RiskBlock{
+			Rule:   RiskRuleMaxOpenPositions,
+			Detail: fmt.Sprintf("%d posições abertas, limite %d", open, limits.MaxOpenPositions),
+		}
+	}
+	if limits.MaxTotalExposure <= 0 && limits.MaxAssetExposure <= 0 {
+		return nil
+	}
+
+	// O notional da entrada está na moeda de cotação do par
+	entry, err := g.toUSDT(quoteAsset(symbol), notional)
+	if err != nil {
+		return err
+	}
+
+	base := baseAsset(symbol)
+	total, asset := entry, entry
+	for _, p := range positions {
+		exposure, err := g.toUSDT(baseAsset(p.Symbol), p.Quantity)
+		if err != nil {
+			return err
+		}
+		total += exposure
+		if baseAsset(p.Symbol) == base {
+			asset += exposure
+		}
+	}
+	for _, o := range entries {
+		orderBase, err := g.orderBase(o)
+		if err != nil {
+			return err
+		}
+		exposure, err := g.toUSDT(orderBase, o.Quantity)
+		if err != nil {
+			return err
+		}
+		total += exposure
+		if orderBase == base {
+			asset += exposure
+		}
+	}
+
+	if limits.MaxTotalExposure > 0 && total > limits.MaxTotalExposure {
+		return &RiskBlock{
+			Rule:   RiskRuleMaxTotalExposure,
+			Detail: fmt.Sprintf("exposição total %.2f, limite %.2f", total, limits.MaxTotalExposure),
+		}
+	}
+	if limits.MaxAssetExposure > 0 && asset > limits.MaxAssetExposure {
+		return &RiskBlock{
+			Rule:   RiskRuleMaxAssetExposure,
+			Detail: fmt.Sprintf("exposição em %s %.2f, limite %.2f", base, asset, limits.MaxAssetExposure),
+		}
+	}
+	return nil
+}
+
+// pendingEntries retorna as ordens de compra abertas de bots que ainda não têm posição:
+// a posição só é aberta quando a ordem termina de executar.
+func pendingEntries(positions []entity.OpenPosition, orders []entity.Order) []entity.Order {
+	holding := make(map[uuid.UUID]bool, len(positions))
+	for _, p := range positions {
+		holding[p.BotID] = true
+	}
+	var entries []entity.Order
+	for _, o := range orders {
+		if o.Side == entity.OrderSideBuy && !holding[o.BotID] {
+			holding[o.BotID] = true
+			entries = append(entries, o)
+		}
+	}
+	return entries
+}
+
+// orderBase retorna o ativo base da ordem, cujo símbolo está no formato da exchange.
+func (g *RiskGuard) orderBase(o entity.Order) (string, error) {
+	if g.market == nil {
+		return "", fmt.Errorf("sem dados do símbolo %s", o.Symbol)
+	}
+	base, _, err := g.market.GetBaseQuote(o.Symbol)
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter ativos de %s: %w", o.Symbol, err)
+	}
+	return base, nil
+}
+
+// toUSDT converte amount do ativo informado para USDT ao preço atual (par ASSETUSDT).
+func (g *RiskGuard) toUSDT(asset string, amount float64) (float64, error) {
+	if asset == exposureAsset || amount == 0 {
+		return amount, nil
+	}
+	if g.market == nil {
+		return 0, fmt.Errorf("sem cotação para converter %s em %s", asset, exposureAsset)
+	}
+	price, err := g.market.GetCurrentPrice(asset + exposureAsset)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao obter cotação de %s em %s: %w", asset, exposureAsset, err)
+	}
+	return amount * price, nil
+}
+
+// checkDailyLoss avalia o resultado realizado no dia, com o resultado de cada execução
+// (na moeda de cotação do par) convertido para USDT ao preço atual.
+func (g *RiskGuard) checkDailyLoss(limits *entity.RiskLimits, executions []entity.ExecutionLog) error {
+	realized := 0.0
+	for _, e := range executions {
+		profit, err := g.toUSDT(quoteAsset(e.Symbol), e.Profit)
+		if err != nil {
+			return err
+		}
+		realized += profit
+	}
+	if limits.MaxDailyLoss > 0 && -realized >= limits.MaxDailyLoss {
+		return &RiskBlock{
+			Rule:   RiskRuleMaxDailyLoss,
+			Detail: fmt.Sprintf("resultado do dia %.2f, perda máxima %.2f", realized, limits.MaxDailyLoss),
+		}
+	}
+	return nil
+}
+
+// checkConsecutiveLosses conta as perdas seguidas mais recentes (execuções da mais recente
+// para a mais antiga), até o primeiro ganho.
+func checkConsecutiveLosses(limits *entity.RiskLimits, executions []entity.ExecutionLog) error {
+	consecutive := 0
+	for _, e := range executions {
+		if e.Profit >= 0 {
+			break
+		}
+		consecutive++
+	}
+	if limits.MaxConsecutiveLosses > 0 && consecutive >= limits.MaxConsecutiveLosses {
+		return &RiskBlock{
+			Rule:   RiskRuleMaxConsecutiveLosses,
+			Detail: fmt.Sprintf("%d perdas seguidas, limite %d", consecutive, limits.MaxConsecutiveLosses),
+		}
+	}
+	return nil
+}
+
+// Limits retorna os limites da conta (zerados se nunca foram configurados).
+func (g *RiskGuard) Limits(accountID uuid.UUID) (*entity.RiskLimits, error) {
+	limits, err := g.limitsRepo.Get(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if limits == nil {
+		limits = &entity.RiskLimits{AccountID: accountID}
+	}
+	return limits, nil
+}
+
+// SaveLimits grava os limites da conta.
+func (g *RiskGuard) SaveLimits(limits entity.RiskLimits) error {
+	return g.limitsRepo.Save(limits)
+}
+
+// SetKillSwitch liga ou desliga o bloqueio de novas entradas da conta.
+func (g *RiskGuard) SetKillSwitch(accountID uuid.UUID, enabled bool) (*entity.RiskLimits, error) {
+	limits, err := g.Limits(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits.KillSwitch = enabled
+	limits.KillSwitchAt = nil
+	if enabled {
+		now := g.now()
+		limits.KillSwitchAt = &now
+	}
+	if err := g.limitsRepo.Save(*limits); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (g *RiskGuard) accountLock(accountID uuid.UUID) *sync.Mutex {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	lock, ok := g.locks[accountID]
+	if !ok {
+		lock = &sync.Mutex{}
+		g.locks[accountID] = lock
+	}
+	return lock
+}
+
+func baseAsset(symbol string) string {
+	base, _, _ := strings.Cut(symbol, "/")
+	return base
+}
+
+func quoteAsset(symbol string) string {
+	_, quote, _ := strings.Cut(symbol, "/")
+	return quote
+}
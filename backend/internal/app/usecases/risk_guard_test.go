@@ -0,0 +1,190 @@
+// internal/app/usecases/risk_guard_test.go
+
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertBlockedBy(t *testing.T, err error, rule string) {
+	t.Helper()
+	var block *usecases.RiskBlock
+	require.True(t, errors.As(err, &block), "esperava bloqueio, obteve %v", err)
+	assert.Equal(t, rule, block.Rule)
+	assert.ErrorIs(t, err, usecases.ErrEntryBlocked)
+}
+
+func TestRiskGuardExposureLimits(t *testing.T) {
+	accountID := uuid.New()
+	limitsRepo := mocks.NewMockRiskLimitsRepository()
+	positionRepo := mocks.NewMockPositionRepository()
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 120, "ETHUSDT": 10}}
+	guard := usecases.NewRiskGuard(limitsRepo, positionRepo, mocks.NewMockOrderRepository(), &mocks.MockExecutionLogRepository{}, market)
+
+	// Sem limites configurados tudo é permitido
+	release, err := guard.CheckEntry(accountID, "BTC/USDT", 1_000_000)
+	require.NoError(t, err)
+	release()
+
+	limitsRepo.Save(entity.RiskLimits{AccountID: accountID, MaxOpenPositions: 2, MaxTotalExposure: 1000, MaxAssetExposure: 400})
+	// Comprada a 100, a posição vale 3 × 120 = 360 USDT ao preço atual
+	positionRepo.Save(entity.OpenPosition{BotID: uuid.New(), Symbol: "BTC/USDT", EntryPrice: 100, Quantity: 3})
+
+	release, err = guard.CheckEntry(accountID, "ETH/USDT", 400)
+	require.NoError(t, err)
+	release()
+
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 50)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxAssetExposure)
+
+	_, err = guard.CheckEntry(accountID, "ETH/USDT", 700)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxTotalExposure)
+
+	// Entrada cotada em BTC: 6 BTC × 120 = 720 USDT
+	_, err = guard.CheckEntry(accountID, "ETH/BTC", 6)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxTotalExposure)
+
+	// Sem cotação não é possível avaliar a exposição: a entrada é bloqueada
+	_, err = guard.CheckEntry(accountID, "SOL/BNB", 1)
+	assert.Error(t, err)
+
+	positionRepo.Save(entity.OpenPosition{BotID: uuid.New(), Symbol: "ETH/USDT", EntryPrice: 10, Quantity: 1})
+	_, err = guard.CheckEntry(accountID, "SOL/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxOpenPositions)
+}
+
+func TestRiskGuardCountsPendingBuyOrders(t *testing.T) {
+	accountID := uuid.New()
+	limitsRepo := mocks.NewMockRiskLimitsRepository()
+	positionRepo := mocks.NewMockPositionRepository()
+	orderRepo := mocks.NewMockOrderRepository()
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 100, "ETHUSDT": 10}}
+	guard := usecases.NewRiskGuard(limitsRepo, positionRepo, orderRepo, &mocks.MockExecutionLogRepository{}, market)
+
+	limitsRepo.Save(entity.RiskLimits{AccountID: accountID, MaxOpenPositions: 2, MaxTotalExposure: 1000, MaxAssetExposure: 400})
+
+	// Compra ainda aberta de 3 BTC (300 USDT): conta como posição e como exposição
+	orderRepo.Save(entity.Order{
+		AccountID: accountID, BotID: uuid.New(), Symbol: "BTCUSDT",
+		Side: entity.OrderSideBuy, Status: entity.OrderStatusNew, Quantity: 3,
+	})
+	// Venda aberta de um bot com posição: a posição já foi contada
+	holder := uuid.New()
+	positionRepo.Save(entity.OpenPosition{BotID: holder, Symbol: "ETH/USDT", EntryPrice: 10, Quantity: 10})
+	orderRepo.Save(entity.Order{
+		AccountID: accountID, BotID: holder, Symbol: "ETHUSDT",
+		Side: entity.OrderSideSell, Status: entity.OrderStatusNew, Quantity: 10,
+	})
+
+	_, err := guard.CheckEntry(accountID, "SOL/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxOpenPositions)
+
+	limitsRepo.Save(entity.RiskLimits{AccountID: accountID, MaxOpenPositions: 3, MaxTotalExposure: 1000, MaxAssetExposure: 400})
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 150)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxAssetExposure)
+
+	release, err := guard.CheckEntry(accountID, "BTC/USDT", 50)
+	require.NoError(t, err)
+	release()
+}
+
+func TestRiskGuardDailyLossAndConsecutiveLosses(t *testing.T) {
+	accountID := uuid.New()
+	limitsRepo := mocks.NewMockRiskLimitsRepository()
+	executionRepo := &mocks.MockExecutionLogRepository{}
+	guard := usecases.NewRiskGuard(limitsRepo, mocks.NewMockPositionRepository(), mocks.NewMockOrderRepository(), executionRepo, nil)
+
+	limitsRepo.Save(entity.RiskLimits{AccountID: accountID, MaxDailyLoss: 100, MaxConsecutiveLosses: 3})
+
+	now := time.Now()
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -500, CreatedAt: now.Add(-48 * time.Hour)}) // fora do dia
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -30, CreatedAt: now})
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: 20, CreatedAt: now})
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -30, CreatedAt: now})
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -30, CreatedAt: now})
+
+	release, err := guard.CheckEntry(accountID, "BTC/USDT", 10)
+	require.NoError(t, err)
+	release()
+
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -10, CreatedAt: now})
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxConsecutiveLosses)
+
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: 5, CreatedAt: now})
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -30, CreatedAt: now})
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxDailyLoss)
+}
+
+func TestRiskGuardDailyLossInUSDT(t *testing.T) {
+	accountID := uuid.New()
+	limitsRepo := mocks.NewMockRiskLimitsRepository()
+	executionRepo := &mocks.MockExecutionLogRepository{}
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 60000}}
+	guard := usecases.NewRiskGuard(limitsRepo, mocks.NewMockPositionRepository(), mocks.NewMockOrderRepository(), executionRepo, market)
+
+	limitsRepo.Save(entity.RiskLimits{AccountID: accountID, MaxDailyLoss: 100})
+
+	// 0.001 BTC de perda valem 60 USDT: ainda abaixo do limite
+	executionRepo.Save(entity.ExecutionLog{Symbol: "ETH/BTC", Profit: -0.001, CreatedAt: time.Now()})
+	release, err := guard.CheckEntry(accountID, "BTC/USDT", 10)
+	require.NoError(t, err)
+	release()
+
+	// Somados, 0.002 BTC (120 USDT) atingem o limite
+	executionRepo.Save(entity.ExecutionLog{Symbol: "ETH/BTC", Profit: -0.001, CreatedAt: time.Now()})
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxDailyLoss)
+}
+
+func TestRiskGuardConsecutiveLossesSpanDays(t *testing.T) {
+	accountID := uuid.New()
+	limitsRepo := mocks.NewMockRiskLimitsRepository()
+	executionRepo := &mocks.MockExecutionLogRepository{}
+	guard := usecases.NewRiskGuard(limitsRepo, mocks.NewMockPositionRepository(), mocks.NewMockOrderRepository(), executionRepo, nil)
+
+	limitsRepo.Save(entity.RiskLimits{AccountID: accountID, MaxConsecutiveLosses: 3})
+
+	now := time.Now()
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: 10, CreatedAt: now.Add(-72 * time.Hour)})
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -5, CreatedAt: now.Add(-48 * time.Hour)})
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -5, CreatedAt: now.Add(-24 * time.Hour)})
+
+	release, err := guard.CheckEntry(accountID, "BTC/USDT", 10)
+	require.NoError(t, err)
+	release()
+
+	// A terceira perda, hoje, completa a sequência iniciada em dias anteriores
+	executionRepo.Save(entity.ExecutionLog{Symbol: "BTC/USDT", Profit: -5, CreatedAt: now})
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleMaxConsecutiveLosses)
+}
+
+func TestRiskGuardKillSwitch(t *testing.T) {
+	accountID := uuid.New()
+	guard := usecases.NewRiskGuard(mocks.NewMockRiskLimitsRepository(), mocks.NewMockPositionRepository(), mocks.NewMockOrderRepository(), &mocks.MockExecutionLogRepository{}, nil)
+
+	limits, err := guard.SetKillSwitch(accountID, true)
+	require.NoError(t, err)
+	assert.True(t, limits.KillSwitch)
+	assert.NotNil(t, limits.KillSwitchAt)
+
+	_, err = guard.CheckEntry(accountID, "BTC/USDT", 10)
+	assertBlockedBy(t, err, usecases.RiskRuleKillSwitch)
+
+	_, err = guard.SetKillSwitch(accountID, false)
+	require.NoError(t, err)
+	release, err := guard.CheckEntry(accountID, "BTC/USDT", 10)
+	require.NoError(t, err)
+	release()
+}
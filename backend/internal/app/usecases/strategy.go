@@ -0,0 +1,69 @@
+// internal/app/usecases/strategy.go
+
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Strategy define o contrato de uma estratégia de trading plugável.
+// A estratégia não guarda estado próprio: todo o estado (candles, posição, logs)
+// fica no StrategyUseCase recebido em Evaluate.
+type Strategy interface {
+	Name() string                                        // Nome usado em bots.strategy_name
+	Version() string                                     // Versão registrada nos logs de decisão/execução
+	DefaultParams() map[string]any                       // Parâmetros padrão, sobrescritos por bot_configs
+	WarmupCandles(params map[string]any) int             // Quantidade mínima de candles antes de avaliar
+	Evaluate(s *StrategyUseCase, timestamp int64) string // Retorna BUY, SELL ou HOLD
+}
+
+// Registro global de estratégias disponíveis, indexado pelo nome.
+var strategyRegistry = struct {
+	sync.RWMutex
+	Items map[string]Strategy
+}{
+	Items: make(map[string]Strategy),
+}
+
+// RegisterStrategy adiciona uma estratégia ao registro. Nomes duplicados causam panic,
+// pois indicam erro de programação na inicialização.
+func RegisterStrategy(strategy Strategy) {
+	strategyRegistry.Lock()
+	defer strategyRegistry.Unlock()
+
+	name := strategy.Name()
+	if _, exists := strategyRegistry.Items[name]; exists {
+		panic(fmt.Sprintf("estratégia já registrada: %s", name))
+	}
+	strategyRegistry.Items[name] = strategy
+}
+
+// GetStrategy retorna a estratégia registrada com o nome informado.
+func GetStrategy(name string) (Strategy, error) {
+	strategyRegistry.RLock()
+	defer strategyRegistry.RUnlock()
+
+	strategy, ok := strategyRegistry.Items[name]
+	if !ok {
+		return nil, fmt.Errorf("estratégia desconhecida: %q (disponíveis: %v)", name, strategyNamesLocked())
+	}
+	return strategy, nil
+}
+
+// StrategyNames retorna os nomes de todas as estratégias registradas, em ordem alfabética.
+func StrategyNames() []string {
+	strategyRegistry.RLock()
+	defer strategyRegistry.RUnlock()
+	return strategyNamesLocked()
+}
+
+func strategyNamesLocked() []string {
+	names := make([]string, 0, len(strategyRegistry.Items))
+	for name := range strategyRegistry.Items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
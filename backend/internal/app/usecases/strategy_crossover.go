@@ -2,61 +2,103 @@ package usecases
 
 import (
 	"fmt"
-	"time"
 
-	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
-	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
-	reporter "github.com/jeancarlosdanese/crypto-bot/internal/report"
 	serverws "github.com/jeancarlosdanese/crypto-bot/internal/server/ws"
 )
 
+const (
+	crossoverStrategyName    = "EvaluateCrossover"
+	crossoverStrategyVersion = "1.2.0"
+)
+
+// crossoverStrategy expõe EvaluateCrossover através da interface Strategy.
+type crossoverStrategy struct{}
+
+func init() {
+	RegisterStrategy(crossoverStrategy{})
+}
+
+func (crossoverStrategy) Name() string    { return crossoverStrategyName }
+func (crossoverStrategy) Version() string { return crossoverStrategyVersion }
+
+func (crossoverStrategy) DefaultParams() map[string]any {
+	return map[string]any{
+		"ma_short":            9,
+		"ma_long":             26,
+		"rsi_period":          14,
+		"rsi_threshold":       70,
+		"volatility_min":      0.0,
+		"atr_min":             0.0,
+		"ema_trailing":        5,
+		"rsi_exit_threshold":  80,
+		"atr_period":          14,
+		"stop_atr_multiplier": 1.5, // stop por ATR abaixo da entrada (ver newExitPolicy)
+		"pattern_min_score":   0.0, // score mínimo dos padrões de candlestick para entrar (0 desativa)
+	}
+}
+
+func (crossoverStrategy) WarmupCandles(params map[string]any) int {
+	return max(getIntParam(params, "ma_long", 26), getIntParam(params, "rsi_period", 14)+2)
+}
+
+func (crossoverStrategy) Evaluate(s *StrategyUseCase, timestamp int64) string {
+	return s.EvaluateCrossover(timestamp)
+}
+
 func (s *StrategyUseCase) EvaluateCrossover(timestamp int64) string {
-	prices := s.ClosingPrices()
-	if len(prices) < 26 {
+	return s.evaluateCrossover(timestamp, crossoverStrategyName, crossoverStrategyVersion, nil)
+}
+
+// evaluateCrossover aplica o crossover registrando as decisões com o nome e a versão da
+// estratégia. entryFilter, se informado, pode vetar entradas e acrescentar os indicadores
+// usados ao log de decisão.
+func (s *StrategyUseCase) evaluateCrossover(timestamp int64, strategyName, strategyVersion string, entryFilter func(indicators map[string]float64) bool) string {
+	params := s.Params
+	maShortPeriod := getIntParam(params, "ma_short", 9)
+	maLongPeriod := getIntParam(params, "ma_long", 26)
+	rsiPeriod := getIntParam(params, "rsi_period", 14)
+	rsiThreshold := getFloatParam(params, "rsi_threshold", 70)
+
+	last, _ := s.Candles.Last()
+	if s.Candles.Len() < maLongPeriod || s.Candles.Len() < rsiPeriod+2 {
 		return "HOLD"
 	}
 
-	ma9 := indicators.MovingAverage(prices, 9)
-	ma26 := indicators.MovingAverage(prices, 26)
-	rsi := indicators.RSI(prices, 14)
-	volatility := indicators.Volatility(prices)
-	atr := indicators.ATRFromCandles(s.CandlesWindow)
-	currentPrice := prices[len(prices)-1]
+	maShort := s.SMA(maShortPeriod)
+	maLong := s.SMA(maLongPeriod)
+	rsi, rsiPrev := s.RSI(rsiPeriod)
+	volatility := s.Volatility()
+	atr := s.atr()
+	currentPrice := last.Close
 
 	basicSignal := "HOLD"
-	if ma9 > ma26 && currentPrice > ma9 && rsi < 70 {
+	if maShort > maLong && currentPrice > maShort && rsi < rsiThreshold {
 		basicSignal = "BUY"
-	} else if ma9 < ma26 && currentPrice < ma9 {
+	} else if maShort < maLong && currentPrice < maShort {
 		basicSignal = "SELL"
 	}
 
 	indicatorsMap := map[string]float64{
-		"ma9":        ma9,
-		"ma26":       ma26,
-		"rsi":        rsi,
-		"volatility": volatility,
-		"atr":        atr,
-		"price":      currentPrice,
+		fmt.Sprintf("ma%d", maShortPeriod): maShort,
+		fmt.Sprintf("ma%d", maLongPeriod):  maLong,
+		"rsi":                              rsi,
+		"volatility":                       volatility,
+		"atr":                              atr,
+		"price":                            currentPrice,
 	}
 
-	params := map[string]any{
-		"ma_short":      9,
-		"ma_long":       26,
-		"rsi_threshold": 70,
-	}
 	ctx := map[string]any{
 		"candles_total": s.TotalCandles,
 		"calibrated_at": s.LastCalibrationGlob,
 	}
 
-	strategyName := "EvaluateCrossover"
-	strategyVersion := "1.0.1"
-
 	if s.PositionQuantity == 0 && basicSignal == "BUY" {
 		// 🔧 Parâmetros dinâmicos
 		minVolatility := getFloatParam(params, "volatility_min", 0.0)
 		minATR := getFloatParam(params, "atr_min", 0.0)
+		minPatternScore := getFloatParam(params, "pattern_min_score", 0.0)
 
 		// ❌ Ignora entrada se volatilidade ou ATR estiverem abaixo do mínimo
 		if volatility < minVolatility {
@@ -77,42 +119,37 @@ func (s *StrategyUseCase) EvaluateCrossover(timestamp int64) string {
 			return "HOLD"
 		}
 
-		// ✅ Entrada aprovada
-		s.PositionQuantity = 1
-		s.LastEntryPrice = currentPrice
-		s.LastEntryTimestamp = timestamp
-		s.LastDecision = "BUY"
+		// ❌ Ignora entrada sem confirmação dos padrões de candlestick, se exigida
+		if minPatternScore > 0 {
+			if score := s.PatternScore(); score < minPatternScore {
+				logger.Debug("🚫 Entrada bloqueada por padrões de candlestick",
+					"symbol", s.Bot.Symbol,
+					"pattern_score", score,
+					"min_required", minPatternScore,
+				)
+				return "HOLD"
+			}
+		}
 
+		if entryFilter != nil && !entryFilter(indicatorsMap) {
+			return "HOLD"
+		}
+
+		// ✅ Entrada aprovada (sujeita aos limites de risco da conta)
+		if !s.enterPosition(currentPrice, timestamp) {
+			return "HOLD"
+		}
 		logger.Info("📈 Entrada executada (Crossover)",
+			"strategy", strategyName,
 			"symbol", s.Bot.Symbol,
 			"price", currentPrice,
-			"ma9", ma9,
-			"ma26", ma26,
+			"ma_short", maShort,
+			"ma_long", maLong,
 			"rsi", rsi,
 			"volatility", volatility,
 			"atr", atr,
 		)
 
-		// 💾 Salvar posição aberta
-		if s.PositionRepo != nil {
-			logger.Debug("🧩 Salvando posição em aberto...",
-				"bot_id", s.Bot.ID.String(),
-				"price", currentPrice,
-				"timestamp", timestamp,
-			)
-
-			err := s.PositionRepo.Save(entity.OpenPosition{
-				BotID:      s.Bot.ID,
-				EntryPrice: currentPrice,
-				Timestamp:  timestamp,
-			})
-			if err != nil {
-				logger.Error("❌ Erro ao salvar posição", err, "bot_id", s.Bot.ID.String())
-			} else {
-				logger.Debug("✅ Posição salva com sucesso", "bot_id", s.Bot.ID.String())
-			}
-		}
-
 		// 📝 Log de decisão
 		s.saveDecisionLog(strategyName, strategyVersion, "BUY", timestamp, indicatorsMap, params, ctx)
 
@@ -130,26 +167,20 @@ func (s *StrategyUseCase) EvaluateCrossover(timestamp int64) string {
 	}
 
 	if s.PositionQuantity > 0 {
-		// 🔧 Parâmetros configuráveis
+		// 🔧 Parâmetros configuráveis (o stop por ATR fica na política de saída)
 		emaTrailingPeriod := getIntParam(params, "ema_trailing", 5)
 		rsiExitThreshold := getFloatParam(params, "rsi_exit_threshold", 80)
-		atrMultiplier := getFloatParam(params, "atr_multiplier", 1.5)
 
 		// 📊 Indicadores auxiliares
-		emaTrailing := indicators.MovingAverage(prices, emaTrailingPeriod)
-		rsiPrev := indicators.RSI(prices[:len(prices)-1], 14)
-		stopLossThreshold := s.LastEntryPrice + atr*atrMultiplier
+		emaTrailing := s.SMA(emaTrailingPeriod)
 
 		// 🧠 Critérios de saída
-		stopLossHit := currentPrice < stopLossThreshold
 		priceBelowTrailing := currentPrice < emaTrailing
 		rsiReversal := rsiPrev > rsiExitThreshold && rsi < rsiPrev
 
-		if stopLossHit || priceBelowTrailing || rsiReversal || basicSignal == "SELL" {
+		if priceBelowTrailing || rsiReversal || basicSignal == "SELL" {
 			reason := ""
 			switch {
-			case stopLossHit:
-				reason = fmt.Sprintf("ATR stop hit (%.2f < %.2f)", currentPrice, stopLossThreshold)
 			case priceBelowTrailing:
 				reason = fmt.Sprintf("Price below EMA%d (%.2f < %.2f)", emaTrailingPeriod, currentPrice, emaTrailing)
 			case rsiReversal:
@@ -158,38 +189,15 @@ func (s *StrategyUseCase) EvaluateCrossover(timestamp int64) string {
 				reason = "Crossover reversal signal"
 			}
 
-			s.PositionQuantity = 0
-			s.LastDecision = "SELL"
-
+			if !s.exitPosition(currentPrice, timestamp, exits.ReasonSignal) {
+				return "HOLD"
+			}
 			logger.Info("📉 Saída executada (Crossover)",
 				"symbol", s.Bot.Symbol, "price", currentPrice, "reason", reason,
 				"roi", ((currentPrice-s.LastEntryPrice)/s.LastEntryPrice)*100)
 
-			if s.PositionRepo != nil {
-				_ = s.PositionRepo.Delete(s.Bot.ID)
-			}
 			s.saveDecisionLog(strategyName, strategyVersion, "SELL", timestamp, indicatorsMap, params, ctx)
 
-			profit := currentPrice - s.LastEntryPrice
-			roi := (profit / s.LastEntryPrice) * 100
-			duration := (timestamp - s.LastEntryTimestamp) / 1000
-
-			exec := entity.ExecutionLog{
-				BotID:     s.Bot.ID,
-				Symbol:    s.Bot.Symbol,
-				Interval:  s.Bot.Interval,
-				Entry:     entity.TradePoint{Price: s.LastEntryPrice, Timestamp: s.LastEntryTimestamp},
-				Exit:      entity.TradePoint{Price: currentPrice, Timestamp: timestamp},
-				Profit:    profit,
-				ROIPct:    roi,
-				Duration:  duration,
-				Strategy:  entity.StrategyInfo{Name: strategyName, Version: strategyVersion},
-				CreatedAt: time.Now(),
-			}
-			if s.ExecutionLogRepo != nil {
-				_ = s.ExecutionLogRepo.Save(exec)
-			}
-
 			// 💬 Enviar evento de decisão para o WebSocket
 			serverws.Publish(s.Bot.ID.String(), serverws.Event{
 				Type: "decision",
@@ -200,27 +208,8 @@ func (s *StrategyUseCase) EvaluateCrossover(timestamp int64) string {
 				},
 			})
 
-			go reporter.PrintExecutionSummary(s.ExecutionLogRepo)
 			return "SELL"
 		}
 	}
 	return "HOLD"
 }
-
-func getFloatParam(params map[string]any, key string, defaultVal float64) float64 {
-	if val, ok := params[key]; ok {
-		if f, ok := val.(float64); ok {
-			return f
-		}
-	}
-	return defaultVal
-}
-
-func getIntParam(params map[string]any, key string, defaultVal int) int {
-	if val, ok := params[key]; ok {
-		if f, ok := val.(float64); ok { // JSON unmarshals numbers as float64
-			return int(f)
-		}
-	}
-	return defaultVal
-}
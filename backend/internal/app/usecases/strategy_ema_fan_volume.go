@@ -2,24 +2,60 @@ package usecases
 
 import (
 	"fmt"
-	"time"
+	"slices"
 
-	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
-	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
-	reporter "github.com/jeancarlosdanese/crypto-bot/internal/report"
 )
 
+const (
+	emaFanStrategyName    = "EvaluateEMAFanWithVolume"
+	emaFanStrategyVersion = "1.0.0"
+)
+
+// emaFanVolumeStrategy expõe EvaluateEMAFanWithVolume através da interface Strategy.
+type emaFanVolumeStrategy struct{}
+
+func init() {
+	RegisterStrategy(emaFanVolumeStrategy{})
+}
+
+func (emaFanVolumeStrategy) Name() string    { return emaFanStrategyName }
+func (emaFanVolumeStrategy) Version() string { return emaFanStrategyVersion }
+
+func (emaFanVolumeStrategy) DefaultParams() map[string]any {
+	return map[string]any{
+		"emas":          []int{10, 15, 20, 25, 30, 35, 40},
+		"volume_period": 10,
+		"slope_min":     0.08,
+	}
+}
+
+func (emaFanVolumeStrategy) WarmupCandles(params map[string]any) int {
+	periods := getIntSliceParam(params, "emas", []int{10, 15, 20, 25, 30, 35, 40})
+	return max(slices.Max(periods)+2, getIntParam(params, "volume_period", 10)+1)
+}
+
+func (emaFanVolumeStrategy) Evaluate(s *StrategyUseCase, timestamp int64) string {
+	return s.EvaluateEMAFanWithVolume(timestamp)
+}
+
 func (s *StrategyUseCase) EvaluateEMAFanWithVolume(timestamp int64) string {
+	parameters := s.Params
+	periods := getIntSliceParam(parameters, "emas", []int{10, 15, 20, 25, 30, 35, 40})
+	volumePeriod := getIntParam(parameters, "volume_period", 10)
+	slopeMin := getFloatParam(parameters, "slope_min", 0.08)
+
 	prices := s.ClosingPrices()
-	if len(prices) < 40 || len(s.CandlesWindow) < 11 {
+	if len(prices) < slices.Max(periods)+2 || s.Candles.Len() < volumePeriod+1 {
 		return "HOLD"
 	}
 
-	periods := []int{10, 15, 20, 25, 30, 35, 40}
 	emas := make([]float64, len(periods))
+	slopes := make([]float64, len(periods))
 	for i, p := range periods {
-		emas[i] = indicators.MovingAverage(prices, p)
+		emas[i] = s.EMA(p)
+		slopes[i] = s.EMASlope(p)
 	}
 
 	isAligned := true
@@ -30,19 +66,19 @@ func (s *StrategyUseCase) EvaluateEMAFanWithVolume(timestamp int64) string {
 		}
 	}
 
-	slopeMap := calculateEMASlopes(prices, periods)
-	for _, slope := range slopeMap {
-		if slope < 0.08 {
+	for _, slope := range slopes {
+		if slope < slopeMin {
 			return "HOLD"
 		}
 	}
 
-	lastVolume := s.CandlesWindow[len(s.CandlesWindow)-1].Volume
+	n := s.Candles.Len()
+	lastVolume := s.Candles.At(n - 1).Volume
 	avgVolume := 0.0
-	for i := len(s.CandlesWindow) - 11; i < len(s.CandlesWindow)-1; i++ {
-		avgVolume += s.CandlesWindow[i].Volume
+	for i := n - volumePeriod - 1; i < n-1; i++ {
+		avgVolume += s.Candles.At(i).Volume
 	}
-	avgVolume /= 10
+	avgVolume /= float64(volumePeriod)
 	volumeConfirmed := lastVolume > avgVolume
 
 	currentPrice := prices[len(prices)-1]
@@ -55,13 +91,7 @@ func (s *StrategyUseCase) EvaluateEMAFanWithVolume(timestamp int64) string {
 	}
 	for i, p := range periods {
 		indicatorsMap[fmt.Sprintf("ema%d", p)] = emas[i]
-		indicatorsMap[fmt.Sprintf("slope%d", p)] = slopeMap[p]
-	}
-
-	parameters := map[string]any{
-		"emas":          periods,
-		"volume_period": 10,
-		"slope_min":     0.08,
+		indicatorsMap[fmt.Sprintf("slope%d", p)] = slopes[i]
 	}
 
 	context := map[string]any{
@@ -69,69 +99,26 @@ func (s *StrategyUseCase) EvaluateEMAFanWithVolume(timestamp int64) string {
 		"calibrated_at": s.LastCalibrationGlob,
 	}
 
-	name := "EvaluateEMAFanWithVolume"
-	version := "1.0.0"
+	name := emaFanStrategyName
+	version := emaFanStrategyVersion
 
 	if isAligned && volumeConfirmed && s.PositionQuantity == 0 {
-		s.PositionQuantity = 1
-		s.LastEntryPrice = currentPrice
-		s.LastEntryTimestamp = timestamp
-		s.LastDecision = "BUY"
-
-		logger.Info("📈 Entrada (EMA Fan)", "symbol", s.Bot.Symbol, "price", currentPrice, "volume_ratio", lastVolume/avgVolume)
-
-		if s.PositionRepo != nil {
-			_ = s.PositionRepo.Save(entity.OpenPosition{
-				BotID:      s.Bot.ID,
-				EntryPrice: currentPrice,
-				Timestamp:  timestamp,
-			})
+		if !s.enterPosition(currentPrice, timestamp) {
+			return "HOLD"
 		}
+		logger.Info("📈 Entrada (EMA Fan)", "symbol", s.Bot.Symbol, "price", currentPrice, "volume_ratio", lastVolume/avgVolume)
 
 		s.saveDecisionLog(name, version, "BUY", timestamp, indicatorsMap, parameters, context)
 		return "BUY"
 	}
 
 	if s.PositionQuantity > 0 && !isAligned {
-		s.PositionQuantity = 0
-		s.LastDecision = "SELL"
-
-		logger.Info("📉 Saída (EMA Fan)", "symbol", s.Bot.Symbol, "price", currentPrice)
-
-		if s.PositionRepo != nil {
-			_ = s.PositionRepo.Delete(s.Bot.ID)
+		if !s.exitPosition(currentPrice, timestamp, exits.ReasonSignal) {
+			return "HOLD"
 		}
+		logger.Info("📉 Saída (EMA Fan)", "symbol", s.Bot.Symbol, "price", currentPrice)
 
 		s.saveDecisionLog(name, version, "SELL", timestamp, indicatorsMap, parameters, context)
-
-		profit := currentPrice - s.LastEntryPrice
-		roi := (profit / s.LastEntryPrice) * 100
-		duration := (timestamp - s.LastEntryTimestamp) / 1000
-
-		exec := entity.ExecutionLog{
-			BotID:    s.Bot.ID,
-			Symbol:   s.Bot.Symbol,
-			Interval: s.Bot.Interval,
-			Entry: entity.TradePoint{
-				Price:     s.LastEntryPrice,
-				Timestamp: s.LastEntryTimestamp,
-			},
-			Exit: entity.TradePoint{
-				Price:     currentPrice,
-				Timestamp: timestamp,
-			},
-			Profit:    profit,
-			ROIPct:    roi,
-			Duration:  duration,
-			Strategy:  entity.StrategyInfo{Name: name, Version: version},
-			CreatedAt: time.Now(),
-		}
-
-		if s.ExecutionLogRepo != nil {
-			_ = s.ExecutionLogRepo.Save(exec)
-		}
-
-		go reporter.PrintExecutionSummary(s.ExecutionLogRepo)
 		return "SELL"
 	}
 
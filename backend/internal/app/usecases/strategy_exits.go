@@ -0,0 +1,118 @@
+// internal/app/usecases/strategy_exits.go
+
+package usecases
+
+import (
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	serverws "github.com/jeancarlosdanese/crypto-bot/internal/server/ws"
+)
+
+// newExitPolicy monta a política de saída configurada no bot. Vale para qualquer
+// estratégia; chaves ausentes ou zeradas desativam a regra. Stops são acionados pela
+// mínima do candle e o alvo pela máxima (ver exits.Policy.Check):
+//
+//	exit_stop_loss_pct           stop a X% abaixo da entrada
+//	exit_take_profit_pct         alvo a X% acima da entrada
+//	stop_atr_multiplier          stop a N × ATR abaixo da entrada
+//	exit_trailing_atr_multiplier chandelier: máxima desde a entrada − N × ATR
+//	exit_trailing_pct            trailing: máxima desde a entrada − X%
+//	exit_break_even_pct          lucro que move o stop para a entrada
+//	exit_break_even_offset_pct   stop do break-even a X% acima da entrada
+//	exit_max_holding             tempo máximo na posição (ex: "12h")
+func newExitPolicy(params map[string]any) exits.Policy {
+	policy := exits.Policy{
+		StopLossPct:           getFloatParam(params, "exit_stop_loss_pct", 0),
+		TakeProfitPct:         getFloatParam(params, "exit_take_profit_pct", 0),
+		ATRStopMultiplier:     getFloatParam(params, "stop_atr_multiplier", 0),
+		TrailingATRMultiplier: getFloatParam(params, "exit_trailing_atr_multiplier", 0),
+		TrailingPct:           getFloatParam(params, "exit_trailing_pct", 0),
+		BreakEvenTriggerPct:   getFloatParam(params, "exit_break_even_pct", 0),
+		BreakEvenOffsetPct:    getFloatParam(params, "exit_break_even_offset_pct", 0),
+	}
+	if v, ok := params["exit_max_holding"].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			policy.MaxHolding = d
+		} else {
+			logger.Warn("exit_max_holding inválido, ignorando", "valor", v)
+		}
+	}
+	return policy
+}
+
+// evaluateExitPolicy encerra a posição aberta se o último candle atingir alguma regra da
+// política de saída e, caso contrário, atualiza o acompanhamento com ele. O candle é
+// conferido contra o stop vigente na abertura: a máxima dele não sobe o stop que a própria
+// mínima testaria, pois não se sabe a ordem dos extremos dentro do candle. Com uma ordem
+// pendente o acompanhamento continua, mas a saída aguarda a confirmação da ordem.
+func (s *StrategyUseCase) evaluateExitPolicy(timestamp int64) (string, bool) {
+	candle, ok := s.Candles.Last()
+	if s.PositionQuantity == 0 || !ok {
+		return "", false
+	}
+	policy := newExitPolicy(s.Params)
+	if !policy.Enabled() {
+		return "", false
+	}
+
+	atr := s.atr()
+	reason, price, hit := policy.Check(s.ExitState, candle, timestamp)
+	if !hit {
+		if policy.Update(&s.ExitState, candle.High, atr) {
+			s.savePosition()
+		}
+		return "", false
+	}
+
+	if s.PendingOrder != nil {
+		logger.Warn("⏳ Regra de saída atingida com ordem pendente, aguardando a ordem",
+			"bot_id", s.Bot.ID.String(),
+			"reason", reason,
+			"exchange_order_id", s.PendingOrder.ExchangeOrderID,
+		)
+		return "HOLD", true
+	}
+
+	// O acompanhamento é zerado ao encerrar a posição; o log usa o estado que disparou a saída
+	state := s.ExitState
+	if !s.exitPosition(price, timestamp, reason) {
+		return "HOLD", true
+	}
+
+	logger.Info("📉 Saída pela política de saída",
+		"symbol", s.Bot.Symbol,
+		"price", price,
+		"reason", reason,
+		"stop_price", state.StopPrice,
+		"roi", ((price-s.LastEntryPrice)/s.LastEntryPrice)*100,
+	)
+
+	s.saveDecisionLog(s.Strategy.Name(), s.Strategy.Version(), "SELL", timestamp,
+		map[string]float64{
+			"price":         price,
+			"atr":           atr,
+			"stop_price":    state.StopPrice,
+			"take_profit":   policy.TakeProfitPrice(state),
+			"highest_price": state.HighestPrice,
+		},
+		s.Params,
+		map[string]any{
+			"candles_total": s.TotalCandles,
+			"exit_reason":   string(reason),
+		},
+	)
+
+	// 💬 Enviar evento de decisão para o WebSocket
+	serverws.Publish(s.Bot.ID.String(), serverws.Event{
+		Type: "decision",
+		Data: map[string]interface{}{
+			"time":     timestamp / 1000,
+			"price":    price,
+			"decision": "SELL",
+		},
+	})
+
+	return "SELL", true
+}
@@ -6,7 +6,11 @@ import (
 )
 
 // CalibrateLastEntry recalibra o último ponto de entrada com base nos preços de fechamento.
+// Com uma posição aberta (restaurada do banco) o preço de entrada real é mantido.
 func (s *StrategyUseCase) CalibrateLastEntry() {
+	if s.PositionQuantity > 0 {
+		return
+	}
 	prices := s.ClosingPrices()
 	var lastSignal string = "HOLD"
 	for i := len(prices) - 1; i > 0; i-- {
@@ -27,38 +31,22 @@ func (s *StrategyUseCase) CalibrateLastEntry() {
 	logger.Debug("Nenhum ponto de reversão significativo encontrado", "Calibração Global", s.LastCalibrationGlob)
 }
 
-// calculateSignal aplica uma lógica simples baseada em MA9 e MA26 para determinar o sinal.
+// calculateSignal aplica uma lógica simples baseada nas médias curta e longa (padrão MA9 e MA26)
+// configuradas no bot para determinar o sinal.
 func (d *StrategyUseCase) calculateSignal(window []float64) string {
-	if len(window) < 26 {
+	maShortPeriod := getIntParam(d.Params, "ma_short", 9)
+	maLongPeriod := getIntParam(d.Params, "ma_long", 26)
+	if len(window) < maLongPeriod {
 		return "HOLD"
 	}
-	ma9 := indicators.MovingAverage(window, 9)
-	ma26 := indicators.MovingAverage(window, 26)
+	maShort := indicators.MovingAverage(window, maShortPeriod)
+	maLong := indicators.MovingAverage(window, maLongPeriod)
 	currentPrice := window[len(window)-1]
 
-	if ma9 > ma26 && currentPrice > ma9 {
+	if maShort > maLong && currentPrice > maShort {
 		return "BUY"
-	} else if ma9 < ma26 && currentPrice < ma9 {
+	} else if maShort < maLong && currentPrice < maShort {
 		return "SELL"
 	}
 	return "HOLD"
 }
-
-// calculateEMASlopes calcula a inclinação percentual das EMAs nos últimos dois pontos.
-func calculateEMASlopes(prices []float64, periods []int) map[int]float64 {
-	slopeMap := make(map[int]float64)
-	for _, p := range periods {
-		if len(prices) < p+2 {
-			slopeMap[p] = 0
-			continue
-		}
-		emaNow := indicators.MovingAverage(prices[len(prices)-1:], p)
-		emaPrev := indicators.MovingAverage(prices[len(prices)-2:], p)
-		if emaPrev != 0 {
-			slopeMap[p] = ((emaNow - emaPrev) / emaPrev) * 100 // porcentagem
-		} else {
-			slopeMap[p] = 0
-		}
-	}
-	return slopeMap
-}
@@ -0,0 +1,113 @@
+// internal/app/usecases/strategy_indicators.go
+
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// rollingIndicator é um indicador incremental mantido pelo StrategyUseCase: criado na
+// primeira consulta a partir dos candles da janela e atualizado em O(1) a cada candle.
+type rollingIndicator struct {
+	update   func(entity.Candle) float64
+	value    float64
+	previous float64 // valor no candle anterior
+}
+
+// SMA retorna a média móvel simples dos fechamentos.
+func (s *StrategyUseCase) SMA(period int) float64 {
+	return s.rolling(fmt.Sprintf("sma:%d", period), func() func(entity.Candle) float64 {
+		sma := indicators.NewRollingSMA(period)
+		return func(c entity.Candle) float64 { return sma.Update(c.Close) }
+	}).value
+}
+
+// EMA retorna a média móvel exponencial dos fechamentos.
+func (s *StrategyUseCase) EMA(period int) float64 {
+	return s.ema(period).value
+}
+
+// EMASlope retorna a inclinação percentual da EMA entre o candle anterior e o atual,
+// calculada a partir do mesmo indicador consultado por EMA.
+func (s *StrategyUseCase) EMASlope(period int) float64 {
+	ind := s.ema(period)
+	if ind.previous == 0 {
+		return 0
+	}
+	return (ind.value - ind.previous) / ind.previous * 100
+}
+
+func (s *StrategyUseCase) ema(period int) *rollingIndicator {
+	return s.rolling(fmt.Sprintf("ema:%d", period), func() func(entity.Candle) float64 {
+		ema := indicators.NewRollingEMA(period)
+		return func(c entity.Candle) float64 { return ema.Update(c.Close) }
+	})
+}
+
+// RSI retorna o RSI (Wilder) dos fechamentos e o seu valor no candle anterior.
+func (s *StrategyUseCase) RSI(period int) (current, previous float64) {
+	ind := s.rolling(fmt.Sprintf("rsi:%d", period), func() func(entity.Candle) float64 {
+		rsi := indicators.NewRollingRSI(period)
+		return func(c entity.Candle) float64 { return rsi.Update(c.Close) }
+	})
+	return ind.value, ind.previous
+}
+
+// ATR retorna o Average True Range (Wilder).
+func (s *StrategyUseCase) ATR(period int) float64 {
+	return s.rolling(fmt.Sprintf("atr:%d", period), func() func(entity.Candle) float64 {
+		atr := indicators.NewRollingATR(period)
+		return func(c entity.Candle) float64 { return atr.Update(c.High, c.Low, c.Close) }
+	}).value
+}
+
+// atr retorna o ATR de atr_period candles (padrão 14) usado por stops, alvos e dimensionamento.
+func (s *StrategyUseCase) atr() float64 {
+	return s.ATR(getIntParam(s.Params, "atr_period", 14))
+}
+
+// Volatility retorna o desvio padrão dos fechamentos da janela em percentual da média
+// (equivalente a indicators.Volatility sobre a janela inteira).
+func (s *StrategyUseCase) Volatility() float64 {
+	return s.rolling(fmt.Sprintf("volatility:%d", s.WindowSize), func() func(entity.Candle) float64 {
+		stddev := indicators.NewRollingStdDev(s.WindowSize)
+		return func(c entity.Candle) float64 {
+			stddev.Update(c.Close)
+			if mean := stddev.Mean(); mean != 0 {
+				return stddev.Value() / mean * 100
+			}
+			return 0
+		}
+	}).value
+}
+
+// rolling retorna o indicador da chave, criando-o (e alimentando-o com a janela) na primeira consulta.
+func (s *StrategyUseCase) rolling(key string, build func() func(entity.Candle) float64) *rollingIndicator {
+	if ind, ok := s.indicators[key]; ok {
+		return ind
+	}
+	if s.indicators == nil {
+		s.indicators = make(map[string]*rollingIndicator)
+	}
+	ind := &rollingIndicator{update: build()}
+	for i := 0; i < s.Candles.Len(); i++ {
+		ind.push(s.Candles.At(i))
+	}
+	s.indicators[key] = ind
+	return ind
+}
+
+func (ind *rollingIndicator) push(candle entity.Candle) {
+	ind.previous = ind.value
+	ind.value = ind.update(candle)
+}
+
+// updateIndicators alimenta os indicadores já criados com o novo candle.
+func (s *StrategyUseCase) updateIndicators(candle entity.Candle) {
+	for _, ind := range s.indicators {
+		ind.push(candle)
+	}
+}
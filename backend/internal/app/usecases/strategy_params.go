@@ -0,0 +1,152 @@
+// internal/app/usecases/strategy_params.go
+
+package usecases
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// maxParamPeriod limita períodos e contagens configuráveis, evitando janelas que nunca aquecem.
+const maxParamPeriod = 1000
+
+// MergeParams combina os parâmetros padrão da estratégia com os valores configurados
+// para o bot (bot_configs.config_json). Chaves configuradas sobrescrevem os padrões;
+// chaves desconhecidas são preservadas para uso por outros módulos.
+func MergeParams(defaults, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(defaults)+len(overrides))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		if v == nil {
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// ValidateParams confere os valores configurados contra os parâmetros padrão da estratégia:
+// limiares (*_threshold, escala do RSI) aceitam valores entre 0 e 100, demais chaves com
+// padrão inteiro (períodos) exigem inteiros entre 1 e maxParamPeriod, chaves com padrão
+// decimal exigem números finitos não negativos e listas de períodos exigem ao menos um
+// período válido. Chaves desconhecidas não são verificadas aqui.
+func ValidateParams(strategy Strategy, overrides map[string]any) error {
+	if strategy == nil {
+		return nil
+	}
+	for key, def := range strategy.DefaultParams() {
+		val, ok := overrides[key]
+		if !ok || val == nil {
+			continue
+		}
+		switch def.(type) {
+		case int:
+			if strings.HasSuffix(key, "_threshold") {
+				if f, ok := toFloat(val); !ok || f <= 0 || f > 100 {
+					return fmt.Errorf("parâmetro %s inválido: use um valor entre 0 e 100", key)
+				}
+				continue
+			}
+
+			if !validPeriod(val) {
+				return fmt.Errorf("parâmetro %s inválido: use um inteiro entre 1 e %d", key, maxParamPeriod)
+			}
+		case float64:
+			if f, ok := toFloat(val); !ok || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+				return fmt.Errorf("parâmetro %s inválido: use um número maior ou igual a zero", key)
+			}
+		case []int:
+			items, ok := val.([]any)
+			if ints, isInts := val.([]int); isInts {
+				items, ok = make([]any, len(ints)), true
+				for i, v := range ints {
+					items[i] = v
+				}
+			}
+			if !ok || len(items) == 0 {
+				return fmt.Errorf("parâmetro %s inválido: informe uma lista de períodos", key)
+			}
+			for _, item := range items {
+				if !validPeriod(item) {
+					return fmt.Errorf("parâmetro %s inválido: períodos devem ser inteiros entre 1 e %d", key, maxParamPeriod)
+				}
+			}
+		case string:
+			if _, ok := val.(string); !ok {
+				return fmt.Errorf("parâmetro %s inválido: informe um texto", key)
+			}
+		}
+	}
+	return nil
+}
+
+func validPeriod(val any) bool {
+	f, ok := toFloat(val)
+	return ok && f == math.Trunc(f) && f >= 1 && f <= maxParamPeriod
+}
+
+func toFloat(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64: // JSON unmarshals numbers as float64
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func getFloatParam(params map[string]any, key string, defaultVal float64) float64 {
+	if val, ok := params[key]; ok {
+		if f, ok := toFloat(val); ok {
+			return f
+		}
+	}
+	return defaultVal
+}
+
+func getIntParam(params map[string]any, key string, defaultVal int) int {
+	if val, ok := params[key]; ok {
+		if f, ok := toFloat(val); ok {
+			return int(f)
+		}
+	}
+	return defaultVal
+}
+
+func getStringParam(params map[string]any, key string, defaultVal string) string {
+	if val, ok := params[key].(string); ok && val != "" {
+		return val
+	}
+	return defaultVal
+}
+
+func getIntSliceParam(params map[string]any, key string, defaultVal []int) []int {
+	switch v := params[key].(type) {
+	case []int:
+		return v
+	case []any: // JSON unmarshals arrays as []any
+		result := make([]int, 0, len(v))
+		for _, item := range v {
+			f, ok := toFloat(item)
+			if !ok {
+				return defaultVal
+			}
+			result = append(result, int(f))
+		}
+		if len(result) > 0 {
+			return result
+		}
+	}
+	return defaultVal
+}
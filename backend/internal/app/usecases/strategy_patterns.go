@@ -0,0 +1,55 @@
+// internal/app/usecases/strategy_patterns.go
+
+package usecases
+
+import (
+	"maps"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/patterns"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// Patterns retorna os padrões de candlestick que se completam no candle mais recente da janela.
+func (s *StrategyUseCase) Patterns() []patterns.Pattern {
+	rules := patterns.DefaultRules()
+	n := s.Candles.Len()
+	recent := make([]entity.Candle, 0, rules.Lookback())
+	for i := max(n-rules.Lookback(), 0); i < n; i++ {
+		recent = append(recent, s.Candles.At(i))
+	}
+	return rules.DetectLast(recent)
+}
+
+// PatternScore soma os padrões do candle mais recente: positivo favorece alta, negativo baixa.
+func (s *StrategyUseCase) PatternScore() float64 {
+	return patterns.Score(s.Patterns())
+}
+
+// withPatterns copia os indicadores e o contexto do registro de decisão incluindo os padrões
+// do candle: a força de cada um (negativa nos padrões de baixa) e o score em indicators, e a
+// lista em context.
+func (s *StrategyUseCase) withPatterns(indicators map[string]float64, ctx map[string]any) (map[string]float64, map[string]any) {
+	found := s.Patterns()
+
+	indicators = maps.Clone(indicators)
+	if indicators == nil {
+		indicators = make(map[string]float64)
+	}
+	for _, p := range found {
+		value := p.Strength
+		if p.Direction != patterns.Neutral {
+			value *= float64(p.Direction)
+		}
+		indicators["pattern_"+p.Name] = value
+	}
+	indicators["pattern_score"] = patterns.Score(found)
+
+	if len(found) > 0 {
+		ctx = maps.Clone(ctx)
+		if ctx == nil {
+			ctx = make(map[string]any)
+		}
+		ctx["patterns"] = found
+	}
+	return indicators, ctx
+}
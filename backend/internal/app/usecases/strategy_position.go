@@ -0,0 +1,361 @@
+// internal/app/usecases/strategy_position.go
+
+package usecases
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	reporter "github.com/jeancarlosdanese/crypto-bot/internal/report"
+	service "github.com/jeancarlosdanese/crypto-bot/internal/services"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// enterPosition executa a decisão de compra da estratégia, com a quantidade definida pelo
+// dimensionamento do bot (ver newSizer) e sujeita aos limites de risco da conta. Bots
+// autônomos enviam uma ordem a mercado e só abrem a posição quando a execução é
+// confirmada; os demais apenas acompanham o sinal ao preço do candle.
+// Retorna false se a entrada não foi feita (risco, dimensionamento ou ordem recusada).
+func (s *StrategyUseCase) enterPosition(price float64, timestamp int64) bool {
+	quantity, err := s.positionSize(price)
+	if err != nil {
+		logger.Warn("🚫 Entrada ignorada: tamanho de posição indisponível", "bot_id", s.Bot.ID.String(), "error", err.Error())
+		return false
+	}
+
+	// 🛡️ Limites de risco da conta (posições, exposição, perdas, kill switch)
+	if s.RiskGuard != nil {
+		release, err := s.RiskGuard.CheckEntry(s.Account.ID, s.Bot.Symbol, price*quantity)
+		if err != nil {
+			s.saveRiskBlock(price, quantity, timestamp, err)
+			return false
+		}
+		defer release()
+	}
+
+	if !s.placesOrders() {
+		s.LastDecision = "BUY"
+		s.openPosition(price, quantity, timestamp)
+		return true
+	}
+	if err := s.submitOrder(entity.OrderSideBuy, quantity, timestamp); err != nil {
+		logger.Warn("🚫 Entrada não realizada: ordem recusada", "bot_id", s.Bot.ID.String(), "error", err.Error())
+		return false
+	}
+	s.LastDecision = "BUY"
+	return true
+}
+
+// saveRiskBlock registra no log de decisões a entrada barrada pelos limites de risco.
+func (s *StrategyUseCase) saveRiskBlock(price, quantity float64, timestamp int64, err error) {
+	ctx := map[string]any{
+		"candles_total": s.TotalCandles,
+		"risk_reason":   err.Error(),
+	}
+	var block *RiskBlock
+	if errors.As(err, &block) {
+		ctx["risk_rule"] = block.Rule
+		logger.Warn("🛡️ Entrada bloqueada pelos limites de risco", "bot_id", s.Bot.ID.String(), "rule", block.Rule, "detail", block.Detail)
+	} else {
+		logger.Error("Erro ao avaliar limites de risco, entrada bloqueada", err, "bot_id", s.Bot.ID.String())
+	}
+
+	s.saveDecisionLog(s.Strategy.Name(), s.Strategy.Version(), "BLOCKED", timestamp,
+		map[string]float64{"price": price, "quantity": quantity, "notional": price * quantity},
+		s.Params, ctx,
+	)
+}
+
+// ForceExit encerra a posição aberta a mercado, fora da avaliação da estratégia.
+// Retorna false se não há posição ou se já existe uma ordem pendente.
+func (s *StrategyUseCase) ForceExit(reason exits.Reason) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.forceExit(reason)
+}
+
+// RequestClose marca a posição para encerramento forçado (ex: kill switch) e tenta a saída
+// a mercado. A marcação é persistida com a posição e, enquanto a saída não executar (ordem
+// pendente, recusada ou parcial), é repetida a cada avaliação e após reinícios.
+// Retorna true se a saída foi disparada agora.
+func (s *StrategyUseCase) RequestClose(reason exits.Reason) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.PositionQuantity == 0 {
+		return false
+	}
+	s.closeReq = reason
+	if s.PositionRepo != nil {
+		if err := s.PositionRepo.RequestClose(s.Bot.ID, string(reason)); err != nil {
+			logger.Error("❌ Erro ao marcar encerramento da posição", err, "bot_id", s.Bot.ID.String())
+		}
+	}
+	return s.forceExit(reason)
+}
+
+// CloseRequested retorna o motivo do encerramento forçado pendente ("" se não houver).
+func (s *StrategyUseCase) CloseRequested() exits.Reason {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closeReq
+}
+
+// retryRequestedClose repete o encerramento forçado pendente, se houver. O pedido do kill
+// switch é cancelado se o kill switch da conta foi desligado antes da saída executar.
+func (s *StrategyUseCase) retryRequestedClose() (string, bool) {
+	if s.closeReq == "" || s.PositionQuantity == 0 {
+		return "", false
+	}
+	if s.closeReq == exits.ReasonKillSwitch && s.RiskGuard != nil {
+		if limits, err := s.RiskGuard.Limits(s.Account.ID); err == nil && !limits.KillSwitch {
+			logger.Info("🔓 Kill switch desativado, encerramento da posição cancelado", "bot_id", s.Bot.ID.String(), "symbol", s.Bot.Symbol)
+			s.closeReq = ""
+			if s.PositionRepo != nil {
+				if err := s.PositionRepo.RequestClose(s.Bot.ID, ""); err != nil {
+					logger.Error("❌ Erro ao desmarcar encerramento da posição", err, "bot_id", s.Bot.ID.String())
+				}
+			}
+			return "", false
+		}
+	}
+	if s.PendingOrder != nil || !s.forceExit(s.closeReq) {
+		return "HOLD", true
+	}
+	return "SELL", true
+}
+
+func (s *StrategyUseCase) forceExit(reason exits.Reason) bool {
+	if s.PositionQuantity == 0 || s.PendingOrder != nil || s.Strategy == nil {
+		return false
+	}
+
+	price := 0.0
+	if last, ok := s.Candles.Last(); ok {
+		price = last.Close
+	} else if s.Exchange != nil {
+		price, _ = s.Exchange.GetCurrentPrice(utils.FormatForBinance(s.Bot.Symbol))
+	}
+	if price <= 0 {
+		logger.Warn("⚠️ Sem preço para encerrar a posição", "bot_id", s.Bot.ID.String(), "reason", reason)
+		return false
+	}
+
+	timestamp := time.Now().UnixMilli()
+	if !s.exitPosition(price, timestamp, reason) {
+		return false
+	}
+	logger.Info("🧯 Encerrando posição", "bot_id", s.Bot.ID.String(), "symbol", s.Bot.Symbol, "price", price, "reason", reason)
+	s.saveDecisionLog(s.Strategy.Name(), s.Strategy.Version(), "SELL", timestamp,
+		map[string]float64{"price": price},
+		s.Params,
+		map[string]any{"exit_reason": string(reason)},
+	)
+	return true
+}
+
+// exitPosition executa a decisão de venda (ver enterPosition). O motivo é registrado
+// na execução quando a posição for encerrada. Retorna false se a ordem foi recusada,
+// mantendo a posição aberta para a próxima avaliação.
+func (s *StrategyUseCase) exitPosition(price float64, timestamp int64, reason exits.Reason) bool {
+	s.exitReason = reason
+	if !s.placesOrders() {
+		s.LastDecision = "SELL"
+		s.closePosition(price, s.PositionQuantity, timestamp)
+		return true
+	}
+	if err := s.submitOrder(entity.OrderSideSell, s.PositionQuantity, timestamp); err != nil {
+		s.exitReason = ""
+		logger.Warn("🚫 Saída não realizada: ordem recusada", "bot_id", s.Bot.ID.String(), "reason", reason, "error", err.Error())
+		return false
+	}
+	s.LastDecision = "SELL"
+	return true
+}
+
+func (s *StrategyUseCase) placesOrders() bool {
+	return s.Bot.Autonomous && s.OrderManager != nil && s.Exchange != nil
+}
+
+// submitOrder envia a ordem a mercado e aplica a execução confirmada. Ordens recusadas pela
+// exchange retornam o erro, sem alterar a posição.
+func (s *StrategyUseCase) submitOrder(side entity.OrderSide, quantity float64, timestamp int64) error {
+	order, err := s.OrderManager.Submit(s.Exchange, s.Account.ID, s.Bot, entity.OrderRequest{
+		Symbol:   utils.FormatForBinance(s.Bot.Symbol),
+		Side:     side,
+		Type:     entity.OrderTypeMarket,
+		Quantity: quantity,
+	})
+	if err != nil {
+		return err
+	}
+	s.applyOrder(order, timestamp)
+	return nil
+}
+
+// syncPendingOrder consulta a ordem pendente e aplica a execução, se houver.
+func (s *StrategyUseCase) syncPendingOrder(timestamp int64) {
+	order, err := s.OrderManager.Refresh(s.Exchange, s.PendingOrder)
+	if err != nil {
+		logger.Error("Erro ao sincronizar ordem pendente", err, "bot_id", s.Bot.ID.String())
+		return
+	}
+	s.applyOrder(order, timestamp)
+}
+
+// applyOrder abre ou fecha a posição conforme a execução confirmada da ordem.
+// Ordens ainda abertas ficam pendentes até a próxima sincronização.
+func (s *StrategyUseCase) applyOrder(order *entity.Order, timestamp int64) {
+	if !order.Status.IsFinal() {
+		s.PendingOrder = order
+		return
+	}
+	s.PendingOrder = nil
+
+	if order.ExecutedQuantity == 0 {
+		logger.Warn("⚠️ Ordem finalizada sem execução",
+			"bot_id", s.Bot.ID.String(),
+			"exchange_order_id", order.ExchangeOrderID,
+			"status", order.Status,
+		)
+		return
+	}
+
+	if order.Side == entity.OrderSideBuy {
+		// A posição fica no step size: a taxa no ativo comprado deixaria uma fração invendável
+		s.openPosition(order.AvgPrice(), service.FloorToMarketLot(s.symbolInfo(), s.netQuantity(order)), timestamp)
+		return
+	}
+	s.closePosition(order.AvgPrice(), order.ExecutedQuantity, timestamp)
+}
+
+// netQuantity desconta a taxa quando ela é cobrada no próprio ativo comprado.
+func (s *StrategyUseCase) netQuantity(order *entity.Order) float64 {
+	base, _, _ := strings.Cut(s.Bot.Symbol, "/")
+	if order.FeeAsset == base {
+		return order.ExecutedQuantity - order.Fee
+	}
+	return order.ExecutedQuantity
+}
+
+// symbolInfo retorna os filtros do símbolo do bot, ou nil se a exchange não os informar.
+func (s *StrategyUseCase) symbolInfo() *entity.SymbolInfo {
+	if s.Exchange == nil {
+		return nil
+	}
+	info, err := s.Exchange.GetSymbolInfo(utils.FormatForBinance(s.Bot.Symbol))
+	if err != nil {
+		logger.Warn("⚠️ Filtros do símbolo indisponíveis", "bot_id", s.Bot.ID.String(), "symbol", s.Bot.Symbol, "error", err.Error())
+		return nil
+	}
+	return info
+}
+
+// openPosition registra a posição aberta em memória e no banco. Em uma nova posição
+// o acompanhamento da política de saída é reiniciado.
+func (s *StrategyUseCase) openPosition(price, quantity float64, timestamp int64) {
+	if s.PositionQuantity == 0 {
+		s.ExitState = newExitPolicy(s.Params).Open(price, timestamp, s.atr())
+	}
+	s.PositionQuantity = quantity
+	s.LastEntryPrice = price
+	s.LastEntryTimestamp = timestamp
+	s.savePosition()
+}
+
+// RestorePosition retoma uma posição salva no banco (ex: após reinício do bot).
+func (s *StrategyUseCase) RestorePosition(pos entity.OpenPosition) {
+	s.PositionQuantity = pos.Quantity
+	s.LastEntryPrice = pos.EntryPrice
+	s.LastEntryTimestamp = pos.Timestamp
+	s.ExitState = exits.State{
+		EntryPrice:   pos.EntryPrice,
+		EntryTime:    pos.Timestamp,
+		HighestPrice: max(pos.HighestPrice, pos.EntryPrice),
+		StopPrice:    pos.StopPrice,
+		StopReason:   exits.Reason(pos.StopReason),
+	}
+	s.closeReq = exits.Reason(pos.CloseRequested)
+}
+
+// savePosition persiste a posição aberta com o acompanhamento da política de saída.
+func (s *StrategyUseCase) savePosition() {
+	if s.PositionRepo == nil {
+		return
+	}
+	err := s.PositionRepo.Save(entity.OpenPosition{
+		BotID:        s.Bot.ID,
+		EntryPrice:   s.LastEntryPrice,
+		Quantity:     s.PositionQuantity,
+		Timestamp:    s.LastEntryTimestamp,
+		HighestPrice: s.ExitState.HighestPrice,
+		StopPrice:    s.ExitState.StopPrice,
+		StopReason:   string(s.ExitState.StopReason),
+	})
+	if err != nil {
+		logger.Error("❌ Erro ao salvar posição", err, "bot_id", s.Bot.ID.String())
+	}
+}
+
+// closePosition encerra a posição (total ou parcialmente) e grava o log de execução da
+// quantidade vendida. Em uma venda parcial a posição continua aberta com o restante, a menos
+// que ele fique abaixo dos filtros do símbolo e não possa mais ser vendido (ver service.IsDust).
+func (s *StrategyUseCase) closePosition(price, quantity float64, timestamp int64) {
+	quantity = min(quantity, s.PositionQuantity)
+	if remaining := s.PositionQuantity - quantity; remaining > 0 && !s.isDust(remaining, price) {
+		logger.Warn("⚠️ Saída parcial da posição", "bot_id", s.Bot.ID.String(), "executado", quantity, "restante", remaining)
+		s.PositionQuantity = remaining
+		s.savePosition()
+	} else {
+		if remaining > 0 {
+			logger.Info("🧹 Resíduo abaixo dos filtros do símbolo, posição encerrada", "bot_id", s.Bot.ID.String(), "restante", remaining)
+		}
+		s.PositionQuantity = 0
+		s.ExitState = exits.State{}
+		s.closeReq = ""
+		if s.PositionRepo != nil {
+			_ = s.PositionRepo.Delete(s.Bot.ID)
+		}
+	}
+
+	// Resultado em moeda de cotação sobre a quantidade vendida
+	profit := (price - s.LastEntryPrice) * quantity
+	roi := ((price - s.LastEntryPrice) / s.LastEntryPrice) * 100
+	duration := (timestamp - s.LastEntryTimestamp) / 1000
+
+	exec := entity.ExecutionLog{
+		BotID:      s.Bot.ID,
+		Symbol:     s.Bot.Symbol,
+		Interval:   s.Bot.Interval,
+		Entry:      entity.TradePoint{Price: s.LastEntryPrice, Timestamp: s.LastEntryTimestamp},
+		Exit:       entity.TradePoint{Price: price, Timestamp: timestamp},
+		Quantity:   quantity,
+		Profit:     profit,
+		ROIPct:     roi,
+		Duration:   duration,
+		ExitReason: string(s.exitReason),
+		Strategy:   entity.StrategyInfo{Name: s.Strategy.Name(), Version: s.Strategy.Version()},
+		CreatedAt:  time.Now(),
+	}
+	s.exitReason = ""
+	if s.OnExecution != nil {
+		s.OnExecution(exec)
+	}
+	if s.ExecutionLogRepo != nil {
+		_ = s.ExecutionLogRepo.Save(exec)
+		go reporter.PrintExecutionSummary(s.ExecutionLogRepo)
+	}
+}
+
+// isDust informa se o restante da posição não pode mais ser vendido. Sem os filtros do
+// símbolo, apenas o ruído de ponto flutuante (até 1e-6 da posição) é descartado.
+func (s *StrategyUseCase) isDust(remaining, price float64) bool {
+	if info := s.symbolInfo(); info != nil {
+		return service.IsDust(info, remaining, price)
+	}
+	return remaining <= s.PositionQuantity*1e-6
+}
@@ -0,0 +1,79 @@
+// internal/app/usecases/strategy_sizing.go
+
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/sizing"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+)
+
+// balanceHeadroom deixa margem no saldo para o slippage de ordens a mercado.
+const balanceHeadroom = 0.99
+
+// newSizer monta o dimensionamento configurado no bot (chaves sizing_*):
+//
+//	sizing_method         fixed_quote (padrão), balance_pct, fixed_fractional ou volatility_target
+//	sizing_quote_amount   valor por entrada em moeda de cotação (fixed_quote, padrão 100)
+//	sizing_balance_pct    percentual do saldo por entrada (balance_pct, padrão 10)
+//	sizing_risk_pct       percentual do saldo arriscado até o stop (fixed_fractional, padrão 1)
+//	sizing_atr_multiplier distância do stop em ATRs usada no risco (fixed_fractional, padrão 1.5)
+//	sizing_target_vol_pct volatilidade alvo da posição em % do saldo (volatility_target, padrão 0.5)
+//	sizing_max_pct        exposição máxima em % do saldo (fixed_fractional/volatility_target, padrão 100)
+func newSizer(params map[string]any) sizing.Sizer {
+	maxPct := getFloatParam(params, "sizing_max_pct", 100)
+
+	method, _ := params["sizing_method"].(string)
+	switch method {
+	case sizing.MethodBalancePercent:
+		return sizing.BalancePercent{Percent: getFloatParam(params, "sizing_balance_pct", 10)}
+	case sizing.MethodFixedFractional:
+		return sizing.FixedFractional{
+			RiskPercent:        getFloatParam(params, "sizing_risk_pct", 1),
+			ATRMultiplier:      getFloatParam(params, "sizing_atr_multiplier", 1.5),
+			MaxPositionPercent: maxPct,
+		}
+	case sizing.MethodVolatilityTarget:
+		return sizing.VolatilityTarget{
+			TargetPercent:      getFloatParam(params, "sizing_target_vol_pct", 0.5),
+			MaxPositionPercent: maxPct,
+		}
+	case "", sizing.MethodFixedQuote:
+	default:
+		logger.Warn("sizing_method desconhecido, usando fixed_quote", "sizing_method", method)
+	}
+	return sizing.FixedQuote{Amount: getFloatParam(params, "sizing_quote_amount", 100)}
+}
+
+// positionSize calcula a quantidade da próxima entrada. Bots que enviam ordens
+// ficam limitados ao saldo disponível em moeda de cotação.
+func (s *StrategyUseCase) positionSize(price float64) (float64, error) {
+	in := sizing.Input{
+		Price: price,
+		ATR:   s.atr(),
+	}
+
+	if s.Exchange != nil {
+		_, quote, _ := strings.Cut(s.Bot.Symbol, "/")
+		balance, err := s.Exchange.GetFreeBalance(quote)
+		if err != nil && s.placesOrders() {
+			return 0, fmt.Errorf("erro ao obter saldo de %s: %w", quote, err)
+		}
+		in.Balance = balance
+	}
+
+	quantity, err := newSizer(s.Params).Size(in)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.placesOrders() {
+		quantity = min(quantity, in.Balance*balanceHeadroom/price)
+	}
+	if quantity <= 0 {
+		return 0, fmt.Errorf("%w: saldo %.8f", sizing.ErrNoSize, in.Balance)
+	}
+	return quantity, nil
+}
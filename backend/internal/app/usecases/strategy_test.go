@@ -0,0 +1,427 @@
+// internal/app/usecases/strategy_test.go
+
+package usecases_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/paper"
+	"github.com/jeancarlosdanese/crypto-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStrategy(t *testing.T) {
+	crossover, err := usecases.GetStrategy("EvaluateCrossover")
+	assert.NoError(t, err)
+	assert.Equal(t, "EvaluateCrossover", crossover.Name())
+
+	trend, err := usecases.GetStrategy("EvaluateTrendCrossover")
+	assert.NoError(t, err)
+	assert.Implements(t, (*usecases.MultiTimeframeStrategy)(nil), trend)
+
+	emaFan, err := usecases.GetStrategy("EvaluateEMAFanWithVolume")
+	assert.NoError(t, err)
+	assert.Equal(t, "EvaluateEMAFanWithVolume", emaFan.Name())
+
+	_, err = usecases.GetStrategy("Inexistente")
+	assert.Error(t, err)
+}
+
+func TestEvaluateHoldsDuringWarmup(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{}, strategy, nil, nil, nil, nil, 10)
+
+	warmup := strategy.WarmupCandles(uc.Params)
+	assert.Equal(t, warmup, uc.WindowSize)
+
+	for i := 0; i < warmup-1; i++ {
+		uc.UpdateCandle(entity.Candle{Open: 1, High: 1, Low: 1, Close: 1, Time: int64(i)})
+	}
+	assert.Equal(t, "HOLD", uc.Evaluate(0))
+}
+
+func TestSetParamsMergesBotConfig(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{}, strategy, nil, nil, nil, nil, 10)
+
+	// Valores vindos do config_json chegam como float64
+	uc.SetParams(map[string]any{"ma_long": float64(50), "atr_min": 0.5})
+
+	assert.Equal(t, float64(50), uc.Params["ma_long"])
+	assert.Equal(t, 0.5, uc.Params["atr_min"])
+	assert.Equal(t, 9, uc.Params["ma_short"])
+	assert.Equal(t, 50, uc.WindowSize)
+}
+
+func TestTimeframeCandlesWithoutLookahead(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateTrendCrossover")
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{Interval: "1m"}, strategy, nil, nil, nil, nil, 10)
+	uc.SetParams(map[string]any{"trend_interval": "5m", "trend_ema": 2.0})
+	assert.Equal(t, map[string]int{"5m": 6}, uc.Timeframes())
+
+	minute := func(i int64) entity.Candle {
+		open := i * 60_000
+		return entity.Candle{Open: 1, High: 2, Low: 0.5, Close: float64(i), Volume: 1, Time: (open + 59_999) / 1000, OpenTime: open, CloseTime: open + 59_999}
+	}
+
+	// Histórico semeado até 00:00; um candle que ainda não fechou para o bot fica oculto
+	uc.SeedTimeframe("5m", []entity.Candle{
+		{Close: -1, OpenTime: -300_000, CloseTime: -1},
+		{Close: 99, OpenTime: 0, CloseTime: 299_999},
+	})
+	uc.UpdateCandle(minute(0))
+	assert.Len(t, uc.TimeframeCandles("5m"), 1, "o candle 00:00-00:04 só existe depois de 00:04")
+
+	for i := int64(1); i < 5; i++ {
+		uc.UpdateCandle(minute(i))
+	}
+	candles := uc.TimeframeCandles("5m")
+	assert.Len(t, candles, 2)
+	assert.Equal(t, float64(99), candles[1].Close, "o candle semeado não é duplicado pela agregação")
+
+	for i := int64(5); i < 9; i++ {
+		uc.UpdateCandle(minute(i))
+	}
+	assert.Len(t, uc.TimeframeCandles("5m"), 2, "00:05-00:09 ainda em formação")
+	uc.UpdateCandle(minute(9))
+	candles = uc.TimeframeCandles("5m")
+	require.Len(t, candles, 3)
+	assert.Equal(t, float64(9), candles[2].Close)
+	assert.Equal(t, int64(599_999), candles[2].CloseTime)
+
+	assert.Equal(t, "HOLD", uc.Evaluate(600_000), "janela de 5m ainda sem os 6 candles")
+}
+
+func TestSetParamsRejectsInvalidTrendInterval(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateTrendCrossover")
+	bot := entity.Bot{Interval: "5m"}
+	uc := usecases.NewStrategyUseCase(entity.Account{}, bot, strategy, nil, nil, nil, nil, 10)
+
+	for _, interval := range []string{"7m", "1m", "2h30m", "1M"} {
+		assert.Error(t, uc.SetParams(map[string]any{"trend_interval": interval}), interval)
+		assert.Error(t, uc.ApplyConfig(bot, strategy, map[string]any{"trend_interval": interval}), interval)
+		assert.Error(t, usecases.ValidateTimeframes(strategy, bot.Interval, map[string]any{"trend_interval": interval}), interval)
+	}
+	assert.Equal(t, map[string]int{"1h": 63}, uc.Timeframes(), "intervalos inválidos mantêm a configuração anterior")
+
+	require.NoError(t, uc.SetParams(map[string]any{"trend_interval": "15m"}))
+	assert.Contains(t, uc.Timeframes(), "15m")
+}
+
+func TestPatternsUseRecentCandles(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{}, strategy, nil, nil, nil, nil, 10)
+
+	for _, c := range []entity.Candle{
+		{Open: 110, High: 110.5, Low: 108.5, Close: 109},
+		{Open: 108.5, High: 109, Low: 107, Close: 107.5},
+		{Open: 102, High: 102.5, Low: 99.5, Close: 100},
+		{Open: 99.8, High: 103.5, Low: 99.5, Close: 103},
+	} {
+		uc.UpdateCandle(c)
+	}
+
+	found := uc.Patterns()
+	require.NotEmpty(t, found)
+	assert.Equal(t, "bullish_engulfing", found[0].Name)
+	assert.Greater(t, uc.PatternScore(), 0.0)
+}
+
+func TestSetParamsRejectsInvalidValues(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{}, strategy, nil, nil, nil, nil, 10)
+
+	for _, overrides := range []map[string]any{
+		{"ma_long": float64(0)},
+		{"ma_short": float64(-5)},
+		{"rsi_period": 2.5},
+		{"rsi_threshold": float64(120)},
+		{"ma_long": "26"},
+		{"atr_min": -1.0},
+		{"ma_long": float64(5000)},
+	} {
+		assert.Error(t, uc.SetParams(overrides), "%v", overrides)
+	}
+	assert.Equal(t, 26, uc.Params["ma_long"], "parâmetros inválidos mantêm a configuração anterior")
+
+	fan, _ := usecases.GetStrategy("EvaluateEMAFanWithVolume")
+	assert.Error(t, usecases.ValidateParams(fan, map[string]any{"emas": []any{10.0, 0.0}}))
+	assert.Error(t, usecases.ValidateParams(fan, map[string]any{"emas": []any{}}))
+	assert.NoError(t, usecases.ValidateParams(fan, map[string]any{"emas": []any{5.0, 8.0}, "sizing_method": "fixed"}))
+}
+
+func TestEMASlopeFollowsRollingEMA(t *testing.T) {
+	strategy, _ := usecases.GetStrategy("EvaluateEMAFanWithVolume")
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{}, strategy, nil, nil, nil, nil, 50)
+
+	var closes []float64
+	for i := 0; i < 30; i++ {
+		price := 100 + float64(i%7) - float64(i)/3
+		closes = append(closes, price)
+		uc.UpdateCandle(entity.Candle{Open: price, High: price, Low: price, Close: price, CloseTime: int64(i)})
+	}
+	uc.EMA(10)
+
+	// A inclinação acompanha a EMA incremental a cada novo candle
+	for i := 30; i < 40; i++ {
+		price := 100 + float64(i%5)
+		closes = append(closes, price)
+		uc.UpdateCandle(entity.Candle{Open: price, High: price, Low: price, Close: price, CloseTime: int64(i)})
+
+		ema := indicators.EMASeries(closes, 10)
+		now, prev := ema[len(ema)-1], ema[len(ema)-2]
+		assert.InDelta(t, now, uc.EMA(10), 1e-9)
+		assert.InDelta(t, (now-prev)/prev*100, uc.EMASlope(10), 1e-9)
+	}
+}
+
+func TestForceExitHandlesRejectedAndPartialSells(t *testing.T) {
+	logger.InitLogger()
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	exchange := &mocks.MockExchangeService{}
+	bot := entity.Bot{ID: uuid.New(), Symbol: "BTC/USDT", Interval: "1m", Autonomous: true}
+	uc := usecases.NewStrategyUseCase(entity.Account{ID: uuid.New()}, bot, strategy, exchange, nil, nil, nil, 10)
+	uc.OrderManager = usecases.NewOrderManager(mocks.NewMockOrderRepository())
+
+	var executions []entity.ExecutionLog
+	uc.OnExecution = func(exec entity.ExecutionLog) { executions = append(executions, exec) }
+
+	uc.UpdateCandle(entity.Candle{Open: 110, High: 110, Low: 110, Close: 110})
+	uc.RestorePosition(entity.OpenPosition{BotID: bot.ID, EntryPrice: 100, Quantity: 1})
+
+	// Ordem recusada: a posição continua aberta e nada é registrado
+	exchange.FillOrder = func(entity.OrderRequest) (*entity.Order, error) { return nil, errors.New("saldo insuficiente") }
+	assert.False(t, uc.ForceExit(exits.ReasonKillSwitch))
+	assert.Equal(t, 1.0, uc.PositionQuantity)
+	assert.Empty(t, executions)
+
+	// Execução parcial: registra o resultado do que foi vendido e mantém o restante
+	exchange.FillOrder = func(req entity.OrderRequest) (*entity.Order, error) {
+		return &entity.Order{Symbol: req.Symbol, Side: req.Side, Type: req.Type, Status: entity.OrderStatusCanceled,
+			Quantity: req.Quantity, ExecutedQuantity: 0.6, QuoteQuantity: 66}, nil
+	}
+	require.True(t, uc.ForceExit(exits.ReasonKillSwitch))
+	assert.InDelta(t, 0.4, uc.PositionQuantity, 1e-9)
+	require.Len(t, executions, 1)
+	assert.InDelta(t, 0.6, executions[0].Quantity, 1e-9)
+	assert.InDelta(t, 6.0, executions[0].Profit, 1e-9)
+	assert.Equal(t, string(exits.ReasonKillSwitch), executions[0].ExitReason)
+}
+
+func TestRequestCloseRetriesUntilExitFills(t *testing.T) {
+	logger.InitLogger()
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	exchange := &mocks.MockExchangeService{}
+	account := entity.Account{ID: uuid.New()}
+	bot := entity.Bot{ID: uuid.New(), Symbol: "BTC/USDT", Interval: "1m", Autonomous: true}
+	positions := mocks.NewMockPositionRepository()
+	limits := mocks.NewMockRiskLimitsRepository()
+	limits.Save(entity.RiskLimits{AccountID: account.ID, KillSwitch: true})
+
+	uc := usecases.NewStrategyUseCase(account, bot, strategy, exchange, nil, nil, positions, 4)
+	uc.OrderManager = usecases.NewOrderManager(mocks.NewMockOrderRepository())
+	uc.RiskGuard = usecases.NewRiskGuard(limits, positions, mocks.NewMockOrderRepository(), &mocks.MockExecutionLogRepository{}, nil)
+	require.NoError(t, uc.SetParams(map[string]any{"ma_short": 2.0, "ma_long": 3.0, "rsi_period": 2.0}))
+	for i := 0; i < 4; i++ {
+		uc.UpdateCandle(entity.Candle{Open: 110, High: 110, Low: 110, Close: 110, CloseTime: int64(i)})
+	}
+
+	position := entity.OpenPosition{BotID: bot.ID, EntryPrice: 100, Quantity: 1, StopPrice: 95, StopReason: string(exits.ReasonStopLoss)}
+	require.NoError(t, positions.Save(position))
+	uc.RestorePosition(position)
+
+	// Ordem recusada: o pedido fica gravado sem alterar o stop da posição
+	exchange.FillOrder = func(entity.OrderRequest) (*entity.Order, error) { return nil, errors.New("saldo insuficiente") }
+	assert.False(t, uc.RequestClose(exits.ReasonKillSwitch))
+	assert.Equal(t, string(exits.ReasonKillSwitch), positions.Positions[bot.ID].CloseRequested)
+	assert.Equal(t, string(exits.ReasonStopLoss), positions.Positions[bot.ID].StopReason)
+	assert.Equal(t, "HOLD", uc.Evaluate(4))
+
+	// Execução parcial: o restante continua marcado
+	exchange.FillOrder = func(req entity.OrderRequest) (*entity.Order, error) {
+		return &entity.Order{Symbol: req.Symbol, Side: req.Side, Type: req.Type, Status: entity.OrderStatusCanceled,
+			Quantity: req.Quantity, ExecutedQuantity: 0.6, QuoteQuantity: 66}, nil
+	}
+	assert.Equal(t, "SELL", uc.Evaluate(5))
+	assert.InDelta(t, 0.4, uc.PositionQuantity, 1e-9)
+	assert.Equal(t, exits.ReasonKillSwitch, uc.CloseRequested())
+	assert.Equal(t, string(exits.ReasonKillSwitch), positions.Positions[bot.ID].CloseRequested)
+
+	// Execução completa: a posição e o pedido são encerrados
+	exchange.FillOrder = func(req entity.OrderRequest) (*entity.Order, error) {
+		return &entity.Order{Symbol: req.Symbol, Side: req.Side, Type: req.Type, Status: entity.OrderStatusFilled,
+			Quantity: req.Quantity, ExecutedQuantity: req.Quantity, QuoteQuantity: req.Quantity * 110}, nil
+	}
+	assert.Equal(t, "SELL", uc.Evaluate(6))
+	assert.Zero(t, uc.PositionQuantity)
+	assert.Empty(t, uc.CloseRequested())
+	assert.Empty(t, positions.Positions)
+}
+
+func TestRequestCloseCanceledWhenKillSwitchTurnsOff(t *testing.T) {
+	logger.InitLogger()
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	exchange := &mocks.MockExchangeService{
+		FillOrder: func(entity.OrderRequest) (*entity.Order, error) { return nil, errors.New("saldo insuficiente") },
+	}
+	account := entity.Account{ID: uuid.New()}
+	bot := entity.Bot{ID: uuid.New(), Symbol: "BTC/USDT", Interval: "1m", Autonomous: true}
+	positions := mocks.NewMockPositionRepository()
+	limits := mocks.NewMockRiskLimitsRepository()
+	limits.Save(entity.RiskLimits{AccountID: account.ID, KillSwitch: true})
+
+	uc := usecases.NewStrategyUseCase(account, bot, strategy, exchange, nil, nil, positions, 4)
+	uc.OrderManager = usecases.NewOrderManager(mocks.NewMockOrderRepository())
+	uc.RiskGuard = usecases.NewRiskGuard(limits, positions, mocks.NewMockOrderRepository(), &mocks.MockExecutionLogRepository{}, nil)
+	require.NoError(t, uc.SetParams(map[string]any{"ma_short": 2.0, "ma_long": 3.0, "rsi_period": 2.0}))
+	for i := 0; i < 4; i++ {
+		uc.UpdateCandle(entity.Candle{Open: 110, High: 110, Low: 110, Close: 110, CloseTime: int64(i)})
+	}
+
+	position := entity.OpenPosition{BotID: bot.ID, EntryPrice: 100, Quantity: 1}
+	require.NoError(t, positions.Save(position))
+	uc.RestorePosition(position)
+	assert.False(t, uc.RequestClose(exits.ReasonKillSwitch))
+
+	// Kill switch desligado antes da saída executar: o pedido é desfeito e a posição segue
+	limits.Save(entity.RiskLimits{AccountID: account.ID})
+	assert.Equal(t, "HOLD", uc.Evaluate(4))
+	assert.Empty(t, uc.CloseRequested())
+	assert.Empty(t, positions.Positions[bot.ID].CloseRequested)
+	assert.Equal(t, 1.0, uc.PositionQuantity)
+}
+
+func TestEvaluateExitsAppliesOnlyExitPolicy(t *testing.T) {
+	logger.InitLogger()
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	params := map[string]any{"ma_short": 2.0, "ma_long": 3.0, "rsi_period": 2.0, "rsi_threshold": 100.0, "exit_stop_loss_pct": 5.0}
+	prices := []float64{100, 99, 98, 97, 99, 102}
+	candle := func(i int, price float64) entity.Candle {
+		return entity.Candle{Open: price, High: price, Low: price, Close: price, CloseTime: int64(i)}
+	}
+
+	// Na avaliação completa, o cruzamento no último candle abre posição
+	live := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{Symbol: "BTC/USDT"}, strategy, nil, nil, nil, nil, 4)
+	require.NoError(t, live.SetParams(params))
+	for i, price := range prices {
+		live.UpdateCandle(candle(i, price))
+		live.Evaluate(int64(i))
+	}
+	require.Greater(t, live.PositionQuantity, 0.0)
+
+	// Os mesmos candles recuperados passam só pela política de saída e não abrem posição
+	uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{Symbol: "BTC/USDT"}, strategy, nil, nil, nil, nil, 4)
+	require.NoError(t, uc.SetParams(params))
+	for i, price := range prices {
+		uc.UpdateCandle(candle(i, price))
+		assert.Equal(t, "HOLD", uc.EvaluateExits(int64(i)))
+	}
+	assert.Zero(t, uc.PositionQuantity)
+
+	// Com posição aberta, o stop atingido pela mínima do candle recuperado encerra a posição
+	uc.RestorePosition(entity.OpenPosition{EntryPrice: 100, Quantity: 1, StopPrice: 95, StopReason: string(exits.ReasonStopLoss)})
+	uc.UpdateCandle(entity.Candle{Open: 99, High: 100, Low: 94, Close: 98, CloseTime: 6})
+	assert.Equal(t, "SELL", uc.EvaluateExits(6))
+	assert.Zero(t, uc.PositionQuantity)
+}
+
+func TestCrossoverPatternMinScoreFiltersEntries(t *testing.T) {
+	logger.InitLogger()
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	params := map[string]any{"ma_short": 2.0, "ma_long": 3.0, "rsi_period": 2.0, "rsi_threshold": 100.0, "pattern_min_score": 0.5}
+	flat := func(i int, price float64) entity.Candle {
+		return entity.Candle{Open: price, High: price, Low: price, Close: price, CloseTime: int64(i)}
+	}
+	run := func(last []entity.Candle) *usecases.StrategyUseCase {
+		uc := usecases.NewStrategyUseCase(entity.Account{}, entity.Bot{Symbol: "BTC/USDT"}, strategy, nil, nil, nil, nil, 4)
+		require.NoError(t, uc.SetParams(params))
+		for i, c := range append([]entity.Candle{flat(0, 100), flat(1, 99), flat(2, 98), flat(3, 97)}, last...) {
+			uc.UpdateCandle(c)
+			uc.Evaluate(int64(i))
+		}
+		return uc
+	}
+
+	// Cruzamento sem padrão de candlestick: entrada vetada
+	assert.Zero(t, run([]entity.Candle{flat(4, 99), flat(5, 102)}).PositionQuantity)
+
+	// O mesmo cruzamento confirmado por um engolfo de alta
+	engulfing := []entity.Candle{
+		{Open: 100, High: 100.2, Low: 98.8, Close: 99, CloseTime: 4},
+		{Open: 98.9, High: 102.2, Low: 98.8, Close: 102, CloseTime: 5},
+	}
+	assert.Greater(t, run(engulfing).PositionQuantity, 0.0)
+}
+
+func TestBuyFeeInBaseAssetKeepsPositionSellable(t *testing.T) {
+	logger.InitLogger()
+
+	market := &mocks.MockExchangeService{
+		Prices: map[string]float64{"BTCUSDT": 102},
+		Symbols: map[string]*entity.SymbolInfo{"BTCUSDT": {
+			Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT",
+			StepSize: 0.001, MinQty: 0.001, MinNotional: 5, ApplyMinNotionalToMarket: true,
+		}},
+	}
+	exchange, err := paper.NewPaperExchange(uuid.New(), market, paper.DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	bot := entity.Bot{ID: uuid.New(), Symbol: "BTC/USDT", Interval: "1m", Autonomous: true}
+	uc := usecases.NewStrategyUseCase(entity.Account{ID: uuid.New()}, bot, strategy, exchange, nil, nil, nil, 10)
+	uc.OrderManager = usecases.NewOrderManager(mocks.NewMockOrderRepository())
+	require.NoError(t, uc.SetParams(map[string]any{"ma_short": 2.0, "ma_long": 3.0, "rsi_period": 2.0, "rsi_threshold": 100.0}))
+
+	for i, price := range []float64{100, 99, 98, 97, 99, 102} {
+		uc.UpdateCandle(entity.Candle{Open: price, High: price, Low: price, Close: price, CloseTime: int64(i)})
+		uc.Evaluate(int64(i))
+	}
+
+	// 100 USDT a 102 → 0.980 BTC, menos a taxa de 0.00098 BTC: a posição fica no step size
+	require.Equal(t, 0.979, uc.PositionQuantity)
+
+	// A venda a mercado encerra a posição inteira, sem resíduo preso
+	require.True(t, uc.ForceExit(exits.ReasonKillSwitch))
+	assert.Zero(t, uc.PositionQuantity)
+}
+
+func TestEvaluateResumesAfterLostPendingOrder(t *testing.T) {
+	logger.InitLogger()
+
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 100}}
+	exchange, err := paper.NewPaperExchange(uuid.New(), market, paper.DefaultConfig(), nil)
+	require.NoError(t, err)
+
+	strategy, _ := usecases.GetStrategy("EvaluateCrossover")
+	bot := entity.Bot{ID: uuid.New(), Symbol: "BTC/USDT", Interval: "1m", Autonomous: true}
+	uc := usecases.NewStrategyUseCase(entity.Account{ID: uuid.New()}, bot, strategy, exchange, nil, nil, nil, 10)
+	repo := mocks.NewMockOrderRepository()
+	uc.OrderManager = usecases.NewOrderManager(repo)
+	require.NoError(t, uc.SetParams(map[string]any{"ma_short": 2.0, "ma_long": 3.0, "rsi_period": 2.0, "rsi_threshold": 100.0}))
+
+	// Ordem pendente restaurada que a exchange simulada não conhece (perdida no reinício)
+	lost, _ := repo.Save(entity.Order{ExchangeOrderID: "paper-1-1", Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Status: entity.OrderStatusNew, CreatedAt: time.Now()})
+	uc.PendingOrder = lost
+
+	for i, price := range []float64{100, 99, 98, 97, 99, 102} {
+		uc.UpdateCandle(entity.Candle{Open: price, High: price, Low: price, Close: price, CloseTime: int64(i)})
+		uc.Evaluate(int64(i))
+	}
+	assert.Nil(t, uc.PendingOrder)
+	assert.Equal(t, entity.OrderStatusRejected, repo.Orders[lost.ID].Status)
+	assert.Greater(t, uc.PositionQuantity, 0.0)
+}
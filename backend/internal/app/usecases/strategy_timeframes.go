@@ -0,0 +1,167 @@
+// internal/app/usecases/strategy_timeframes.go
+
+package usecases
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// MultiTimeframeStrategy é implementada pelas estratégias que, além dos candles de
+// Bot.Interval, consomem janelas de intervalos maiores (ex: entradas em 1m filtradas pela
+// tendência de 1h).
+type MultiTimeframeStrategy interface {
+	Strategy
+	Timeframes(params map[string]any) map[string]int // intervalo -> candles necessários na janela
+}
+
+// timeframeWindow mantém os candles fechados de um intervalo maior, montados a partir dos
+// candles do bot (ou semeados pelo histórico da exchange no aquecimento).
+type timeframeWindow struct {
+	aggregator *marketdata.Aggregator
+	size       int
+	candles    []entity.Candle
+}
+
+func (w *timeframeWindow) add(candle entity.Candle) {
+	if n := len(w.candles); n > 0 && candle.OpenTime <= w.candles[n-1].OpenTime {
+		return // já semeado pelo histórico
+	}
+	w.candles = append(w.candles, candle)
+	if excess := len(w.candles) - w.size; excess > 0 {
+		w.candles = w.candles[excess:]
+	}
+}
+
+// ValidateTimeframes confere se os intervalos adicionais declarados pela estratégia com os
+// parâmetros configurados podem ser montados a partir dos candles de botInterval (intervalos
+// válidos, múltiplos dele). Sem intervalo do bot não há o que conferir.
+func ValidateTimeframes(strategy Strategy, botInterval string, overrides map[string]any) error {
+	mtf, ok := strategy.(MultiTimeframeStrategy)
+	if !ok || botInterval == "" {
+		return nil
+	}
+	for interval := range mtf.Timeframes(MergeParams(strategy.DefaultParams(), overrides)) {
+		if _, err := marketdata.NewAggregator(botInterval, interval); err != nil {
+			return fmt.Errorf("intervalo adicional %s inválido para o bot em %s: %w", interval, botInterval, err)
+		}
+	}
+	return nil
+}
+
+// Timeframes retorna os intervalos adicionais declarados pela estratégia e o tamanho das janelas.
+func (s *StrategyUseCase) Timeframes() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.timeframeSizes()
+}
+
+func (s *StrategyUseCase) timeframeSizes() map[string]int {
+	sizes := make(map[string]int, len(s.timeframes))
+	for interval, w := range s.timeframes {
+		sizes[interval] = w.size
+	}
+	return sizes
+}
+
+// TimeframeCandles retorna a janela de candles fechados do intervalo maior. Sem lookahead:
+// um candle só aparece depois que o último candle do bot contido nele fecha, e nunca
+// termina depois do candle do bot mais recente.
+func (s *StrategyUseCase) TimeframeCandles(interval string) []entity.Candle {
+	w, ok := s.timeframes[interval]
+	if !ok {
+		return nil
+	}
+	candles := w.candles
+	if last, ok := s.Candles.Last(); ok {
+		lastClose := last.CloseTime
+		for len(candles) > 0 && candles[len(candles)-1].CloseTime > lastClose {
+			candles = candles[:len(candles)-1]
+		}
+	}
+	return candles
+}
+
+// SeedTimeframe preenche a janela do intervalo maior com candles fechados do histórico
+// (em ordem cronológica), antes dos candles do bot usados no aquecimento.
+func (s *StrategyUseCase) SeedTimeframe(interval string, candles []entity.Candle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w, ok := s.timeframes[interval]
+	if !ok {
+		return
+	}
+	for _, c := range candles {
+		w.add(c)
+	}
+}
+
+// configureTimeframes cria as janelas dos intervalos declarados pela estratégia (já
+// conferidos por ValidateTimeframes). Janelas novas são montadas a partir dos candles do bot
+// já recebidos; a janela do bot é ampliada para cobrir ao menos um candle de cada intervalo maior.
+func (s *StrategyUseCase) configureTimeframes() {
+	mtf, ok := s.Strategy.(MultiTimeframeStrategy)
+	if !ok {
+		s.timeframes = nil
+		return
+	}
+
+	declared := mtf.Timeframes(s.Params)
+	if maps.Equal(declared, s.timeframeSizes()) {
+		return
+	}
+
+	timeframes := make(map[string]*timeframeWindow, len(declared))
+	for interval, size := range declared {
+		if current, ok := s.timeframes[interval]; ok {
+			current.size = size
+			timeframes[interval] = current
+			continue
+		}
+
+		aggregator, err := marketdata.NewAggregator(s.Bot.Interval, interval)
+		if err != nil {
+			logger.Error("Intervalo adicional da estratégia inválido", err, "bot_id", s.Bot.ID.String(), "interval", interval)
+			continue
+		}
+		w := &timeframeWindow{aggregator: aggregator, size: size}
+		for i := 0; i < s.Candles.Len(); i++ {
+			if candle, ok := aggregator.Add(s.Candles.At(i)); ok {
+				w.add(candle)
+			}
+		}
+		timeframes[interval] = w
+
+		base, _ := utils.IntervalDuration(s.Bot.Interval)
+		period, _ := utils.IntervalDuration(interval)
+		if perCandle := int(period / base); s.WindowSize < perCandle {
+			s.WindowSize = perCandle
+		}
+	}
+	s.timeframes = timeframes
+}
+
+// timeframesReady indica se todas as janelas adicionais têm os candles necessários.
+func (s *StrategyUseCase) timeframesReady() bool {
+	for interval, w := range s.timeframes {
+		if len(s.TimeframeCandles(interval)) < w.size {
+			return false
+		}
+	}
+	return true
+}
+
+// updateTimeframes agrega o candle do bot nas janelas dos intervalos maiores.
+func (s *StrategyUseCase) updateTimeframes(candle entity.Candle) {
+	for _, w := range s.timeframes {
+		if aggregated, ok := w.aggregator.Add(candle); ok {
+			w.add(aggregated)
+		}
+	}
+}
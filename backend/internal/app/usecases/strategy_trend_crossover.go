@@ -0,0 +1,88 @@
+// internal/app/usecases/strategy_trend_crossover.go
+
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+)
+
+const (
+	trendCrossoverStrategyName    = "EvaluateTrendCrossover"
+	trendCrossoverStrategyVersion = "1.1.0"
+)
+
+// trendCrossoverStrategy aplica o crossover no intervalo do bot, mas só compra quando a EMA
+// do intervalo maior (trend_interval) está subindo. As saídas seguem o crossover.
+type trendCrossoverStrategy struct{}
+
+func init() {
+	RegisterStrategy(trendCrossoverStrategy{})
+}
+
+func (trendCrossoverStrategy) Name() string    { return trendCrossoverStrategyName }
+func (trendCrossoverStrategy) Version() string { return trendCrossoverStrategyVersion }
+
+func (trendCrossoverStrategy) DefaultParams() map[string]any {
+	params := crossoverStrategy{}.DefaultParams()
+	params["trend_interval"] = "1h"
+	params["trend_ema"] = 21
+	params["trend_slope_min"] = 0.0 // inclinação mínima da EMA, em % por candle
+	return params
+}
+
+func (trendCrossoverStrategy) WarmupCandles(params map[string]any) int {
+	return crossoverStrategy{}.WarmupCandles(params)
+}
+
+// Timeframes pede 3x o período da EMA de tendência: a EMA parte do primeiro fechamento da
+// janela e precisa de alguns períodos para convergir.
+func (trendCrossoverStrategy) Timeframes(params map[string]any) map[string]int {
+	return map[string]int{
+		getStringParam(params, "trend_interval", "1h"): 3 * getIntParam(params, "trend_ema", 21),
+	}
+}
+
+func (trendCrossoverStrategy) Evaluate(s *StrategyUseCase, timestamp int64) string {
+	return s.EvaluateTrendCrossover(timestamp)
+}
+
+func (s *StrategyUseCase) EvaluateTrendCrossover(timestamp int64) string {
+	trendInterval := getStringParam(s.Params, "trend_interval", "1h")
+	trendPeriod := getIntParam(s.Params, "trend_ema", 21)
+	slopeMin := getFloatParam(s.Params, "trend_slope_min", 0.0)
+
+	trendFilter := func(indicatorsMap map[string]float64) bool {
+		candles := s.TimeframeCandles(trendInterval)
+		if len(candles) < trendPeriod+1 {
+			return false
+		}
+		closes := make([]float64, len(candles))
+		for i, c := range candles {
+			closes[i] = c.Close
+		}
+		ema := indicators.EMASeries(closes, trendPeriod)
+		emaNow, emaPrev := ema[len(ema)-1], ema[len(ema)-2]
+		slope := 0.0
+		if emaPrev != 0 {
+			slope = (emaNow - emaPrev) / emaPrev * 100
+		}
+
+		indicatorsMap[fmt.Sprintf("trend_ema%d", trendPeriod)] = emaNow
+		indicatorsMap["trend_slope"] = slope
+		if slope <= slopeMin {
+			logger.Debug("🚫 Entrada bloqueada pela tendência do intervalo maior",
+				"symbol", s.Bot.Symbol,
+				"trend_interval", trendInterval,
+				"trend_slope", slope,
+				"min_required", slopeMin,
+			)
+			return false
+		}
+		return true
+	}
+
+	return s.evaluateCrossover(timestamp, trendCrossoverStrategyName, trendCrossoverStrategyVersion, trendFilter)
+}
@@ -3,75 +3,282 @@
 package usecases
 
 import (
+	"sync"
 	"time"
 
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
 	service "github.com/jeancarlosdanese/crypto-bot/internal/services"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
 )
 
 type StrategyUseCase struct {
 	Account             entity.Account                    // Conta do usuário
 	Bot                 entity.Bot                        // Bot associado à conta
 	Exchange            service.ExchangeService           // Serviço de exchange para obter dados de mercado
+	Strategy            Strategy                          // Estratégia resolvida a partir de Bot.StrategyName
+	Params              map[string]any                    // Parâmetros efetivos (padrões da estratégia + bot_configs)
 	DecisionLogRepo     repository.DecisionLogRepository  // Repositório para registrar decisões
 	ExecutionLogRepo    repository.ExecutionLogRepository // Repositório para registrar execuções
 	PositionRepo        repository.PositionRepository     // Repositório para gerenciar posições abertas
+	OrderManager        *OrderManager                     // Envio de ordens (bots autônomos); nil apenas sinaliza
+	RiskGuard           *RiskGuard                        // Limites de risco da conta avaliados antes das entradas
+	PendingOrder        *entity.Order                     // Ordem enviada aguardando execução
+	OnExecution         func(entity.ExecutionLog)         // Chamado a cada posição encerrada (ex: backtests); opcional
 	WindowSize          int                               // Tamanho da janela de candles
-	CandlesWindow       []entity.Candle                   // Janela de candles para análise
+	Candles             *marketdata.CandleSeries          // Janela de candles para análise (buffer circular de WindowSize)
 	PositionQuantity    float64                           // Quantidade de posição atual (0 significa que não há posição)
 	LastEntryPrice      float64                           // Último preço de entrada
 	LastEntryTimestamp  int64                             // Último timestamp de entrada
+	ExitState           exits.State                       // Acompanhamento da posição pela política de saída
 	LastDecision        string                            // Última decisão tomada (BUY, SELL ou HOLD)
 	TotalCandles        int                               // Contador global de candles processados
 	LastCalibrationGlob int                               // Valor global de TotalCandles no momento da calibração
+
+	paused     bool                         // Bot pausado: candles continuam chegando, mas não há avaliação
+	exitReason exits.Reason                 // Motivo da saída em andamento (gravado na execução)
+	closeReq   exits.Reason                 // Encerramento forçado pendente, repetido até a saída executar
+	timeframes map[string]*timeframeWindow  // Janelas dos intervalos maiores declarados pela estratégia
+	indicators map[string]*rollingIndicator // Indicadores incrementais consultados pela estratégia
+	mu         sync.Mutex                   // Protege a troca de estratégia/parâmetros durante a avaliação
 }
 
 // NewStrategyUseCase cria uma nova instância do StrategyUseCase com o tamanho de janela desejado.
 func NewStrategyUseCase(
 	account entity.Account,
 	bot entity.Bot,
+	strategy Strategy,
 	exchange service.ExchangeService,
 	decisionRepo repository.DecisionLogRepository,
 	executionRepo repository.ExecutionLogRepository,
 	positionRepo repository.PositionRepository,
 	windowSize int,
 ) *StrategyUseCase {
-	return &StrategyUseCase{
+	s := &StrategyUseCase{
 		Account:          account,
 		Bot:              bot,
+		Strategy:         strategy,
 		Exchange:         exchange,
 		DecisionLogRepo:  decisionRepo,
 		ExecutionLogRepo: executionRepo,
 		PositionRepo:     positionRepo,
 		WindowSize:       windowSize,
-		CandlesWindow:    make([]entity.Candle, 0, windowSize),
+		Candles:          marketdata.NewCandleSeries(windowSize),
 		LastDecision:     "HOLD",
 	}
+	s.SetParams(nil)
+	return s
+}
+
+// SetParams aplica a configuração do bot sobre os parâmetros padrão da estratégia.
+// Se os novos parâmetros exigirem mais aquecimento, a janela de candles é ampliada.
+// Os indicadores incrementais são recriados na próxima consulta. Parâmetros inválidos,
+// inclusive intervalos adicionais que não podem ser montados a partir de Bot.Interval,
+// são rejeitados e mantêm a configuração anterior.
+func (s *StrategyUseCase) SetParams(overrides map[string]any) error {
+	if err := ValidateParams(s.Strategy, overrides); err != nil {
+		return err
+	}
+	if err := ValidateTimeframes(s.Strategy, s.Bot.Interval, overrides); err != nil {
+		return err
+	}
+
+	s.indicators = nil
+	if s.Strategy == nil {
+		s.Params = MergeParams(nil, overrides)
+		s.timeframes = nil
+		return nil
+	}
+
+	s.Params = MergeParams(s.Strategy.DefaultParams(), overrides)
+	if warmup := s.Strategy.WarmupCandles(s.Params); s.WindowSize < warmup {
+		s.WindowSize = warmup
+	}
+	s.configureTimeframes()
+	s.Candles.Resize(s.WindowSize)
+	return nil
+}
+
+// Evaluate delega a avaliação do candle fechado para a estratégia configurada no bot.
+// Enquanto as janelas (inclusive as de intervalos maiores) não tiverem candles suficientes
+// para o aquecimento, ou com o bot pausado, retorna HOLD. Com uma ordem pendente, apenas a
+// política de saída acompanha o candle.
+func (s *StrategyUseCase) Evaluate(timestamp int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.readyToEvaluate(timestamp) {
+		return "HOLD"
+	}
+
+	// 🧯 Encerramento forçado pendente tem prioridade sobre a estratégia
+	if decision, ok := s.retryRequestedClose(); ok {
+		return decision
+	}
+	// 🛑 Regras de saída (stop, alvo, trailing, tempo) valem para qualquer estratégia
+	if decision, ok := s.evaluateExitPolicy(timestamp); ok {
+		return decision
+	}
+	// 🧾 Com ordem pendente a estratégia aguarda a confirmação da execução
+	if s.PendingOrder != nil {
+		return "HOLD"
+	}
+	return s.Strategy.Evaluate(s, timestamp)
+}
+
+// EvaluateExits aplica ao último candle apenas a política de saída, sem novas entradas.
+// Usado nos candles recuperados após reconexões: stops e alvos atingidos enquanto o stream
+// estava fora são respeitados, mas sinais de entrada atrasados são descartados.
+func (s *StrategyUseCase) EvaluateExits(timestamp int64) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.readyToEvaluate(timestamp) {
+		return "HOLD"
+	}
+	if decision, ok := s.retryRequestedClose(); ok {
+		return decision
+	}
+	if decision, ok := s.evaluateExitPolicy(timestamp); ok {
+		return decision
+	}
+	return "HOLD"
 }
 
-// UpdateCandle atualiza a janela de candles com o novo candle recebido.
+// readyToEvaluate informa se a estratégia pode ser avaliada: janelas aquecidas e bot não
+// pausado. A ordem pendente, se houver, é sincronizada aqui com a exchange.
+func (s *StrategyUseCase) readyToEvaluate(timestamp int64) bool {
+	if s.paused || s.Strategy == nil || s.Candles.Len() < s.Strategy.WarmupCandles(s.Params) || !s.timeframesReady() {
+		return false
+	}
+	if s.PendingOrder != nil {
+		s.syncPendingOrder(timestamp)
+	}
+	return true
+}
+
+// ApplyConfig troca, com o bot em execução, a estratégia e os parâmetros configurados.
+// A troca acontece entre duas avaliações, nunca durante uma. Com parâmetros inválidos
+// nada é alterado e o erro é retornado.
+func (s *StrategyUseCase) ApplyConfig(bot entity.Bot, strategy Strategy, overrides map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := ValidateParams(strategy, overrides); err != nil {
+		return err
+	}
+	if err := ValidateTimeframes(strategy, bot.Interval, overrides); err != nil {
+		return err
+	}
+	s.Bot = bot
+	s.Strategy = strategy
+	return s.SetParams(overrides)
+}
+
+// SetExchange troca o serviço de exchange de um bot em execução (ex: novas credenciais).
+func (s *StrategyUseCase) SetExchange(exchange service.ExchangeService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Exchange = exchange
+}
+
+// CurrentExchange retorna o serviço de exchange em uso pelo bot.
+func (s *StrategyUseCase) CurrentExchange() service.ExchangeService {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Exchange
+}
+
+// SetAccount atualiza os dados da conta de um bot em execução.
+func (s *StrategyUseCase) SetAccount(account entity.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Account = account
+}
+
+// SetPaused pausa ou retoma a avaliação da estratégia sem derrubar o stream.
+func (s *StrategyUseCase) SetPaused(paused bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused = paused
+}
+
+// IsPaused informa se a avaliação da estratégia está pausada.
+func (s *StrategyUseCase) IsPaused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused
+}
+
+// CurrentBot retorna os dados do bot em uso, que podem mudar a quente via ApplyConfig.
+func (s *StrategyUseCase) CurrentBot() entity.Bot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Bot
+}
+
+// StrategyName retorna o nome da estratégia configurada, ou vazio se não houver.
+func (s *StrategyUseCase) StrategyName() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Strategy == nil {
+		return ""
+	}
+	return s.Strategy.Name()
+}
+
+// CurrentWindowSize retorna o tamanho atual da janela de candles.
+func (s *StrategyUseCase) CurrentWindowSize() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.WindowSize
+}
+
+// CurrentParams retorna uma cópia dos parâmetros efetivos da estratégia.
+func (s *StrategyUseCase) CurrentParams() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return MergeParams(nil, s.Params)
+}
+
+// UpdateCandle inclui o novo candle na janela (descartando o mais antigo) e atualiza os
+// indicadores incrementais, em O(1).
 func (s *StrategyUseCase) UpdateCandle(candle entity.Candle) {
-	s.CandlesWindow = append(s.CandlesWindow, candle)
+	s.mu.Lock()
+	s.Candles.Push(candle)
 	s.TotalCandles++
-	if len(s.CandlesWindow) > cap(s.CandlesWindow) {
-		s.CandlesWindow = s.CandlesWindow[1:]
+	s.updateIndicators(candle)
+	s.updateTimeframes(candle)
+	symbol, exchange := s.Bot.Symbol, s.Exchange
+	s.mu.Unlock()
+
+	// 📄 Exchanges simuladas acompanham os candles para executar ordens limitadas
+	if listener, ok := exchange.(service.CandleListener); ok {
+		listener.OnCandle(utils.FormatForBinance(symbol), candle)
 	}
 }
 
-// closingPrices extrai os preços de fechamento dos candles na janela atual.
+// CandlesSnapshot retorna uma cópia dos candles da janela, segura para uso fora do stream
+// do bot (ex: handlers HTTP e WebSocket).
+func (s *StrategyUseCase) CandlesSnapshot() []entity.Candle {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Candles.Slice()
+}
+
+// ClosingPrices extrai os preços de fechamento dos candles na janela atual. Uso restrito às
+// estratégias, dentro de Evaluate; fora do stream do bot use CandlesSnapshot.
 func (s *StrategyUseCase) ClosingPrices() []float64 {
-	prices := make([]float64, len(s.CandlesWindow))
-	for i, c := range s.CandlesWindow {
-		prices[i] = c.Close
-	}
-	return prices
+	return s.Candles.Closes()
 }
 
 func (s *StrategyUseCase) saveDecisionLog(strategy, version, decision string, timestamp int64, indicators map[string]float64, params, ctx map[string]any) {
 	if s.DecisionLogRepo == nil {
 		return
 	}
+	indicators, ctx = s.withPatterns(indicators, ctx)
 	_ = s.DecisionLogRepo.Save(entity.DecisionLog{
 		BotID:      s.Bot.ID,
 		Symbol:     s.Bot.Symbol,
@@ -0,0 +1,199 @@
+// internal/backtest/backtest.go
+
+// Package backtest reproduz candles históricos pela mesma StrategyUseCase usada pelos bots,
+// executando as ordens em uma exchange simulada (paper) com taxa e slippage configuráveis.
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/paper"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// DefaultWindowSize é a janela de candles usada pelos bots em execução.
+const DefaultWindowSize = 240
+
+// Config define o que será testado e o modelo de custos da simulação.
+type Config struct {
+	Symbol         string             // par no formato BASE/QUOTE (ex: BTC/USDT)
+	Interval       string             // intervalo dos candles (ex: 1h)
+	Strategy       string             // nome da estratégia registrada (ex: EvaluateCrossover)
+	Params         map[string]any     // sobrescreve os parâmetros padrão da estratégia
+	InitialBalance float64            // saldo inicial em moeda de cotação
+	Fee            float64            // taxa por execução (fração: 0.001 = 0,1%)
+	Slippage       float64            // slippage das ordens a mercado (fração)
+	WindowSize     int                // janela de candles da estratégia (0 = DefaultWindowSize)
+	Warmup         int                // candles iniciais usados apenas para aquecer os indicadores
+	SymbolInfo     *entity.SymbolInfo // filtros do símbolo (opcional)
+}
+
+// Trade é uma posição encerrada durante o backtest. Valores em moeda de cotação.
+type Trade struct {
+	EntryTime  int64   `json:"entry_time"` // ms
+	ExitTime   int64   `json:"exit_time"`  // ms
+	EntryPrice float64 `json:"entry_price"`
+	ExitPrice  float64 `json:"exit_price"`
+	Quantity   float64 `json:"quantity"`
+	Fees       float64 `json:"fees"`
+	PnL        float64 `json:"pnl"`        // resultado líquido (taxas e slippage incluídos)
+	ReturnPct  float64 `json:"return_pct"` // PnL sobre o custo de entrada
+	Bars       int     `json:"bars"`
+	ExitReason string  `json:"exit_reason"`
+}
+
+// EquityPoint é o patrimônio (saldo + posição a preço de fechamento) ao fim de um candle.
+type EquityPoint struct {
+	Time   int64   `json:"time"` // ms
+	Equity float64 `json:"equity"`
+}
+
+// Result reúne trades, curva de patrimônio e métricas do backtest.
+type Result struct {
+	Symbol         string         `json:"symbol"`
+	Interval       string         `json:"interval"`
+	Strategy       string         `json:"strategy"`
+	Version        string         `json:"version"`
+	Params         map[string]any `json:"params"`
+	Start          int64          `json:"start"` // ms
+	End            int64          `json:"end"`   // ms
+	Candles        int            `json:"candles"`
+	InitialBalance float64        `json:"initial_balance"`
+	Fee            float64        `json:"fee"`
+	Slippage       float64        `json:"slippage"`
+	Metrics        Metrics        `json:"metrics"`
+	Trades         []Trade        `json:"trades"`
+	OpenPosition   *Trade         `json:"open_position,omitempty"` // posição ainda aberta no último candle
+	Equity         []EquityPoint  `json:"equity"`
+}
+
+// Run executa o backtest sobre os candles fechados informados, em ordem cronológica.
+// Os primeiros cfg.Warmup candles apenas preenchem a janela da estratégia: não são avaliados
+// nem entram na curva de patrimônio e nas métricas.
+func Run(cfg Config, candles []entity.Candle) (*Result, error) {
+	if cfg.Warmup < 0 || cfg.Warmup >= len(candles) {
+		return nil, errors.New("nenhum candle para o backtest")
+	}
+	base, quote, ok := strings.Cut(cfg.Symbol, "/")
+	if !ok || base == "" || quote == "" {
+		return nil, fmt.Errorf("símbolo inválido (use BASE/QUOTE): %s", cfg.Symbol)
+	}
+	barDuration, ok := utils.IntervalDuration(cfg.Interval)
+	if !ok {
+		return nil, fmt.Errorf("intervalo inválido: %s", cfg.Interval)
+	}
+	if cfg.InitialBalance <= 0 {
+		return nil, errors.New("o saldo inicial deve ser positivo")
+	}
+	strategy, err := usecases.GetStrategy(cfg.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = DefaultWindowSize
+	}
+
+	market := &replayMarket{base: base, quote: quote, info: cfg.SymbolInfo}
+	exchange, err := paper.NewPaperExchange(uuid.New(), market, paper.Config{
+		TakerFee:        cfg.Fee,
+		MakerFee:        cfg.Fee,
+		Slippage:        cfg.Slippage,
+		InitialBalances: map[string]float64{quote: cfg.InitialBalance},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	account := entity.Account{ID: uuid.New(), Name: "backtest"}
+	bot := entity.Bot{
+		ID:           uuid.New(),
+		AccountID:    account.ID,
+		Symbol:       cfg.Symbol,
+		Interval:     cfg.Interval,
+		StrategyName: strategy.Name(),
+		Autonomous:   true,
+		Active:       true,
+	}
+
+	ledger := newFillLedger()
+	uc := usecases.NewStrategyUseCase(account, bot, strategy, exchange, nil, nil, nil, cfg.WindowSize)
+	if err := uc.SetParams(cfg.Params); err != nil {
+		return nil, err
+	}
+	uc.OrderManager = usecases.NewOrderManager(ledger)
+
+	result := &Result{
+		Symbol:         cfg.Symbol,
+		Interval:       cfg.Interval,
+		Strategy:       strategy.Name(),
+		Version:        strategy.Version(),
+		Params:         uc.CurrentParams(),
+		Start:          closeTime(candles[cfg.Warmup]),
+		End:            closeTime(candles[len(candles)-1]),
+		Candles:        len(candles) - cfg.Warmup,
+		InitialBalance: cfg.InitialBalance,
+		Fee:            cfg.Fee,
+		Slippage:       cfg.Slippage,
+		Trades:         []Trade{},
+		Equity:         make([]EquityPoint, 0, len(candles)-cfg.Warmup),
+	}
+
+	uc.OnExecution = func(exec entity.ExecutionLog) {
+		result.Trades = append(result.Trades, ledger.closeTrade(exec, barDuration))
+	}
+
+	barsInPosition := 0
+	for i, candle := range candles {
+		timestamp := closeTime(candle)
+		if candle.CloseTime == 0 {
+			// Candles sem abertura/fechamento em ms (necessários à agregação de intervalos maiores)
+			candle.CloseTime = timestamp + 999
+			candle.OpenTime = candle.CloseTime + 1 - barDuration.Milliseconds()
+		}
+		uc.UpdateCandle(candle)
+		if i < cfg.Warmup {
+			continue
+		}
+		uc.Evaluate(timestamp)
+
+		if uc.PositionQuantity > 0 {
+			barsInPosition++
+		}
+		result.Equity = append(result.Equity, EquityPoint{
+			Time:   timestamp,
+			Equity: equity(exchange, base, quote, candle.Close),
+		})
+	}
+
+	if uc.PositionQuantity > 0 {
+		last := candles[len(candles)-1]
+		open := ledger.openTrade(uc.LastEntryTimestamp, uc.LastEntryPrice, uc.PositionQuantity, last, barDuration)
+		result.OpenPosition = &open
+	}
+
+	result.Metrics = computeMetrics(result, barsInPosition, barDuration)
+	return result, nil
+}
+
+// equity soma o saldo em moeda de cotação e a posição no ativo base ao preço informado.
+func equity(exchange *paper.PaperExchange, base, quote string, price float64) float64 {
+	quoteBalance, _ := exchange.GetFreeBalance(quote)
+	baseBalance, _ := exchange.GetFreeBalance(base)
+	return quoteBalance + baseBalance*price
+}
+
+// closeTime retorna o fechamento do candle em milissegundos (Candle.Time está em segundos).
+func closeTime(c entity.Candle) int64 {
+	return c.Time * 1000
+}
+
+// barsBetween conta os candles entre dois timestamps (ms).
+func barsBetween(from, to int64, barDuration time.Duration) int {
+	return int((to - from) / barDuration.Milliseconds())
+}
@@ -0,0 +1,175 @@
+// internal/backtest/backtest_test.go
+
+package backtest_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/backtest"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sineCandles gera candles de 1h oscilando em torno de 100, com tendência leve.
+func sineCandles(n int) []entity.Candle {
+	const start = int64(1_700_000_000) // s
+	candles := make([]entity.Candle, n)
+	price := 100.0
+	for i := range candles {
+		next := 100 + 15*math.Sin(float64(i)/12) + float64(i)*0.01
+		candles[i] = entity.Candle{
+			Open:   price,
+			High:   math.Max(price, next) + 0.5,
+			Low:    math.Min(price, next) - 0.5,
+			Close:  next,
+			Volume: 10,
+			Time:   start + int64(i+1)*3600 - 1,
+		}
+		price = next
+	}
+	return candles
+}
+
+func TestRunCrossover(t *testing.T) {
+	logger.InitLogger()
+	candles := sineCandles(600)
+
+	result, err := backtest.Run(backtest.Config{
+		Symbol:         "BTC/USDT",
+		Interval:       "1h",
+		Strategy:       "EvaluateCrossover",
+		Params:         map[string]any{"sizing_method": "balance_pct", "sizing_balance_pct": 50.0},
+		InitialBalance: 10000,
+		Fee:            0.001,
+		Slippage:       0.0005,
+		WindowSize:     120,
+	}, candles)
+	require.NoError(t, err)
+
+	m := result.Metrics
+	assert.Len(t, result.Equity, len(candles))
+	assert.NotZero(t, m.Trades, "a oscilação deve gerar trades")
+	assert.Equal(t, m.Trades, m.Wins+m.Losses)
+	assert.Equal(t, len(result.Trades), m.Trades)
+	assert.Greater(t, m.TotalFees, 0.0)
+	assert.InDelta(t, result.InitialBalance+m.NetPnL, m.FinalEquity, 1e-6)
+	assert.GreaterOrEqual(t, m.MaxDrawdownPct, 0.0)
+	assert.True(t, m.ExposurePct > 0 && m.ExposurePct <= 100)
+
+	// Sem posição aberta, o resultado líquido é a soma dos trades
+	if result.OpenPosition == nil {
+		sum := 0.0
+		for _, trade := range result.Trades {
+			sum += trade.PnL
+		}
+		assert.InDelta(t, m.NetPnL, sum, 1e-6)
+	}
+	assert.Contains(t, result.Summary(), "EvaluateCrossover")
+}
+
+func TestRunValidatesConfig(t *testing.T) {
+	candles := sineCandles(10)
+	cfg := backtest.Config{Symbol: "BTCUSDT", Interval: "1h", Strategy: "EvaluateCrossover", InitialBalance: 1000}
+
+	_, err := backtest.Run(cfg, candles)
+	assert.Error(t, err, "símbolo sem BASE/QUOTE")
+
+	cfg.Symbol = "BTC/USDT"
+	_, err = backtest.Run(cfg, nil)
+	assert.Error(t, err, "sem candles")
+
+	cfg.Strategy = "Inexistente"
+	_, err = backtest.Run(cfg, candles)
+	assert.Error(t, err)
+}
+
+func TestLoadCSV(t *testing.T) {
+	dir := t.TempDir()
+	jan := filepath.Join(dir, "jan.csv")
+	feb := filepath.Join(dir, "feb.csv")
+
+	// Formato da Binance com cabeçalho e close_time em ms
+	require.NoError(t, os.WriteFile(jan, []byte(
+		"open_time,open,high,low,close,volume,close_time\n"+
+			"1704070800000,101,103,100,102,5,1704074399999,510.5,42,2.5,255.25,0\n"+
+			"1704067200000,100,102,99,101,4,1704070799999\n"), 0o644))
+	// Sem close_time, em microssegundos, repetindo um candle do outro arquivo
+	require.NoError(t, os.WriteFile(feb, []byte(
+		"1704070800000000,101,103,100,102,5\n"+
+			"1704074400000000,102,104,101,103,6\n"), 0o644))
+
+	candles, err := backtest.LoadCSV(time.Hour, jan, feb)
+	require.NoError(t, err)
+	require.Len(t, candles, 3)
+	assert.Equal(t, int64(1704070799), candles[0].Time)
+	assert.Equal(t, int64(1704074399), candles[1].Time)
+	assert.Equal(t, int64(1704077999), candles[2].Time)
+	assert.Equal(t, 103.0, candles[2].Close)
+	assert.Equal(t, 510.5, candles[1].QuoteVolume)
+	assert.Equal(t, int64(42), candles[1].Trades)
+	assert.Equal(t, 2.5, candles[1].TakerBuyVolume)
+	assert.Equal(t, 255.25, candles[1].TakerBuyQuoteVolume)
+	assert.Zero(t, candles[0].QuoteVolume, "sem as colunas opcionais")
+
+	require.NoError(t, os.WriteFile(jan, []byte("1704067200000,abc,1,1,1,1\n"), 0o644))
+	_, err = backtest.LoadCSV(time.Hour, jan)
+	assert.Error(t, err)
+}
+
+// trendCandles gera candles de 15m oscilando em ciclos de 4h (invisíveis nos fechamentos de 4h)
+// em torno de uma tendência de drift por candle.
+func trendCandles(n int, drift float64) []entity.Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	candles := make([]entity.Candle, n)
+	price := 100.0
+	for i := range candles {
+		next := 100 + 4*math.Sin(float64(i)*math.Pi/8) + float64(i)*drift
+		open := start + int64(i)*15*60_000
+		candles[i] = entity.Candle{
+			Open:      price,
+			High:      math.Max(price, next) + 0.2,
+			Low:       math.Min(price, next) - 0.2,
+			Close:     next,
+			Volume:    10,
+			Time:      (open + 15*60_000 - 1) / 1000,
+			OpenTime:  open,
+			CloseTime: open + 15*60_000 - 1,
+		}
+		price = next
+	}
+	return candles
+}
+
+func TestRunTrendCrossoverFiltersByHigherTimeframe(t *testing.T) {
+	logger.InitLogger()
+	cfg := backtest.Config{
+		Symbol:         "BTC/USDT",
+		Interval:       "15m",
+		Strategy:       "EvaluateTrendCrossover",
+		Params:         map[string]any{"trend_interval": "4h", "trend_ema": 5.0},
+		InitialBalance: 10000,
+		Fee:            0.001,
+		WindowSize:     60,
+	}
+
+	up, err := backtest.Run(cfg, trendCandles(800, 0.05))
+	require.NoError(t, err)
+	assert.NotZero(t, up.Metrics.Trades, "tendência de alta no 4h libera as entradas")
+
+	down, err := backtest.Run(cfg, trendCandles(800, -0.05))
+	require.NoError(t, err)
+	assert.Zero(t, down.Metrics.Trades, "tendência de baixa no 4h bloqueia as entradas")
+	assert.Nil(t, down.OpenPosition)
+
+	// Sem o filtro, a mesma oscilação gera entradas
+	cfg.Strategy = "EvaluateCrossover"
+	unfiltered, err := backtest.Run(cfg, trendCandles(800, -0.05))
+	require.NoError(t, err)
+	assert.NotZero(t, unfiltered.Metrics.Trades)
+}
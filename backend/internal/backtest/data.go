@@ -0,0 +1,169 @@
+// internal/backtest/data.go
+
+package backtest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// CandleSource fornece os candles históricos e os filtros do símbolo (ex: BinanceService).
+type CandleSource interface {
+	GetSymbolInfo(symbol string) (*entity.SymbolInfo, error)
+	GetCandlesRange(symbol string, interval string, start, end time.Time) ([]entity.Candle, error)
+}
+
+// FetchCandles baixa os candles fechados do período e os filtros do símbolo (BASE/QUOTE).
+// Intervalos que a Binance não oferece (ex: 10m) são agregados a partir dos candles de 1m.
+func FetchCandles(source CandleSource, symbol, interval string, start, end time.Time) ([]entity.Candle, *entity.SymbolInfo, error) {
+	binanceSymbol := utils.FormatForBinance(symbol)
+	info, err := source.GetSymbolInfo(binanceSymbol)
+	if err != nil {
+		return nil, nil, err
+	}
+	sourceInterval := marketdata.SourceInterval(interval)
+	candles, err := source.GetCandlesRange(binanceSymbol, sourceInterval, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	if candles, err = marketdata.Aggregate(sourceInterval, interval, candles); err != nil {
+		return nil, nil, err
+	}
+	return candles, info, nil
+}
+
+// LoadAggregatedCSV lê arquivos CSV com candles de csvInterval (ver LoadCSV) e os agrega em
+// interval, com os períodos alinhados ao UTC (ex: arquivos de 1m para um backtest de 10m).
+func LoadAggregatedCSV(csvInterval, interval string, paths ...string) ([]entity.Candle, error) {
+	barDuration, ok := utils.IntervalDuration(csvInterval)
+	if !ok {
+		return nil, fmt.Errorf("intervalo dos CSV inválido: %s", csvInterval)
+	}
+	candles, err := LoadCSV(barDuration, paths...)
+	if err != nil {
+		return nil, err
+	}
+	return marketdata.Aggregate(csvInterval, interval, candles)
+}
+
+// LoadCSV lê candles de arquivos CSV no formato de klines da Binance (data.binance.vision):
+//
+//	open_time, open, high, low, close, volume[, close_time, quote_volume, trades,
+//	taker_buy_volume, taker_buy_quote_volume, ...]
+//
+// com ou sem cabeçalho. Timestamps em segundos, milissegundos ou microssegundos são aceitos;
+// sem close_time, o fechamento é calculado pela duração do intervalo. Os candles de todos os
+// arquivos são ordenados e candles repetidos são descartados.
+func LoadCSV(barDuration time.Duration, paths ...string) ([]entity.Candle, error) {
+	var candles []entity.Candle
+	for _, path := range paths {
+		loaded, err := loadCSVFile(path, barDuration)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		candles = append(candles, loaded...)
+	}
+
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].Time < candles[j].Time })
+	unique := candles[:0]
+	for i, c := range candles {
+		if i > 0 && c.Time == candles[i-1].Time {
+			continue
+		}
+		unique = append(unique, c)
+	}
+	return unique, nil
+}
+
+func loadCSVFile(path string, barDuration time.Duration) ([]entity.Candle, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var candles []entity.Candle
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 6 {
+			return nil, fmt.Errorf("linha %d: esperadas ao menos 6 colunas, obtidas %d", line, len(record))
+		}
+
+		openTime, err := strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			if line == 1 {
+				continue // cabeçalho
+			}
+			return nil, fmt.Errorf("linha %d: open_time inválido: %w", line, err)
+		}
+
+		values := make([]float64, 5)
+		for i := range values {
+			if values[i], err = strconv.ParseFloat(strings.TrimSpace(record[i+1]), 64); err != nil {
+				return nil, fmt.Errorf("linha %d: coluna %d inválida: %w", line, i+2, err)
+			}
+		}
+
+		closeMillis := toMillis(openTime) + barDuration.Milliseconds() - 1
+		if len(record) > 6 {
+			if closeTime, err := strconv.ParseInt(strings.TrimSpace(record[6]), 10, 64); err == nil {
+				closeMillis = toMillis(closeTime)
+			}
+		}
+
+		candle := entity.Candle{
+			Open:      values[0],
+			High:      values[1],
+			Low:       values[2],
+			Close:     values[3],
+			Volume:    values[4],
+			Time:      closeMillis / 1000,
+			OpenTime:  toMillis(openTime),
+			CloseTime: closeMillis,
+		}
+		// Colunas opcionais; valores ausentes ou inválidos ficam zerados
+		optional := func(i int) string {
+			if len(record) > i {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		candle.QuoteVolume, _ = strconv.ParseFloat(optional(7), 64)
+		candle.Trades, _ = strconv.ParseInt(optional(8), 10, 64)
+		candle.TakerBuyVolume, _ = strconv.ParseFloat(optional(9), 64)
+		candle.TakerBuyQuoteVolume, _ = strconv.ParseFloat(optional(10), 64)
+		candles = append(candles, candle)
+	}
+	return candles, nil
+}
+
+// toMillis normaliza timestamps em segundos ou microssegundos para milissegundos.
+func toMillis(ts int64) int64 {
+	switch {
+	case ts > 1e14: // microssegundos
+		return ts / 1000
+	case ts < 1e11: // segundos
+		return ts * 1000
+	}
+	return ts
+}
@@ -0,0 +1,118 @@
+// internal/backtest/ledger.go
+
+package backtest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+)
+
+var _ repository.OrderRepository = (*fillLedger)(nil)
+
+// fillLedger faz o papel do repositório de ordens no backtest: guarda as ordens em memória
+// e acumula o custo, a receita e as taxas (em moeda de cotação) da posição em andamento.
+type fillLedger struct {
+	orders map[uuid.UUID]entity.Order
+
+	cost     float64 // pago nas compras
+	proceeds float64 // recebido nas vendas, já descontada a taxa
+	fees     float64
+}
+
+func newFillLedger() *fillLedger {
+	return &fillLedger{orders: make(map[uuid.UUID]entity.Order)}
+}
+
+func (l *fillLedger) Save(order entity.Order) (*entity.Order, error) {
+	if order.ID == uuid.Nil {
+		order.ID = uuid.New()
+	}
+	l.record(order)
+	return &order, nil
+}
+
+func (l *fillLedger) Update(order entity.Order) error {
+	l.record(order)
+	return nil
+}
+
+func (l *fillLedger) GetOpenByBot(botID uuid.UUID) ([]entity.Order, error) {
+	var open []entity.Order
+	for _, o := range l.orders {
+		if o.BotID == botID && !o.Status.IsFinal() {
+			open = append(open, o)
+		}
+	}
+	return open, nil
+}
+
+func (l *fillLedger) GetOpenByAccount(accountID uuid.UUID) ([]entity.Order, error) {
+	var open []entity.Order
+	for _, o := range l.orders {
+		if o.AccountID == accountID && !o.Status.IsFinal() {
+			open = append(open, o)
+		}
+	}
+	return open, nil
+}
+
+// record contabiliza a execução da ordem quando ela é finalizada.
+func (l *fillLedger) record(order entity.Order) {
+	previous, seen := l.orders[order.ID]
+	l.orders[order.ID] = order
+	if order.Status != entity.OrderStatusFilled || (seen && previous.Status == entity.OrderStatusFilled) {
+		return
+	}
+
+	if order.Side == entity.OrderSideBuy {
+		// Taxa cobrada no ativo comprado: convertida pelo preço médio da execução
+		l.cost += order.QuoteQuantity
+		l.fees += order.Fee * order.AvgPrice()
+		return
+	}
+	l.proceeds += order.QuoteQuantity - order.Fee
+	l.fees += order.Fee
+}
+
+// closeTrade fecha o trade em andamento com os dados da execução e zera os acumuladores.
+func (l *fillLedger) closeTrade(exec entity.ExecutionLog, barDuration time.Duration) Trade {
+	trade := Trade{
+		EntryTime:  exec.Entry.Timestamp,
+		ExitTime:   exec.Exit.Timestamp,
+		EntryPrice: exec.Entry.Price,
+		ExitPrice:  exec.Exit.Price,
+		Quantity:   exec.Quantity,
+		Fees:       l.fees,
+		PnL:        l.proceeds - l.cost,
+		Bars:       barsBetween(exec.Entry.Timestamp, exec.Exit.Timestamp, barDuration),
+		ExitReason: exec.ExitReason,
+	}
+	if l.cost > 0 {
+		trade.ReturnPct = trade.PnL / l.cost * 100
+	}
+
+	l.cost, l.proceeds, l.fees = 0, 0, 0
+	return trade
+}
+
+// openTrade avalia a posição ainda aberta ao fechamento do último candle, sem custos de saída.
+func (l *fillLedger) openTrade(entryTime int64, entryPrice, quantity float64, last entity.Candle, barDuration time.Duration) Trade {
+	exitTime := closeTime(last)
+	trade := Trade{
+		EntryTime:  entryTime,
+		ExitTime:   exitTime,
+		EntryPrice: entryPrice,
+		ExitPrice:  last.Close,
+		Quantity:   quantity,
+		Fees:       l.fees,
+		PnL:        l.proceeds + quantity*last.Close - l.cost,
+		Bars:       barsBetween(entryTime, exitTime, barDuration),
+	}
+	if l.cost > 0 {
+		trade.ReturnPct = trade.PnL / l.cost * 100
+	}
+	return trade
+}
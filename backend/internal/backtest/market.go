@@ -0,0 +1,57 @@
+// internal/backtest/market.go
+
+package backtest
+
+import (
+	"errors"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+var _ services.ExchangeService = (*replayMarket)(nil)
+
+var errReplayOnly = errors.New("operação indisponível no backtest")
+
+// replayMarket é o mercado "real" por trás da exchange simulada no backtest. Os preços
+// chegam à exchange simulada pelos candles (CandleListener); aqui ficam apenas os dados
+// estáticos do símbolo.
+type replayMarket struct {
+	base  string
+	quote string
+	info  *entity.SymbolInfo
+}
+
+func (m *replayMarket) GetAccountPositions() error { return nil }
+
+func (m *replayMarket) GetCurrentPrice(string) (float64, error) {
+	return 0, errors.New("sem preço antes do primeiro candle")
+}
+
+func (m *replayMarket) GetHistoricalCandles(string, string, int) ([]entity.Candle, error) {
+	return nil, nil
+}
+
+func (m *replayMarket) GetBaseQuote(string) (string, string, error) {
+	return m.base, m.quote, nil
+}
+
+func (m *replayMarket) GetSymbolInfo(string) (*entity.SymbolInfo, error) {
+	return m.info, nil
+}
+
+func (m *replayMarket) PlaceOrder(entity.OrderRequest) (*entity.Order, error) {
+	return nil, errReplayOnly
+}
+
+func (m *replayMarket) CancelOrder(string, string) (*entity.Order, error) {
+	return nil, errReplayOnly
+}
+
+func (m *replayMarket) GetOrder(string, string) (*entity.Order, error) {
+	return nil, errReplayOnly
+}
+
+func (m *replayMarket) GetFreeBalance(string) (float64, error) {
+	return 0, errReplayOnly
+}
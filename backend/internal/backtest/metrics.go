@@ -0,0 +1,137 @@
+// internal/backtest/metrics.go
+
+package backtest
+
+import (
+	"math"
+	"time"
+)
+
+const hoursPerYear = 365 * 24 // cripto negocia 24/7
+
+// Metrics resume o desempenho do backtest. Valores monetários em moeda de cotação.
+type Metrics struct {
+	FinalEquity    float64 `json:"final_equity"`
+	NetPnL         float64 `json:"net_pnl"` // inclui a posição aberta a preço de mercado
+	NetPnLPct      float64 `json:"net_pnl_pct"`
+	Trades         int     `json:"trades"`
+	Wins           int     `json:"wins"`
+	Losses         int     `json:"losses"`
+	WinRatePct     float64 `json:"win_rate_pct"`
+	GrossProfit    float64 `json:"gross_profit"`
+	GrossLoss      float64 `json:"gross_loss"`    // valor positivo
+	ProfitFactor   float64 `json:"profit_factor"` // 0 quando não há trades perdedores
+	AvgTradePnL    float64 `json:"avg_trade_pnl"`
+	AvgTradeBars   float64 `json:"avg_trade_bars"`
+	TotalFees      float64 `json:"total_fees"`
+	MaxDrawdown    float64 `json:"max_drawdown"`
+	MaxDrawdownPct float64 `json:"max_drawdown_pct"`
+	Sharpe         float64 `json:"sharpe"`  // anualizado, retornos por candle, taxa livre de risco 0
+	Sortino        float64 `json:"sortino"` // anualizado, apenas a volatilidade negativa
+	ExposurePct    float64 `json:"exposure_pct"`
+}
+
+// computeMetrics calcula as métricas a partir dos trades e da curva de patrimônio.
+func computeMetrics(r *Result, barsInPosition int, barDuration time.Duration) Metrics {
+	m := Metrics{FinalEquity: r.InitialBalance}
+	if n := len(r.Equity); n > 0 {
+		m.FinalEquity = r.Equity[n-1].Equity
+	}
+	m.NetPnL = m.FinalEquity - r.InitialBalance
+	m.NetPnLPct = m.NetPnL / r.InitialBalance * 100
+
+	totalBars := 0
+	for _, t := range r.Trades {
+		m.Trades++
+		m.TotalFees += t.Fees
+		totalBars += t.Bars
+		if t.PnL > 0 {
+			m.Wins++
+			m.GrossProfit += t.PnL
+		} else {
+			m.Losses++
+			m.GrossLoss -= t.PnL
+		}
+	}
+	if r.OpenPosition != nil {
+		m.TotalFees += r.OpenPosition.Fees
+	}
+	if m.Trades > 0 {
+		m.WinRatePct = float64(m.Wins) / float64(m.Trades) * 100
+		m.AvgTradePnL = (m.GrossProfit - m.GrossLoss) / float64(m.Trades)
+		m.AvgTradeBars = float64(totalBars) / float64(m.Trades)
+	}
+	if m.GrossLoss > 0 {
+		m.ProfitFactor = m.GrossProfit / m.GrossLoss
+	}
+
+	m.MaxDrawdown, m.MaxDrawdownPct = maxDrawdown(r.Equity)
+	m.Sharpe, m.Sortino = sharpeSortino(r.Equity, barDuration)
+	if r.Candles > 0 {
+		m.ExposurePct = float64(barsInPosition) / float64(r.Candles) * 100
+	}
+	return m
+}
+
+// maxDrawdown retorna a maior queda do patrimônio a partir de um topo anterior.
+func maxDrawdown(equity []EquityPoint) (float64, float64) {
+	peak, maxDD, maxDDPct := 0.0, 0.0, 0.0
+	for _, p := range equity {
+		if p.Equity > peak {
+			peak = p.Equity
+		}
+		if dd := peak - p.Equity; dd > maxDD {
+			maxDD = dd
+		}
+		if peak > 0 {
+			if ddPct := (peak - p.Equity) / peak * 100; ddPct > maxDDPct {
+				maxDDPct = ddPct
+			}
+		}
+	}
+	return maxDD, maxDDPct
+}
+
+// sharpeSortino calcula os índices de Sharpe e Sortino dos retornos por candle,
+// anualizados pela quantidade de candles em um ano.
+func sharpeSortino(equity []EquityPoint, barDuration time.Duration) (float64, float64) {
+	if len(equity) < 3 || barDuration <= 0 {
+		return 0, 0
+	}
+
+	returns := make([]float64, 0, len(equity)-1)
+	for i := 1; i < len(equity); i++ {
+		if prev := equity[i-1].Equity; prev > 0 {
+			returns = append(returns, equity[i].Equity/prev-1)
+		}
+	}
+	if len(returns) < 2 {
+		return 0, 0
+	}
+
+	mean, downside := 0.0, 0.0
+	for _, r := range returns {
+		mean += r
+		if r < 0 {
+			downside += r * r
+		}
+	}
+	mean /= float64(len(returns))
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(returns)-1))
+	downsideDev := math.Sqrt(downside / float64(len(returns)))
+
+	annualize := math.Sqrt(float64(hoursPerYear*time.Hour) / float64(barDuration))
+	sharpe, sortino := 0.0, 0.0
+	if stdDev > 0 {
+		sharpe = mean / stdDev * annualize
+	}
+	if downsideDev > 0 {
+		sortino = mean / downsideDev * annualize
+	}
+	return sharpe, sortino
+}
@@ -0,0 +1,232 @@
+// internal/backtest/optimize.go
+
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// Métodos de busca do otimizador.
+const (
+	SearchGrid   = "grid"
+	SearchRandom = "random"
+)
+
+// Objective é a métrica maximizada pelo otimizador.
+type Objective string
+
+const (
+	ObjectiveNetPnL       Objective = "net_pnl_pct"
+	ObjectiveSharpe       Objective = "sharpe"
+	ObjectiveSortino      Objective = "sortino"
+	ObjectiveProfitFactor Objective = "profit_factor"
+	ObjectiveCalmar       Objective = "calmar" // retorno % dividido pelo drawdown máximo %
+)
+
+// Objectives lista os objetivos aceitos pelo otimizador.
+var Objectives = []Objective{ObjectiveNetPnL, ObjectiveSharpe, ObjectiveSortino, ObjectiveProfitFactor, ObjectiveCalmar}
+
+// OptimizeConfig define a busca de parâmetros.
+type OptimizeConfig struct {
+	Base      Config    // símbolo, estratégia, custos e parâmetros fixos (Base.Params)
+	Space     Space     // parâmetros variados
+	Method    string    // SearchGrid ou SearchRandom
+	Samples   int       // combinações sorteadas na busca aleatória
+	Seed      int64     // semente da busca aleatória (0 = aleatória)
+	Objective Objective // métrica a maximizar (padrão: ObjectiveNetPnL)
+	MinTrades int       // combinações com menos trades são descartadas do ranking
+	Workers   int       // backtests em paralelo (0 = número de CPUs)
+}
+
+// Candidate é um conjunto de parâmetros avaliado pelo otimizador.
+type Candidate struct {
+	Rank    int            `json:"rank"`
+	Params  map[string]any `json:"params"` // valores variados nesta combinação
+	Config  map[string]any `json:"config"` // parâmetros fixos + variados, prontos para bot_configs
+	Score   float64        `json:"score"`
+	Metrics Metrics        `json:"metrics"`
+	Folds   int            `json:"folds,omitempty"` // walk-forward: folds em que foi avaliado fora da amostra
+}
+
+// Optimize avalia as combinações do espaço sobre os candles e retorna o ranking, do melhor
+// para o pior, pelo objetivo configurado.
+func Optimize(cfg OptimizeConfig, candles []entity.Candle) ([]Candidate, error) {
+	sets, err := cfg.paramSets()
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := cfg.evaluate(sets, candles, 0)
+	if err != nil {
+		return nil, err
+	}
+	return rank(candidates), nil
+}
+
+// Validate verifica o método, o objetivo e o espaço de busca.
+func (cfg *OptimizeConfig) Validate() error {
+	if cfg.Objective == "" {
+		cfg.Objective = ObjectiveNetPnL
+	}
+	if !isValidObjective(cfg.Objective) {
+		return fmt.Errorf("objetivo inválido: %s", cfg.Objective)
+	}
+	switch cfg.Method {
+	case "", SearchGrid, SearchRandom:
+	default:
+		return fmt.Errorf("método de busca inválido: %s", cfg.Method)
+	}
+	if _, err := usecases.GetStrategy(cfg.Base.Strategy); err != nil {
+		return err
+	}
+	return cfg.Space.Validate()
+}
+
+// paramSets gera as combinações a avaliar conforme o método de busca.
+func (cfg *OptimizeConfig) paramSets() ([]map[string]any, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		sets []map[string]any
+		err  error
+	)
+	if cfg.Method == SearchRandom {
+		seed := cfg.Seed
+		if seed == 0 {
+			seed = rand.Int63()
+		}
+		sets, err = cfg.Space.Random(cfg.Samples, rand.New(rand.NewSource(seed)))
+	} else {
+		sets, err = cfg.Space.Grid()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return nil, fmt.Errorf("nenhuma combinação respeita as restrições do espaço")
+	}
+	return sets, nil
+}
+
+// evaluate executa um backtest por combinação, em paralelo. Combinações com menos trades
+// que MinTrades são descartadas.
+func (cfg *OptimizeConfig) evaluate(sets []map[string]any, candles []entity.Candle, warmup int) ([]Candidate, error) {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	type outcome struct {
+		candidate Candidate
+		ok        bool
+		err       error
+	}
+	jobs := make(chan int)
+	outcomes := make([]outcome, len(sets))
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(sets)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				candidate, err := cfg.run(sets[i], candles, warmup)
+				outcomes[i] = outcome{
+					candidate: candidate,
+					ok:        err == nil && candidate.Metrics.Trades >= cfg.MinTrades,
+					err:       err,
+				}
+			}
+		}()
+	}
+	for i := range sets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	candidates := make([]Candidate, 0, len(sets))
+	for _, o := range outcomes {
+		if o.err != nil {
+			return nil, o.err
+		}
+		if o.ok {
+			candidates = append(candidates, o.candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// run executa o backtest de uma combinação.
+func (cfg *OptimizeConfig) run(params map[string]any, candles []entity.Candle, warmup int) (Candidate, error) {
+	runCfg := cfg.Base
+	runCfg.Params = usecases.MergeParams(cfg.Base.Params, params)
+	runCfg.Warmup = warmup
+
+	result, err := Run(runCfg, candles)
+	if err != nil {
+		return Candidate{}, err
+	}
+	return Candidate{
+		Params:  params,
+		Config:  runCfg.Params,
+		Score:   score(result.Metrics, cfg.Objective),
+		Metrics: result.Metrics,
+	}, nil
+}
+
+// rank ordena os candidatos pelo score (desempate pelo resultado líquido) e numera o ranking.
+func rank(candidates []Candidate) []Candidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Metrics.NetPnLPct > candidates[j].Metrics.NetPnLPct
+	})
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+	return candidates
+}
+
+// score extrai o objetivo das métricas. Sem drawdown, o Calmar usa o próprio retorno.
+func score(m Metrics, objective Objective) float64 {
+	var value float64
+	switch objective {
+	case ObjectiveSharpe:
+		value = m.Sharpe
+	case ObjectiveSortino:
+		value = m.Sortino
+	case ObjectiveProfitFactor:
+		value = m.ProfitFactor
+	case ObjectiveCalmar:
+		value = m.NetPnLPct
+		if m.MaxDrawdownPct > 0 {
+			value = m.NetPnLPct / m.MaxDrawdownPct
+		}
+	default:
+		value = m.NetPnLPct
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0
+	}
+	return value
+}
+
+func isValidObjective(objective Objective) bool {
+	for _, o := range Objectives {
+		if o == objective {
+			return true
+		}
+	}
+	return false
+}
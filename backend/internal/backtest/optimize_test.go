@@ -0,0 +1,129 @@
+// internal/backtest/optimize_test.go
+
+package backtest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/backtest"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSpace() backtest.Space {
+	return backtest.Space{
+		Params: map[string]backtest.ParamRange{
+			"ma_short":            {Min: 5, Max: 12, Step: 1},
+			"ma_long":             {Values: []any{10.0, 26.0}},
+			"stop_atr_multiplier": {Min: 1, Max: 2, Step: 0.5},
+		},
+		Constraints: []string{"ma_short < ma_long"},
+	}
+}
+
+func TestSpaceGrid(t *testing.T) {
+	sets, err := testSpace().Grid()
+	require.NoError(t, err)
+
+	// ma_long=10: ma_short 5..9 (5 valores); ma_long=26: 5..12 (8 valores); 3 stop_atr_multiplier
+	assert.Len(t, sets, (5+8)*3)
+	for _, set := range sets {
+		assert.IsType(t, 0, set["ma_short"], "intervalos inteiros geram int")
+		assert.Less(t, float64(set["ma_short"].(int)), set["ma_long"].(float64))
+	}
+
+	_, err = backtest.Space{Params: map[string]backtest.ParamRange{"x": {Min: 2, Max: 1, Step: 1}}}.Grid()
+	assert.Error(t, err)
+	_, err = backtest.Space{Params: testSpace().Params, Constraints: []string{"ma_short ! ma_long"}}.Grid()
+	assert.Error(t, err)
+}
+
+func TestSpaceRandom(t *testing.T) {
+	sets, err := testSpace().Random(20, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	assert.Len(t, sets, 20)
+
+	seen := make(map[[3]any]bool)
+	for _, set := range sets {
+		key := [3]any{set["ma_short"], set["ma_long"], set["stop_atr_multiplier"]}
+		assert.False(t, seen[key], "combinações repetidas")
+		seen[key] = true
+	}
+}
+
+func TestOptimizeRanksCandidates(t *testing.T) {
+	logger.InitLogger()
+	cfg := backtest.OptimizeConfig{
+		Base: backtest.Config{
+			Symbol:         "BTC/USDT",
+			Interval:       "1h",
+			Strategy:       "EvaluateCrossover",
+			Params:         map[string]any{"sizing_method": "balance_pct", "sizing_balance_pct": 50.0},
+			InitialBalance: 10000,
+			Fee:            0.001,
+			WindowSize:     120,
+		},
+		Space:     testSpace(),
+		Objective: backtest.ObjectiveSharpe,
+		MinTrades: 1,
+		Workers:   4,
+	}
+
+	ranking, err := backtest.Optimize(cfg, sineCandles(500))
+	require.NoError(t, err)
+	require.NotEmpty(t, ranking)
+
+	for i, c := range ranking {
+		assert.Equal(t, i+1, c.Rank)
+		assert.GreaterOrEqual(t, c.Metrics.Trades, 1)
+		assert.Equal(t, "balance_pct", c.Config["sizing_method"], "parâmetros fixos entram no config")
+		assert.Equal(t, c.Params["ma_short"], c.Config["ma_short"])
+		if i > 0 {
+			assert.GreaterOrEqual(t, ranking[i-1].Score, c.Score)
+		}
+	}
+
+	cfg.Objective = "inexistente"
+	_, err = backtest.Optimize(cfg, sineCandles(500))
+	assert.Error(t, err)
+}
+
+func TestWalkForward(t *testing.T) {
+	logger.InitLogger()
+	cfg := backtest.OptimizeConfig{
+		Base: backtest.Config{
+			Symbol:         "BTC/USDT",
+			Interval:       "1h",
+			Strategy:       "EvaluateCrossover",
+			InitialBalance: 10000,
+			Fee:            0.001,
+			WindowSize:     60,
+		},
+		Space: backtest.Space{Params: map[string]backtest.ParamRange{
+			"ma_short": {Values: []any{5.0, 9.0}},
+			"ma_long":  {Values: []any{20.0, 26.0}},
+		}},
+		Workers: 2,
+	}
+
+	result, err := backtest.WalkForward(cfg, backtest.WalkForwardConfig{InSample: 200, OutOfSample: 100, Top: 2}, sineCandles(600))
+	require.NoError(t, err)
+
+	// Folds: out-of-sample em [200,300), [300,400), [400,500), [500,600)
+	require.Len(t, result.Folds, 4)
+	for i, fold := range result.Folds {
+		assert.Equal(t, i, fold.Index)
+		assert.Less(t, fold.InSampleEnd, fold.OutSampleStart)
+		if i > 0 {
+			assert.Equal(t, result.Folds[i-1].OutSampleEnd+3600_000, fold.OutSampleStart)
+		}
+	}
+	for i := 1; i < len(result.Ranking); i++ {
+		assert.GreaterOrEqual(t, result.Ranking[i-1].Folds, result.Ranking[i].Folds)
+	}
+
+	_, err = backtest.WalkForward(cfg, backtest.WalkForwardConfig{InSample: 50, OutOfSample: 50}, sineCandles(600))
+	assert.Error(t, err, "in-sample menor que o aquecimento")
+}
@@ -0,0 +1,251 @@
+// internal/backtest/space.go
+
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// maxGridSize limita a busca em grade para evitar explosões combinatórias acidentais.
+const maxGridSize = 100_000
+
+// ParamRange define os valores candidatos de um parâmetro: uma lista explícita (Values)
+// ou um intervalo numérico de Min a Max (inclusive) com passo Step.
+type ParamRange struct {
+	Values []any   `json:"values,omitempty"`
+	Min    float64 `json:"min,omitempty"`
+	Max    float64 `json:"max,omitempty"`
+	Step   float64 `json:"step,omitempty"`
+}
+
+// Space é o espaço de busca do otimizador. Constraints descarta combinações inválidas
+// comparando dois parâmetros, ex: "ma_short < ma_long" (operadores <, <=, >, >=).
+//
+//	{
+//	  "params": {
+//	    "ma_short": {"min": 5, "max": 20, "step": 1},
+//	    "ma_long": {"values": [26, 50, 100]},
+//	    "stop_atr_multiplier": {"min": 1, "max": 3, "step": 0.5}
+//	  },
+//	  "constraints": ["ma_short < ma_long"]
+//	}
+type Space struct {
+	Params      map[string]ParamRange `json:"params"`
+	Constraints []string              `json:"constraints,omitempty"`
+}
+
+// constraint é uma comparação entre dois parâmetros numéricos.
+type constraint struct {
+	left, op, right string
+}
+
+// Validate verifica os intervalos e as restrições do espaço.
+func (s Space) Validate() error {
+	if len(s.Params) == 0 {
+		return fmt.Errorf("o espaço de busca não tem parâmetros")
+	}
+	for name, r := range s.Params {
+		if _, err := r.candidates(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	_, err := s.constraints()
+	return err
+}
+
+// Size retorna o número de combinações da grade (antes das restrições).
+func (s Space) Size() int {
+	size := 1
+	for _, r := range s.Params {
+		values, _ := r.candidates()
+		size *= len(values)
+		if size > maxGridSize {
+			return size
+		}
+	}
+	return size
+}
+
+// Grid retorna todas as combinações que respeitam as restrições.
+func (s Space) Grid() ([]map[string]any, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if size := s.Size(); size > maxGridSize {
+		return nil, fmt.Errorf("grade com mais de %d combinações: reduza o espaço ou use a busca aleatória", maxGridSize)
+	}
+	constraints, _ := s.constraints()
+	names, values := s.sortedCandidates()
+
+	var sets []map[string]any
+	indexes := make([]int, len(names))
+	for {
+		set := make(map[string]any, len(names))
+		for i, name := range names {
+			set[name] = values[i][indexes[i]]
+		}
+		if satisfies(set, constraints) {
+			sets = append(sets, set)
+		}
+
+		// Próxima combinação (contador em base mista)
+		i := len(indexes) - 1
+		for ; i >= 0; i-- {
+			indexes[i]++
+			if indexes[i] < len(values[i]) {
+				break
+			}
+			indexes[i] = 0
+		}
+		if i < 0 {
+			return sets, nil
+		}
+	}
+}
+
+// Random sorteia até n combinações distintas que respeitam as restrições.
+func (s Space) Random(n int, rng *rand.Rand) ([]map[string]any, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("o número de amostras deve ser positivo")
+	}
+	constraints, _ := s.constraints()
+	names, values := s.sortedCandidates()
+
+	seen := make(map[string]bool)
+	var sets []map[string]any
+	// Limite de tentativas: restrições ou espaços pequenos podem impedir n combinações distintas
+	for attempts := 0; len(sets) < n && attempts < n*20; attempts++ {
+		set := make(map[string]any, len(names))
+		for i, name := range names {
+			set[name] = values[i][rng.Intn(len(values[i]))]
+		}
+		key := paramsKey(set)
+		if seen[key] || !satisfies(set, constraints) {
+			continue
+		}
+		seen[key] = true
+		sets = append(sets, set)
+	}
+	return sets, nil
+}
+
+// sortedCandidates retorna os nomes dos parâmetros em ordem e seus valores candidatos.
+func (s Space) sortedCandidates() ([]string, [][]any) {
+	names := make([]string, 0, len(s.Params))
+	for name := range s.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([][]any, len(names))
+	for i, name := range names {
+		values[i], _ = s.Params[name].candidates()
+	}
+	return names, values
+}
+
+func (s Space) constraints() ([]constraint, error) {
+	result := make([]constraint, 0, len(s.Constraints))
+	for _, raw := range s.Constraints {
+		fields := strings.Fields(raw)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("restrição inválida (use \"a < b\"): %q", raw)
+		}
+		c := constraint{left: fields[0], op: fields[1], right: fields[2]}
+		switch c.op {
+		case "<", "<=", ">", ">=":
+		default:
+			return nil, fmt.Errorf("operador inválido na restrição %q", raw)
+		}
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// candidates expande o intervalo em valores. Intervalos com limites e passo inteiros
+// geram inteiros, para que parâmetros como períodos fiquem como 9 e não 9.0 no config.
+func (r ParamRange) candidates() ([]any, error) {
+	if len(r.Values) > 0 {
+		return r.Values, nil
+	}
+	if r.Step <= 0 || r.Max < r.Min {
+		return nil, fmt.Errorf("informe values ou min <= max com step positivo")
+	}
+
+	integer := r.Min == math.Trunc(r.Min) && r.Max == math.Trunc(r.Max) && r.Step == math.Trunc(r.Step)
+	steps := int(math.Floor((r.Max-r.Min)/r.Step + 1e-9))
+	if steps+1 > maxGridSize {
+		return nil, fmt.Errorf("intervalo com valores demais")
+	}
+
+	values := make([]any, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		v := r.Min + float64(i)*r.Step
+		if integer {
+			values = append(values, int(v))
+			continue
+		}
+		values = append(values, math.Round(v*1e10)/1e10) // evita 0.30000000000000004
+	}
+	return values, nil
+}
+
+// satisfies verifica as restrições; comparações com valores não numéricos ou parâmetros
+// fora do espaço são ignoradas.
+func satisfies(set map[string]any, constraints []constraint) bool {
+	for _, c := range constraints {
+		left, okLeft := number(set[c.left])
+		right, okRight := number(set[c.right])
+		if !okLeft || !okRight {
+			continue
+		}
+		var ok bool
+		switch c.op {
+		case "<":
+			ok = left < right
+		case "<=":
+			ok = left <= right
+		case ">":
+			ok = left > right
+		case ">=":
+			ok = left >= right
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// number converte valores numéricos do espaço (JSON ou gerados por candidates) para float64.
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// paramsKey identifica um conjunto de parâmetros independente da ordem das chaves.
+func paramsKey(set map[string]any) string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s=%v;", name, set[name])
+	}
+	return b.String()
+}
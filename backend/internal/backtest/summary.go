@@ -0,0 +1,75 @@
+// internal/backtest/summary.go
+
+package backtest
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Summary retorna o resumo do backtest em texto legível.
+func (r *Result) Summary() string {
+	m := r.Metrics
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "📊 Backtest %s v%s — %s %s\n", r.Strategy, r.Version, r.Symbol, r.Interval)
+	fmt.Fprintf(&b, "   Período:        %s → %s (%d candles)\n", formatTime(r.Start), formatTime(r.End), r.Candles)
+	fmt.Fprintf(&b, "   Custos:         taxa %.3f%% | slippage %.3f%%\n", r.Fee*100, r.Slippage*100)
+	fmt.Fprintf(&b, "   Patrimônio:     %.2f → %.2f\n", r.InitialBalance, m.FinalEquity)
+	fmt.Fprintf(&b, "   Resultado:      %+.2f (%+.2f%%)\n", m.NetPnL, m.NetPnLPct)
+	fmt.Fprintf(&b, "   Trades:         %d (%d ganhos, %d perdas) | win rate %.2f%%\n", m.Trades, m.Wins, m.Losses, m.WinRatePct)
+	fmt.Fprintf(&b, "   Profit factor:  %.2f | média por trade %+.2f em %.1f candles\n", m.ProfitFactor, m.AvgTradePnL, m.AvgTradeBars)
+	fmt.Fprintf(&b, "   Max drawdown:   %.2f (%.2f%%)\n", m.MaxDrawdown, m.MaxDrawdownPct)
+	fmt.Fprintf(&b, "   Sharpe/Sortino: %.2f / %.2f\n", m.Sharpe, m.Sortino)
+	fmt.Fprintf(&b, "   Exposição:      %.2f%% | taxas pagas %.2f\n", m.ExposurePct, m.TotalFees)
+	if r.OpenPosition != nil {
+		fmt.Fprintf(&b, "   Posição aberta: %.8f a %.2f (%+.2f)\n", r.OpenPosition.Quantity, r.OpenPosition.EntryPrice, r.OpenPosition.PnL)
+	}
+
+	if reasons := exitReasons(r.Trades); len(reasons) > 0 {
+		fmt.Fprintf(&b, "   Saídas:         %s\n", strings.Join(reasons, ", "))
+	}
+	return b.String()
+}
+
+// exitReasons conta os trades por motivo de saída, na ordem em que aparecem.
+func exitReasons(trades []Trade) []string {
+	counts := make(map[string]int)
+	var order []string
+	for _, t := range trades {
+		reason := t.ExitReason
+		if reason == "" {
+			reason = "?"
+		}
+		if counts[reason] == 0 {
+			order = append(order, reason)
+		}
+		counts[reason]++
+	}
+
+	result := make([]string, len(order))
+	for i, reason := range order {
+		result[i] = fmt.Sprintf("%s %d", reason, counts[reason])
+	}
+	return result
+}
+
+func formatTime(ms int64) string {
+	return time.UnixMilli(ms).UTC().Format("2006-01-02 15:04")
+}
+
+// RankingTable formata as primeiras n combinações do ranking em uma tabela de texto.
+func RankingTable(candidates []Candidate, n int) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tscore\tpnl %\ttrades\twin %\tpf\tmax dd %\tsharpe\tfolds\tparâmetros")
+	for _, c := range candidates[:min(n, len(candidates))] {
+		m := c.Metrics
+		fmt.Fprintf(w, "%d\t%.3f\t%+.2f\t%d\t%.1f\t%.2f\t%.2f\t%.2f\t%d\t%s\n",
+			c.Rank, c.Score, m.NetPnLPct, m.Trades, m.WinRatePct, m.ProfitFactor, m.MaxDrawdownPct, m.Sharpe, c.Folds, paramsKey(c.Params))
+	}
+	w.Flush()
+	return b.String()
+}
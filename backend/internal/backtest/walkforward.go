@@ -0,0 +1,227 @@
+// internal/backtest/walkforward.go
+
+package backtest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// defaultWalkForwardTop é quantas combinações de cada janela in-sample seguem para o out-of-sample.
+const defaultWalkForwardTop = 5
+
+// WalkForwardConfig define as janelas da validação walk-forward, em candles.
+type WalkForwardConfig struct {
+	InSample    int  // candles usados na otimização de cada fold
+	OutOfSample int  // candles seguintes, usados para validar as melhores combinações
+	Anchored    bool // in-sample sempre a partir do primeiro candle (janela crescente)
+	Top         int  // melhores combinações in-sample validadas por fold (0 = 5)
+}
+
+// Fold é uma rodada de otimização in-sample seguida da validação out-of-sample.
+type Fold struct {
+	Index          int       `json:"index"`
+	InSampleStart  int64     `json:"in_sample_start"` // ms
+	InSampleEnd    int64     `json:"in_sample_end"`
+	OutSampleStart int64     `json:"out_of_sample_start"`
+	OutSampleEnd   int64     `json:"out_of_sample_end"`
+	Best           Candidate `json:"best"`              // melhor combinação in-sample
+	OutOfSample    Metrics   `json:"out_of_sample"`     // desempenho da melhor combinação fora da amostra
+	Candidates     int       `json:"candidates"`        // combinações elegíveis in-sample
+	Efficiency     float64   `json:"efficiency"`        // score out-of-sample / score in-sample
+	Skipped        bool      `json:"skipped,omitempty"` // nenhuma combinação elegível in-sample
+	Reason         string    `json:"skip_reason,omitempty"`
+}
+
+// WalkForwardResult reúne os folds e o ranking das combinações pelo desempenho out-of-sample.
+type WalkForwardResult struct {
+	Folds []Fold `json:"folds"`
+	// OutOfSampleReturnPct é o retorno composto da melhor combinação de cada fold, aplicada
+	// na janela out-of-sample seguinte: a estimativa de desempenho "real" da otimização.
+	OutOfSampleReturnPct float64 `json:"out_of_sample_return_pct"`
+	// Ranking ordena as combinações validadas pelo número de folds em que ficaram entre as
+	// melhores in-sample (Folds) e, depois, pelo score médio out-of-sample.
+	Ranking []Candidate `json:"ranking"`
+}
+
+// WalkForward divide os candles em folds (in-sample + out-of-sample), otimiza cada janela
+// in-sample e valida as Top melhores combinações na janela out-of-sample seguinte. As
+// janelas out-of-sample recebem os candles anteriores como aquecimento dos indicadores,
+// sem operar neles.
+func WalkForward(cfg OptimizeConfig, wf WalkForwardConfig, candles []entity.Candle) (*WalkForwardResult, error) {
+	if wf.InSample <= 0 || wf.OutOfSample <= 0 {
+		return nil, fmt.Errorf("as janelas in-sample e out-of-sample devem ser positivas")
+	}
+	if wf.InSample+wf.OutOfSample > len(candles) {
+		return nil, fmt.Errorf("candles insuficientes: %d para janelas de %d + %d", len(candles), wf.InSample, wf.OutOfSample)
+	}
+	if wf.Top <= 0 {
+		wf.Top = defaultWalkForwardTop
+	}
+	sets, err := cfg.paramSets()
+	if err != nil {
+		return nil, err
+	}
+
+	warmup, err := cfg.warmup(sets)
+	if err != nil {
+		return nil, err
+	}
+	if warmup > wf.InSample {
+		return nil, fmt.Errorf("a janela in-sample (%d) deve cobrir o aquecimento da estratégia (%d candles)", wf.InSample, warmup)
+	}
+
+	result := &WalkForwardResult{Folds: []Fold{}, Ranking: []Candidate{}}
+	ranking := make(map[string]*Candidate)
+	var order []string
+	compounded := 1.0
+
+	for index, oosStart := 0, wf.InSample; oosStart+wf.OutOfSample <= len(candles); index, oosStart = index+1, oosStart+wf.OutOfSample {
+		isStart := oosStart - wf.InSample
+		if wf.Anchored {
+			isStart = 0
+		}
+		oosEnd := oosStart + wf.OutOfSample
+
+		fold := Fold{
+			Index:          index,
+			InSampleStart:  closeTime(candles[isStart]),
+			InSampleEnd:    closeTime(candles[oosStart-1]),
+			OutSampleStart: closeTime(candles[oosStart]),
+			OutSampleEnd:   closeTime(candles[oosEnd-1]),
+		}
+
+		inSample, err := cfg.evaluate(sets, candles[isStart:oosStart], 0)
+		if err != nil {
+			return nil, err
+		}
+		inSample = rank(inSample)
+		fold.Candidates = len(inSample)
+		if len(inSample) == 0 {
+			fold.Skipped = true
+			fold.Reason = fmt.Sprintf("nenhuma combinação com ao menos %d trades", cfg.MinTrades)
+			result.Folds = append(result.Folds, fold)
+			continue
+		}
+
+		// Valida as melhores combinações fora da amostra (sem exigir trades mínimos)
+		top := inSample[:min(wf.Top, len(inSample))]
+		validation := cfg
+		validation.MinTrades = 0
+		topSets := make([]map[string]any, len(top))
+		for i, c := range top {
+			topSets[i] = c.Params
+		}
+		outOfSample, err := validation.evaluate(topSets, candles[oosStart-warmup:oosEnd], warmup)
+		if err != nil {
+			return nil, err
+		}
+
+		fold.Best = top[0]
+		fold.OutOfSample = outOfSample[0].Metrics
+		if top[0].Score != 0 {
+			fold.Efficiency = outOfSample[0].Score / top[0].Score
+		}
+		compounded *= 1 + fold.OutOfSample.NetPnLPct/100
+
+		for _, c := range outOfSample {
+			key := paramsKey(c.Params)
+			entry, seen := ranking[key]
+			if !seen {
+				entry = &Candidate{Params: c.Params, Config: c.Config}
+				ranking[key] = entry
+				order = append(order, key)
+			}
+			// Acumula score e métricas para a média ao fim
+			entry.Folds++
+			entry.Score += c.Score
+			entry.Metrics = sumMetrics(entry.Metrics, c.Metrics)
+		}
+		result.Folds = append(result.Folds, fold)
+	}
+
+	for _, key := range order {
+		entry := ranking[key]
+		entry.Score /= float64(entry.Folds)
+		entry.Metrics = averageMetrics(entry.Metrics, entry.Folds)
+		result.Ranking = append(result.Ranking, *entry)
+	}
+	result.Ranking = rankWalkForward(result.Ranking)
+	result.OutOfSampleReturnPct = (compounded - 1) * 100
+	return result, nil
+}
+
+// warmup retorna quantos candles a estratégia precisa antes de avaliar, considerando a
+// maior exigência entre as combinações e a janela configurada.
+func (cfg *OptimizeConfig) warmup(sets []map[string]any) (int, error) {
+	strategy, err := usecases.GetStrategy(cfg.Base.Strategy)
+	if err != nil {
+		return 0, err
+	}
+	warmup := cfg.Base.WindowSize
+	if warmup <= 0 {
+		warmup = DefaultWindowSize
+	}
+	for _, set := range sets {
+		params := usecases.MergeParams(strategy.DefaultParams(), usecases.MergeParams(cfg.Base.Params, set))
+		warmup = max(warmup, strategy.WarmupCandles(params))
+	}
+	return warmup, nil
+}
+
+// rankWalkForward prioriza as combinações validadas em mais folds e, entre elas, o score médio.
+func rankWalkForward(candidates []Candidate) []Candidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Folds != candidates[j].Folds {
+			return candidates[i].Folds > candidates[j].Folds
+		}
+		return candidates[i].Score > candidates[j].Score
+	})
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+	return candidates
+}
+
+// sumMetrics soma as métricas aditivas de dois backtests (usado para médias entre folds).
+func sumMetrics(a, b Metrics) Metrics {
+	return Metrics{
+		FinalEquity:    a.FinalEquity + b.FinalEquity,
+		NetPnL:         a.NetPnL + b.NetPnL,
+		NetPnLPct:      a.NetPnLPct + b.NetPnLPct,
+		Trades:         a.Trades + b.Trades,
+		Wins:           a.Wins + b.Wins,
+		Losses:         a.Losses + b.Losses,
+		WinRatePct:     a.WinRatePct + b.WinRatePct,
+		GrossProfit:    a.GrossProfit + b.GrossProfit,
+		GrossLoss:      a.GrossLoss + b.GrossLoss,
+		ProfitFactor:   a.ProfitFactor + b.ProfitFactor,
+		AvgTradePnL:    a.AvgTradePnL + b.AvgTradePnL,
+		AvgTradeBars:   a.AvgTradeBars + b.AvgTradeBars,
+		TotalFees:      a.TotalFees + b.TotalFees,
+		MaxDrawdown:    max(a.MaxDrawdown, b.MaxDrawdown),
+		MaxDrawdownPct: max(a.MaxDrawdownPct, b.MaxDrawdownPct),
+		Sharpe:         a.Sharpe + b.Sharpe,
+		Sortino:        a.Sortino + b.Sortino,
+		ExposurePct:    a.ExposurePct + b.ExposurePct,
+	}
+}
+
+// averageMetrics divide as métricas somadas pelo número de folds. Contagens de trades e
+// valores totais (PnL, taxas) permanecem somados; drawdowns mantêm o pior fold.
+func averageMetrics(m Metrics, folds int) Metrics {
+	n := float64(folds)
+	m.FinalEquity /= n
+	m.NetPnLPct /= n
+	m.WinRatePct /= n
+	m.ProfitFactor /= n
+	m.AvgTradePnL /= n
+	m.AvgTradeBars /= n
+	m.Sharpe /= n
+	m.Sortino /= n
+	m.ExposurePct /= n
+	return m
+}
@@ -25,28 +25,34 @@ type AccountUpdateDTO struct {
 	BinanceAPISecret *string `json:"binance_api_secret"`
 }
 
-// AccountResponseDTO define a estrutura de resposta para a conta
+// AccountResponseDTO define a estrutura de resposta para a conta.
+// O segredo da Binance nunca é retornado: apenas se está configurado e uma dica mascarada.
 type AccountResponseDTO struct {
-	ID               string  `json:"id"`
-	Name             string  `json:"name"`
-	Email            string  `json:"email"`
-	WhatsApp         string  `json:"whatsapp"`
-	APIKey           *string `json:"api_key,omitempty"`
-	BinanceAPIKey    *string `json:"binance_api_key,omitempty"`
-	BinanceAPISecret *string `json:"binance_api_secret,omitempty"`
+	ID                         string  `json:"id"`
+	Name                       string  `json:"name"`
+	Email                      string  `json:"email"`
+	WhatsApp                   string  `json:"whatsapp"`
+	APIKey                     *string `json:"api_key,omitempty"`
+	BinanceAPIKey              *string `json:"binance_api_key,omitempty"`
+	BinanceAPISecretConfigured bool    `json:"binance_api_secret_configured"`
+	BinanceAPISecretHint       string  `json:"binance_api_secret_hint,omitempty"`
 }
 
 // Construtor para resposta formatada
 func NewAccountResponseDTO(account *entity.Account) AccountResponseDTO {
-	return AccountResponseDTO{
-		ID:               account.ID.String(),
-		Name:             account.Name,
-		Email:            account.Email,
-		WhatsApp:         utils.FormatWhatsApp(account.WhatsApp),
-		APIKey:           account.APIKey,
-		BinanceAPIKey:    account.BinanceAPIKey,
-		BinanceAPISecret: account.BinanceAPISecret,
+	resp := AccountResponseDTO{
+		ID:            account.ID.String(),
+		Name:          account.Name,
+		Email:         account.Email,
+		WhatsApp:      utils.FormatWhatsApp(account.WhatsApp),
+		APIKey:        account.APIKey,
+		BinanceAPIKey: account.BinanceAPIKey,
+	}
+	if account.BinanceAPISecret != nil && *account.BinanceAPISecret != "" {
+		resp.BinanceAPISecretConfigured = true
+		resp.BinanceAPISecretHint = utils.MaskSecret(*account.BinanceAPISecret)
 	}
+	return resp
 }
 
 // Construtor para Account (entidade)
@@ -2,7 +2,62 @@
 
 package dto
 
-import "github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/sizing"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+var symbolRegex = regexp.MustCompile(`^[A-Z0-9]{2,12}/?[A-Z0-9]{2,12}$`)
+
+// sizingParamLimits são os valores máximos das chaves numéricas de dimensionamento
+// (0: sem máximo). Todas exigem números finitos maiores que zero.
+var sizingParamLimits = map[string]float64{
+	"sizing_quote_amount":   0,
+	"sizing_balance_pct":    100,
+	"sizing_risk_pct":       100,
+	"sizing_atr_multiplier": 0,
+	"sizing_target_vol_pct": 100,
+	"sizing_max_pct":        100,
+}
+
+// exitParamLimits são os valores máximos das chaves numéricas da política de saída
+// (0: sem máximo). Regras não usadas ficam fora do config em vez de zeradas.
+var exitParamLimits = map[string]float64{
+	"exit_stop_loss_pct":           100,
+	"exit_take_profit_pct":         100,
+	"exit_trailing_atr_multiplier": 0,
+	"exit_trailing_pct":            100,
+	"exit_break_even_pct":          100,
+	"exit_break_even_offset_pct":   100,
+}
+
+// BotCreateDTO define os campos necessários para criar um bot
+type BotCreateDTO struct {
+	Symbol       string         `json:"symbol"`
+	Interval     string         `json:"interval"`
+	StrategyName string         `json:"strategy_name"`
+	Autonomous   bool           `json:"autonomous"`
+	Active       bool           `json:"active"`
+	Config       map[string]any `json:"config"`
+}
+
+// BotUpdateDTO define os campos permitidos para atualizar um bot (campos nulos são mantidos)
+type BotUpdateDTO struct {
+	Symbol       *string        `json:"symbol"`
+	Interval     *string        `json:"interval"`
+	StrategyName *string        `json:"strategy_name"`
+	Autonomous   *bool          `json:"autonomous"`
+	Config       map[string]any `json:"config"`
+}
 
 type BotResponseDTO struct {
 	ID           string `json:"id"`
@@ -11,6 +66,8 @@ type BotResponseDTO struct {
 	StrategyName string `json:"strategy_name"`
 	Autonomous   bool   `json:"autonomous"`
 	Active       bool   `json:"active"`
+	Paused       bool   `json:"paused"`
+	Status       string `json:"status,omitempty"`
 }
 
 func NewBotResponseDTO(bot *entity.Bot) BotResponseDTO {
@@ -21,5 +78,113 @@ func NewBotResponseDTO(bot *entity.Bot) BotResponseDTO {
 		StrategyName: bot.StrategyName,
 		Autonomous:   bot.Autonomous,
 		Active:       bot.Active,
+		Paused:       bot.Paused,
+	}
+}
+
+// Validação ao criar bot (a existência do símbolo e da estratégia é verificada no handler)
+func (b *BotCreateDTO) Validate() error {
+	b.Symbol = strings.ToUpper(strings.TrimSpace(b.Symbol))
+	if !symbolRegex.MatchString(b.Symbol) {
+		return errors.New("símbolo inválido (ex: BTC/USDT)")
+	}
+	if !utils.IsSupportedInterval(b.Interval) {
+		return errors.New("intervalo inválido (ex: 1m, 5m, 1h, 10m)")
+	}
+	if strings.TrimSpace(b.StrategyName) == "" {
+		return errors.New("a estratégia é obrigatória")
+	}
+	return validateBotConfig(b.Config)
+}
+
+// Validação ao atualizar bot
+func (b *BotUpdateDTO) Validate() error {
+	if b.Symbol != nil {
+		symbol := strings.ToUpper(strings.TrimSpace(*b.Symbol))
+		if !symbolRegex.MatchString(symbol) {
+			return errors.New("símbolo inválido (ex: BTC/USDT)")
+		}
+		b.Symbol = &symbol
+	}
+	if b.Interval != nil && !utils.IsSupportedInterval(*b.Interval) {
+		return errors.New("intervalo inválido (ex: 1m, 5m, 1h, 10m)")
+	}
+	if b.StrategyName != nil && strings.TrimSpace(*b.StrategyName) == "" {
+		return errors.New("a estratégia não pode ser vazia")
+	}
+	return validateBotConfig(b.Config)
+}
+
+// validateBotConfig valida as chaves do config que não são parâmetros de estratégia.
+func validateBotConfig(config map[string]any) error {
+	if mode, ok := config["trading_mode"]; ok && mode != entity.TradingModePaper && mode != entity.TradingModeLive {
+		return errors.New("trading_mode inválido (use paper ou live)")
+	}
+	if method, ok := config["sizing_method"]; ok {
+		if m, _ := method.(string); !sizing.IsValidMethod(m) {
+			return fmt.Errorf("sizing_method inválido (use %s)", strings.Join(sizing.Methods, ", "))
+		}
+	}
+	if err := validatePositiveParams(config, sizingParamLimits); err != nil {
+		return err
+	}
+	if err := validatePositiveParams(config, exitParamLimits); err != nil {
+		return err
+	}
+	if holding, ok := config["exit_max_holding"]; ok {
+		v, _ := holding.(string)
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.New("exit_max_holding inválido (ex: 30m, 12h)")
+		}
+	}
+	return nil
+}
+
+// validatePositiveParams confere as chaves numéricas presentes no config: números finitos
+// maiores que zero e, quando limits traz um máximo, até ele (percentuais até 100).
+func validatePositiveParams(config map[string]any, limits map[string]float64) error {
+	keys := make([]string, 0, len(limits))
+	for key := range limits {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val, ok := config[key]
+		if !ok || val == nil {
+			continue
+		}
+		var f float64
+		switch v := val.(type) {
+		case float64:
+			f = v
+		case int:
+			f = float64(v)
+		default:
+			return fmt.Errorf("%s inválido: informe um número", key)
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) || f <= 0 {
+			return fmt.Errorf("%s inválido: use um número maior que zero", key)
+		}
+		if limit := limits[key]; limit > 0 && f > limit {
+			return fmt.Errorf("%s inválido: use um valor até %v", key, limit)
+		}
+	}
+	return nil
+}
+
+// ApplyTo copia os campos informados para o bot
+func (b *BotUpdateDTO) ApplyTo(bot *entity.Bot) {
+	if b.Symbol != nil {
+		bot.Symbol = *b.Symbol
+	}
+	if b.Interval != nil {
+		bot.Interval = *b.Interval
+	}
+	if b.StrategyName != nil {
+		bot.StrategyName = *b.StrategyName
+	}
+	if b.Autonomous != nil {
+		bot.Autonomous = *b.Autonomous
 	}
 }
@@ -0,0 +1,31 @@
+// internal/domain/dto/bot_dto_test.go
+
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotCreateValidatesConfigValues(t *testing.T) {
+	valid := func(config map[string]any) error {
+		create := dto.BotCreateDTO{Symbol: "BTC/USDT", Interval: "1h", StrategyName: "EvaluateCrossover", Config: config}
+		return create.Validate()
+	}
+
+	assert.NoError(t, valid(map[string]any{"sizing_method": "fixed_fractional", "sizing_risk_pct": 1.5, "sizing_atr_multiplier": 2.0}))
+	assert.NoError(t, valid(map[string]any{"exit_stop_loss_pct": 2.0, "exit_take_profit_pct": 6.0, "exit_trailing_atr_multiplier": 3.0}))
+	for _, config := range []map[string]any{
+		{"sizing_risk_pct": 500.0},
+		{"sizing_balance_pct": -10.0},
+		{"sizing_quote_amount": 0.0},
+		{"sizing_max_pct": "50"},
+		{"exit_stop_loss_pct": -1.0},
+		{"exit_trailing_pct": 150.0},
+		{"exit_trailing_atr_multiplier": 0.0},
+	} {
+		assert.Error(t, valid(config), "%v", config)
+	}
+}
@@ -0,0 +1,98 @@
+// internal/domain/dto/indicator_dto.go
+
+package dto
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// FloatSeries é uma série de valores serializada com null nas posições sem valor (NaN).
+type FloatSeries []float64
+
+func (s FloatSeries) MarshalJSON() ([]byte, error) {
+	values := make([]*float64, len(s))
+	for i := range s {
+		values[i] = finite(s[i])
+	}
+	return json.Marshal(values)
+}
+
+// IndicatorSeriesResponseDTO são as séries dos indicadores pedidos sobre os candles do bot.
+// Times traz o close_time (ms) de cada posição das séries. IncompleteWarmup lista os
+// indicadores sem histórico suficiente antes do primeiro candle: seus primeiros valores vêm null.
+type IndicatorSeriesResponseDTO struct {
+	BotID            string                            `json:"bot_id"`
+	Symbol           string                            `json:"symbol"`
+	Interval         string                            `json:"interval"`
+	Times            []int64                           `json:"times"`
+	Indicators       map[string]map[string]FloatSeries `json:"indicators"`
+	IncompleteWarmup []string                          `json:"incomplete_warmup,omitempty"`
+}
+
+// NewIndicatorSeriesResponseDTO calcula os indicadores sobre todos os candles e devolve as
+// séries a partir do candle warmup: os candles anteriores servem apenas para aquecê-los.
+func NewIndicatorSeriesResponseDTO(botID uuid.UUID, symbol, interval string, candles []entity.Candle, specs []indicators.Spec, warmup int) IndicatorSeriesResponseDTO {
+	first := min(warmup, len(candles))
+	resp := IndicatorSeriesResponseDTO{
+		BotID:      botID.String(),
+		Symbol:     symbol,
+		Interval:   interval,
+		Times:      make([]int64, 0, len(candles)-first),
+		Indicators: make(map[string]map[string]FloatSeries, len(specs)),
+	}
+	for _, c := range candles[first:] {
+		resp.Times = append(resp.Times, c.CloseTime)
+	}
+	for _, spec := range specs {
+		lines := make(map[string]FloatSeries)
+		for name, series := range spec.Calculate(candles) {
+			lines[name] = FloatSeries(series[first:])
+		}
+		resp.Indicators[spec.String()] = lines
+		if first < len(candles) && !indicators.Ready(spec, first+1) {
+			resp.IncompleteWarmup = append(resp.IncompleteWarmup, spec.String())
+		}
+	}
+	return resp
+}
+
+// IndicatorValuesDTO são os valores dos indicadores assinados no candle fechado mais recente
+// (evento "indicators" do WebSocket).
+type IndicatorValuesDTO struct {
+	Time      int64                          `json:"time"`
+	OpenTime  int64                          `json:"open_time"`
+	CloseTime int64                          `json:"close_time"`
+	Values    map[string]map[string]*float64 `json:"values"`
+}
+
+// NewIndicatorValuesDTO calcula os indicadores sobre os candles e retorna o valor de cada
+// linha no candle mais recente.
+func NewIndicatorValuesDTO(candles []entity.Candle, specs []indicators.Spec) IndicatorValuesDTO {
+	values := IndicatorValuesDTO{Values: make(map[string]map[string]*float64, len(specs))}
+	if len(candles) == 0 {
+		return values
+	}
+	lastCandle := candles[len(candles)-1]
+	values.Time, values.OpenTime, values.CloseTime = lastCandle.Time, lastCandle.OpenTime, lastCandle.CloseTime
+	for _, spec := range specs {
+		lines := make(map[string]*float64)
+		for name, series := range spec.Calculate(candles) {
+			lines[name] = finite(series[len(series)-1])
+		}
+		values.Values[spec.String()] = lines
+	}
+	return values
+}
+
+// finite retorna nil para valores que não cabem no JSON (NaN e infinitos).
+func finite(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
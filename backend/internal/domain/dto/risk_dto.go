@@ -0,0 +1,73 @@
+// internal/domain/dto/risk_dto.go
+
+package dto
+
+import (
+	"errors"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// RiskLimitsUpdateDTO define os limites de risco da conta que podem ser alterados.
+// Campos omitidos mantêm o valor atual; 0 desativa o limite.
+type RiskLimitsUpdateDTO struct {
+	MaxOpenPositions     *int     `json:"max_open_positions"`
+	MaxTotalExposure     *float64 `json:"max_total_exposure"`
+	MaxAssetExposure     *float64 `json:"max_asset_exposure"`
+	MaxDailyLoss         *float64 `json:"max_daily_loss"`
+	MaxConsecutiveLosses *int     `json:"max_consecutive_losses"`
+}
+
+// Validação ao atualizar limites
+func (d *RiskLimitsUpdateDTO) Validate() error {
+	for _, v := range []*int{d.MaxOpenPositions, d.MaxConsecutiveLosses} {
+		if v != nil && *v < 0 {
+			return errors.New("os limites não podem ser negativos")
+		}
+	}
+	for _, v := range []*float64{d.MaxTotalExposure, d.MaxAssetExposure, d.MaxDailyLoss} {
+		if v != nil && *v < 0 {
+			return errors.New("os limites não podem ser negativos")
+		}
+	}
+	return nil
+}
+
+// ApplyTo copia os campos informados para os limites
+func (d *RiskLimitsUpdateDTO) ApplyTo(limits *entity.RiskLimits) {
+	if d.MaxOpenPositions != nil {
+		limits.MaxOpenPositions = *d.MaxOpenPositions
+	}
+	if d.MaxTotalExposure != nil {
+		limits.MaxTotalExposure = *d.MaxTotalExposure
+	}
+	if d.MaxAssetExposure != nil {
+		limits.MaxAssetExposure = *d.MaxAssetExposure
+	}
+	if d.MaxDailyLoss != nil {
+		limits.MaxDailyLoss = *d.MaxDailyLoss
+	}
+	if d.MaxConsecutiveLosses != nil {
+		limits.MaxConsecutiveLosses = *d.MaxConsecutiveLosses
+	}
+}
+
+// KillSwitchDTO liga (padrão) ou desliga o kill switch da conta, opcionalmente
+// encerrando as posições abertas dos bots em execução.
+type KillSwitchDTO struct {
+	Enabled        *bool `json:"enabled"`
+	ClosePositions bool  `json:"close_positions"`
+}
+
+// IsEnabled retorna o estado desejado do kill switch (ligado se omitido).
+func (d *KillSwitchDTO) IsEnabled() bool {
+	return d.Enabled == nil || *d.Enabled
+}
+
+// KillSwitchResponseDTO retorna os limites da conta, quantas posições foram encerradas e
+// quantas, de bots parados, ficaram marcadas para encerrar quando o bot voltar a rodar.
+type KillSwitchResponseDTO struct {
+	Limits           *entity.RiskLimits `json:"limits"`
+	ClosedPositions  int                `json:"closed_positions"`
+	FlaggedPositions int                `json:"flagged_positions"`
+}
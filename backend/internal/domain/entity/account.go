@@ -11,7 +11,7 @@ type Account struct {
 	WhatsApp         string    `json:"whatsapp"`
 	APIKey           *string   `json:"api_key"`
 	BinanceAPIKey    *string   `json:"binance_api_key"`
-	BinanceAPISecret *string   `json:"binance_api_secret"`
+	BinanceAPISecret *string   `json:"-"` // Nunca serializado; gravado cifrado no banco
 }
 
 // IsAdmin verifica se a conta é admin baseada no ID fixo
@@ -10,4 +10,5 @@ type Bot struct {
 	StrategyName string    `json:"strategy_name"`
 	Autonomous   bool      `json:"autonomous"`
 	Active       bool      `json:"active"`
+	Paused       bool      `json:"paused"` // avaliação suspensa, mantida entre reinícios
 }
@@ -0,0 +1,32 @@
+// internal/domain/entity/bot_config.go
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Modos de negociação de um bot, definidos na chave "trading_mode" do config.
+// Sem a chave o bot opera em paper trading.
+const (
+	TradingModePaper = "paper"
+	TradingModeLive  = "live"
+)
+
+// BotConfig representa uma versão da configuração dinâmica de um bot (tabela bot_configs).
+type BotConfig struct {
+	ID        uuid.UUID      `json:"id"`
+	BotID     uuid.UUID      `json:"bot_id"`
+	Config    map[string]any `json:"config"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// TradingMode retorna o modo de negociação (paper ou live) definido no config.
+func TradingMode(config map[string]any) string {
+	if mode, ok := config["trading_mode"].(string); ok && mode == TradingModeLive {
+		return TradingModeLive
+	}
+	return TradingModePaper
+}
@@ -4,10 +4,16 @@ package entity
 
 // Define o tipo Candle para armazenar os dados de um candle
 type Candle struct {
-	Open   float64
-	High   float64
-	Low    float64
-	Close  float64
-	Volume float64
-	Time   int64
+	Open                float64
+	High                float64
+	Low                 float64
+	Close               float64
+	Volume              float64 // volume no ativo base
+	QuoteVolume         float64 // volume na moeda de cotação
+	Trades              int64   // quantidade de negócios
+	TakerBuyVolume      float64 // volume base comprado a mercado (taker)
+	TakerBuyQuoteVolume float64 // volume em cotação comprado a mercado (taker)
+	Time                int64   // fechamento em segundos
+	OpenTime            int64   // abertura em ms (chave do candle no store)
+	CloseTime           int64   // fechamento em ms
 }
@@ -9,16 +9,18 @@ import (
 )
 
 type ExecutionLog struct {
-	BotID     uuid.UUID    `json:"bot_id"`
-	Symbol    string       `json:"symbol"`
-	Interval  string       `json:"interval"`
-	Entry     TradePoint   `json:"entry"`
-	Exit      TradePoint   `json:"exit"`
-	Duration  int64        `json:"duration"` // segundos entre entrada e saída
-	Profit    float64      `json:"profit"`
-	ROIPct    float64      `json:"roi_pct"`
-	Strategy  StrategyInfo `json:"strategy"`
-	CreatedAt time.Time    `json:"created_at"`
+	BotID      uuid.UUID    `json:"bot_id"`
+	Symbol     string       `json:"symbol"`
+	Interval   string       `json:"interval"`
+	Entry      TradePoint   `json:"entry"`
+	Exit       TradePoint   `json:"exit"`
+	Duration   int64        `json:"duration"` // segundos entre entrada e saída
+	Quantity   float64      `json:"quantity"` // quantidade negociada em ativo base
+	Profit     float64      `json:"profit"`   // resultado em moeda de cotação
+	ROIPct     float64      `json:"roi_pct"`
+	ExitReason string       `json:"exit_reason"` // signal, stop_loss, atr_stop, trailing_stop, break_even, take_profit ou max_holding
+	Strategy   StrategyInfo `json:"strategy"`
+	CreatedAt  time.Time    `json:"created_at"`
 }
 
 type TradePoint struct {
@@ -4,6 +4,16 @@ import "github.com/google/uuid"
 
 type OpenPosition struct {
 	BotID      uuid.UUID `json:"bot_id"`
+	Symbol     string    `json:"symbol,omitempty"` // preenchido nas consultas por conta
 	EntryPrice float64   `json:"entry_price"`
+	Quantity   float64   `json:"quantity"`
 	Timestamp  int64     `json:"timestamp"`
+
+	// Acompanhamento da política de saída
+	HighestPrice float64 `json:"highest_price"` // máxima desde a entrada
+	StopPrice    float64 `json:"stop_price"`    // stop vigente (0 = sem stop)
+	StopReason   string  `json:"stop_reason"`   // regra que definiu o stop
+
+	// Encerramento forçado pendente (ex: kill_switch), mantido até a saída ser executada
+	CloseRequested string `json:"close_requested,omitempty"`
 }
\ No newline at end of file
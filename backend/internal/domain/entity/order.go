@@ -0,0 +1,73 @@
+// internal/domain/entity/order.go
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderSide string
+type OrderType string
+type OrderStatus string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+
+	OrderTypeMarket OrderType = "MARKET"
+	OrderTypeLimit  OrderType = "LIMIT"
+
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCanceled        OrderStatus = "CANCELED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+)
+
+// IsFinal informa se a ordem não terá mais alterações de status.
+func (s OrderStatus) IsFinal() bool {
+	return s == OrderStatusFilled || s == OrderStatusCanceled || s == OrderStatusRejected || s == OrderStatusExpired
+}
+
+// OrderRequest descreve uma ordem a ser enviada à exchange.
+type OrderRequest struct {
+	Symbol        string    `json:"symbol"` // Formato da exchange (ex: BTCUSDT)
+	Side          OrderSide `json:"side"`
+	Type          OrderType `json:"type"`
+	Quantity      float64   `json:"quantity"`
+	Price         float64   `json:"price,omitempty"` // Apenas ordens LIMIT
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+}
+
+// Order é o estado de uma ordem na exchange (real ou simulada).
+type Order struct {
+	ID               uuid.UUID   `json:"id"`
+	AccountID        uuid.UUID   `json:"account_id"`
+	BotID            uuid.UUID   `json:"bot_id"`
+	ExchangeOrderID  string      `json:"exchange_order_id"`
+	ClientOrderID    string      `json:"client_order_id"`
+	Symbol           string      `json:"symbol"`
+	Side             OrderSide   `json:"side"`
+	Type             OrderType   `json:"type"`
+	Status           OrderStatus `json:"status"`
+	Quantity         float64     `json:"quantity"`
+	Price            float64     `json:"price"`
+	ExecutedQuantity float64     `json:"executed_quantity"`
+	QuoteQuantity    float64     `json:"quote_quantity"` // Valor executado em moeda de cotação
+	Fee              float64     `json:"fee"`
+	FeeAsset         string      `json:"fee_asset"`
+	Paper            bool        `json:"paper"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// AvgPrice retorna o preço médio executado da ordem.
+func (o *Order) AvgPrice() float64 {
+	if o.ExecutedQuantity == 0 {
+		return 0
+	}
+	return o.QuoteQuantity / o.ExecutedQuantity
+}
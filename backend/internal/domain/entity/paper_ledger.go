@@ -0,0 +1,29 @@
+// internal/domain/entity/paper_ledger.go
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Motivos de lançamento no ledger de paper trading.
+const (
+	PaperLedgerDeposit = "deposit"
+	PaperLedgerTrade   = "trade"
+	PaperLedgerFee     = "fee"
+)
+
+// PaperLedgerEntry é um lançamento de saldo virtual de uma conta em paper trading.
+type PaperLedgerEntry struct {
+	ID        uuid.UUID `json:"id"`
+	AccountID uuid.UUID `json:"account_id"`
+	OrderID   string    `json:"order_id,omitempty"`
+	Symbol    string    `json:"symbol,omitempty"`
+	Asset     string    `json:"asset"`
+	Amount    float64   `json:"amount"`  // Variação do saldo (negativa em saídas)
+	Balance   float64   `json:"balance"` // Saldo do ativo após o lançamento
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
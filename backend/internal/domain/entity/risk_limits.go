@@ -0,0 +1,23 @@
+// internal/domain/entity/risk_limits.go
+
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RiskLimits são os limites de risco de uma conta, avaliados antes de qualquer entrada
+// dos seus bots. Valores zerados desativam o limite correspondente.
+type RiskLimits struct {
+	AccountID            uuid.UUID  `json:"account_id"`
+	MaxOpenPositions     int        `json:"max_open_positions"`     // posições abertas simultâneas
+	MaxTotalExposure     float64    `json:"max_total_exposure"`     // exposição total em USDT, a preço atual
+	MaxAssetExposure     float64    `json:"max_asset_exposure"`     // exposição por ativo base em USDT, a preço atual
+	MaxDailyLoss         float64    `json:"max_daily_loss"`         // perda realizada no dia (UTC), valor positivo
+	MaxConsecutiveLosses int        `json:"max_consecutive_losses"` // perdas seguidas, contadas até o último ganho
+	KillSwitch           bool       `json:"kill_switch"`            // bloqueia todas as entradas
+	KillSwitchAt         *time.Time `json:"kill_switch_at,omitempty"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
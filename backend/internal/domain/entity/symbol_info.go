@@ -0,0 +1,37 @@
+// internal/domain/entity/symbol_info.go
+
+package entity
+
+// SymbolInfo reúne os ativos e os filtros de negociação de um símbolo na exchange.
+// Filtros com valor zero não se aplicam.
+type SymbolInfo struct {
+	Symbol     string `json:"symbol"`
+	Status     string `json:"status"`
+	BaseAsset  string `json:"base_asset"`
+	QuoteAsset string `json:"quote_asset"`
+
+	// LOT_SIZE e MARKET_LOT_SIZE
+	StepSize       float64 `json:"step_size"`
+	MinQty         float64 `json:"min_qty"`
+	MaxQty         float64 `json:"max_qty"`
+	MarketStepSize float64 `json:"market_step_size"`
+	MarketMinQty   float64 `json:"market_min_qty"`
+	MarketMaxQty   float64 `json:"market_max_qty"`
+
+	// PRICE_FILTER
+	TickSize float64 `json:"tick_size"`
+	MinPrice float64 `json:"min_price"`
+	MaxPrice float64 `json:"max_price"`
+
+	// NOTIONAL / MIN_NOTIONAL
+	MinNotional              float64 `json:"min_notional"`
+	ApplyMinNotionalToMarket bool    `json:"apply_min_notional_to_market"`
+	MaxNotional              float64 `json:"max_notional"`
+	ApplyMaxNotionalToMarket bool    `json:"apply_max_notional_to_market"`
+
+	// PERCENT_PRICE_BY_SIDE / PERCENT_PRICE (multiplicadores sobre o preço de referência)
+	BidMultiplierUp   float64 `json:"bid_multiplier_up"`
+	BidMultiplierDown float64 `json:"bid_multiplier_down"`
+	AskMultiplierUp   float64 `json:"ask_multiplier_up"`
+	AskMultiplierDown float64 `json:"ask_multiplier_down"`
+}
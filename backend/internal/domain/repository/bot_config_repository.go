@@ -0,0 +1,13 @@
+// internal/domain/repository/bot_config_repository.go
+
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type BotConfigRepository interface {
+	GetLatest(botID uuid.UUID) (*entity.BotConfig, error)
+	Save(config entity.BotConfig) (*entity.BotConfig, error)
+}
@@ -11,5 +11,7 @@ type BotRepository interface {
 	Create(bot *entity.Bot) (*entity.Bot, error)
 	GetByID(id uuid.UUID) (*entity.Bot, error)
 	GetByAccountID(accountID uuid.UUID) ([]entity.Bot, error)
+	GetActive() ([]entity.Bot, error)
 	Update(bot *entity.Bot) (*entity.Bot, error)
+	Delete(id uuid.UUID) error
 }
@@ -0,0 +1,19 @@
+// internal/domain/repository/candle_repository.go
+
+package repository
+
+import "github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+
+// CandleRepository guarda os candles fechados por símbolo (formato Binance) e intervalo.
+type CandleRepository interface {
+	// SaveMany grava os candles; candles já existentes (mesmo open_time) são sobrescritos.
+	SaveMany(symbol, interval string, candles []entity.Candle) error
+	// GetLatest retorna os últimos limit candles, do mais antigo para o mais recente.
+	GetLatest(symbol, interval string, limit int) ([]entity.Candle, error)
+	// GetRange retorna até limit candles com close_time entre from e to (ms, 0 = sem limite),
+	// os mais recentes do intervalo, do mais antigo para o mais recente.
+	GetRange(symbol, interval string, from, to int64, limit int) ([]entity.Candle, error)
+	// GetBefore retorna os últimos limit candles abertos antes de openTime (ms), do mais antigo
+	// para o mais recente.
+	GetBefore(symbol, interval string, openTime int64, limit int) ([]entity.Candle, error)
+}
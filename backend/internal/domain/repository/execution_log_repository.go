@@ -2,9 +2,18 @@
 
 package repository
 
-import "github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
 
 type ExecutionLogRepository interface {
 	Save(log entity.ExecutionLog) error
 	GetAll() ([]entity.ExecutionLog, error)
+	// GetByAccountSince retorna as execuções dos bots da conta desde since, da mais recente para a mais antiga.
+	GetByAccountSince(accountID uuid.UUID, since time.Time) ([]entity.ExecutionLog, error)
+	// GetLatestByAccount retorna as últimas limit execuções dos bots da conta, da mais recente para a mais antiga.
+	GetLatestByAccount(accountID uuid.UUID, limit int) ([]entity.ExecutionLog, error)
 }
@@ -0,0 +1,15 @@
+// internal/domain/repository/order_repository.go
+
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type OrderRepository interface {
+	Save(order entity.Order) (*entity.Order, error)
+	Update(order entity.Order) error
+	GetOpenByBot(botID uuid.UUID) ([]entity.Order, error)
+	GetOpenByAccount(accountID uuid.UUID) ([]entity.Order, error)
+}
@@ -0,0 +1,13 @@
+// internal/domain/repository/paper_ledger_repository.go
+
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type PaperLedgerRepository interface {
+	Save(entry entity.PaperLedgerEntry) error
+	GetBalances(accountID uuid.UUID) (map[string]float64, error)
+}
@@ -12,4 +12,6 @@ type PositionRepository interface {
 	Delete(botID uuid.UUID) error
 	Get(botID uuid.UUID) (*entity.OpenPosition, error)
 	GetAll() ([]entity.OpenPosition, error)
+	GetByAccount(accountID uuid.UUID) ([]entity.OpenPosition, error)
+	RequestClose(botID uuid.UUID, reason string) error
 }
@@ -0,0 +1,14 @@
+// internal/domain/repository/risk_limits_repository.go
+
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type RiskLimitsRepository interface {
+	// Get retorna os limites da conta, ou nil se nunca foram configurados.
+	Get(accountID uuid.UUID) (*entity.RiskLimits, error)
+	Save(limits entity.RiskLimits) error
+}
@@ -10,25 +10,34 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/secrets"
 )
 
+// AccountRepository persiste contas. O binance_api_secret é gravado cifrado
+// (envelope encryption, ver internal/infra/secrets) e decifrado na leitura.
 type AccountRepository struct {
-	db *pgxpool.Pool
+	db     *pgxpool.Pool
+	cipher *secrets.Cipher
 }
 
-func NewAccountRepository(db *pgxpool.Pool) *AccountRepository {
-	return &AccountRepository{db: db}
+func NewAccountRepository(db *pgxpool.Pool, cipher *secrets.Cipher) *AccountRepository {
+	return &AccountRepository{db: db, cipher: cipher}
 }
 
 func (r *AccountRepository) Create(ctx context.Context, account *entity.Account) (*entity.Account, error) {
+	secret, err := r.encryptSecret(account.BinanceAPISecret)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
         INSERT INTO accounts (id, name, email, whatsapp, api_key, binance_api_key, binance_api_secret, created_at, updated_at)
         VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())
         RETURNING id
     `
-	err := r.db.QueryRow(ctx, query,
+	err = r.db.QueryRow(ctx, query,
 		account.ID, account.Name, account.Email, account.WhatsApp,
-		account.APIKey, account.BinanceAPIKey, account.BinanceAPISecret,
+		account.APIKey, account.BinanceAPIKey, secret,
 	).Scan(&account.ID)
 	if err != nil {
 		return nil, err
@@ -45,6 +54,9 @@ func (r *AccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 	if err != nil {
 		return nil, err
 	}
+	if err := r.decryptSecret(&a); err != nil {
+		return nil, err
+	}
 	return &a, nil
 }
 
@@ -63,6 +75,9 @@ func (r *AccountRepository) GetAll(ctx context.Context) ([]*entity.Account, erro
 		if err != nil {
 			return nil, err
 		}
+		if err := r.decryptSecret(&a); err != nil {
+			return nil, err
+		}
 		accounts = append(accounts, &a)
 	}
 	return accounts, nil
@@ -74,6 +89,15 @@ func (r *AccountRepository) UpdateByID(ctx context.Context, id uuid.UUID, jsonDa
 		return nil, fmt.Errorf("erro ao fazer unmarshal do JSON: %w", err)
 	}
 
+	// 🔐 O segredo nunca é gravado em texto puro
+	if secret, ok := updates["binance_api_secret"].(string); ok && secret != "" {
+		encrypted, err := r.cipher.Encrypt(secret)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao cifrar binance_api_secret: %w", err)
+		}
+		updates["binance_api_secret"] = encrypted
+	}
+
 	setClause := ""
 	args := []interface{}{}
 	i := 1
@@ -102,3 +126,75 @@ func (r *AccountRepository) DeleteByID(ctx context.Context, id uuid.UUID) error
 	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
+
+// RotateSecrets recifra com a chave mestra ativa todos os segredos gravados em texto puro
+// ou com chaves anteriores. Retorna a quantidade de contas atualizadas.
+func (r *AccountRepository) RotateSecrets(ctx context.Context) (int, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, binance_api_secret FROM accounts WHERE binance_api_secret IS NOT NULL AND binance_api_secret <> ''`)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := make(map[uuid.UUID]string)
+	for rows.Next() {
+		var id uuid.UUID
+		var stored string
+		if err := rows.Scan(&id, &stored); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if r.cipher.NeedsRotation(stored) {
+			pending[id] = stored
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for id, stored := range pending {
+		plaintext, err := r.cipher.Decrypt(stored)
+		if err != nil {
+			return rotated, fmt.Errorf("erro ao decifrar segredo da conta %s: %w", id, err)
+		}
+		encrypted, err := r.cipher.Encrypt(plaintext)
+		if err != nil {
+			return rotated, fmt.Errorf("erro ao cifrar segredo da conta %s: %w", id, err)
+		}
+
+		// A condição no valor antigo evita sobrescrever uma atualização concorrente
+		tag, err := r.db.Exec(ctx,
+			`UPDATE accounts SET binance_api_secret = $1, updated_at = now() WHERE id = $2 AND binance_api_secret = $3`,
+			encrypted, id, stored,
+		)
+		if err != nil {
+			return rotated, err
+		}
+		rotated += int(tag.RowsAffected())
+	}
+	return rotated, nil
+}
+
+func (r *AccountRepository) encryptSecret(secret *string) (*string, error) {
+	if secret == nil || *secret == "" {
+		return secret, nil
+	}
+	encrypted, err := r.cipher.Encrypt(*secret)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao cifrar binance_api_secret: %w", err)
+	}
+	return &encrypted, nil
+}
+
+func (r *AccountRepository) decryptSecret(a *entity.Account) error {
+	if a.BinanceAPISecret == nil || *a.BinanceAPISecret == "" {
+		return nil
+	}
+	plaintext, err := r.cipher.Decrypt(*a.BinanceAPISecret)
+	if err != nil {
+		return fmt.Errorf("erro ao decifrar binance_api_secret da conta %s: %w", a.ID, err)
+	}
+	a.BinanceAPISecret = &plaintext
+	return nil
+}
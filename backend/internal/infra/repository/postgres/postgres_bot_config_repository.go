@@ -0,0 +1,72 @@
+// internal/infra/repository/postgres/postgres_bot_config_repository.go
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type BotConfigRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBotConfigRepository(db *pgxpool.Pool) *BotConfigRepository {
+	return &BotConfigRepository{db: db}
+}
+
+// GetLatest retorna a configuração mais recente do bot, ou nil se o bot não tiver nenhuma.
+func (r *BotConfigRepository) GetLatest(botID uuid.UUID) (*entity.BotConfig, error) {
+	query := `
+        SELECT id, bot_id, config_json, created_at
+        FROM bot_configs
+        WHERE bot_id = $1
+        ORDER BY created_at DESC
+        LIMIT 1
+    `
+	var (
+		c       entity.BotConfig
+		rawJSON []byte
+	)
+	err := r.db.QueryRow(context.Background(), query, botID).Scan(&c.ID, &c.BotID, &rawJSON, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rawJSON, &c.Config); err != nil {
+		return nil, fmt.Errorf("config_json inválido para o bot %s: %w", botID, err)
+	}
+	return &c, nil
+}
+
+// Save grava uma nova versão da configuração do bot (o histórico é preservado).
+func (r *BotConfigRepository) Save(config entity.BotConfig) (*entity.BotConfig, error) {
+	if config.ID == uuid.Nil {
+		config.ID = uuid.New()
+	}
+	configJSON, err := json.Marshal(config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO bot_configs (id, bot_id, config_json, created_at)
+        VALUES ($1, $2, $3, now())
+        RETURNING created_at
+    `
+	err = r.db.QueryRow(context.Background(), query, config.ID, config.BotID, configJSON).Scan(&config.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
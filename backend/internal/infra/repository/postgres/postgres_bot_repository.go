@@ -4,8 +4,10 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
@@ -21,12 +23,12 @@ func NewBotRepository(db *pgxpool.Pool) *BotRepository {
 
 func (r *BotRepository) Create(bot *entity.Bot) (*entity.Bot, error) {
 	query := `
-        INSERT INTO bots (id, account_id, symbol, interval, strategy_name, autonomous, active, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())
+        INSERT INTO bots (id, account_id, symbol, interval, strategy_name, autonomous, active, paused, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now())
     `
 	_, err := r.db.Exec(context.Background(), query,
 		bot.ID, bot.AccountID, bot.Symbol, bot.Interval, bot.StrategyName,
-		bot.Autonomous, bot.Active,
+		bot.Autonomous, bot.Active, bot.Paused,
 	)
 	if err != nil {
 		return nil, err
@@ -35,12 +37,15 @@ func (r *BotRepository) Create(bot *entity.Bot) (*entity.Bot, error) {
 }
 
 func (r *BotRepository) GetByID(id uuid.UUID) (*entity.Bot, error) {
-	query := `SELECT id, account_id, symbol, interval, strategy_name, autonomous, active FROM bots WHERE id = $1`
+	query := `SELECT id, account_id, symbol, interval, strategy_name, autonomous, active, paused FROM bots WHERE id = $1`
 	row := r.db.QueryRow(context.Background(), query, id)
 
 	var b entity.Bot
-	err := row.Scan(&b.ID, &b.AccountID, &b.Symbol, &b.Interval, &b.StrategyName, &b.Autonomous, &b.Active)
+	err := row.Scan(&b.ID, &b.AccountID, &b.Symbol, &b.Interval, &b.StrategyName, &b.Autonomous, &b.Active, &b.Paused)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -50,7 +55,7 @@ func (r *BotRepository) GetByID(id uuid.UUID) (*entity.Bot, error) {
 func (r *BotRepository) GetByAccountID(accountID uuid.UUID) ([]entity.Bot, error) {
 	logger.Debug("Buscando bots para o account_id: ", accountID)
 
-	query := `SELECT id, account_id, symbol, interval, strategy_name, autonomous, active FROM bots WHERE account_id = $1`
+	query := `SELECT id, account_id, symbol, interval, strategy_name, autonomous, active, paused FROM bots WHERE account_id = $1`
 	rows, err := r.db.Query(context.Background(), query, accountID)
 	if err != nil {
 		return nil, err
@@ -60,7 +65,7 @@ func (r *BotRepository) GetByAccountID(accountID uuid.UUID) ([]entity.Bot, error
 	var bots []entity.Bot
 	for rows.Next() {
 		var b entity.Bot
-		if err := rows.Scan(&b.ID, &b.AccountID, &b.Symbol, &b.Interval, &b.StrategyName, &b.Autonomous, &b.Active); err != nil {
+		if err := rows.Scan(&b.ID, &b.AccountID, &b.Symbol, &b.Interval, &b.StrategyName, &b.Autonomous, &b.Active, &b.Paused); err != nil {
 			return nil, err
 		}
 		bots = append(bots, b)
@@ -71,17 +76,44 @@ func (r *BotRepository) GetByAccountID(accountID uuid.UUID) ([]entity.Bot, error
 	return bots, nil
 }
 
+// GetActive retorna os bots ativos de todas as contas.
+func (r *BotRepository) GetActive() ([]entity.Bot, error) {
+	query := `SELECT id, account_id, symbol, interval, strategy_name, autonomous, active, paused FROM bots WHERE active = true ORDER BY account_id`
+	rows, err := r.db.Query(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bots []entity.Bot
+	for rows.Next() {
+		var b entity.Bot
+		if err := rows.Scan(&b.ID, &b.AccountID, &b.Symbol, &b.Interval, &b.StrategyName, &b.Autonomous, &b.Active, &b.Paused); err != nil {
+			return nil, err
+		}
+		bots = append(bots, b)
+	}
+
+	return bots, rows.Err()
+}
+
 func (r *BotRepository) Update(bot *entity.Bot) (*entity.Bot, error) {
 	query := `
         UPDATE bots
-        SET symbol = $1, interval = $2, strategy_name = $3, autonomous = $4, active = $5, updated_at = now()
-        WHERE id = $6
+        SET symbol = $1, interval = $2, strategy_name = $3, autonomous = $4, active = $5, paused = $6, updated_at = now()
+        WHERE id = $7
     `
 	_, err := r.db.Exec(context.Background(), query,
-		bot.Symbol, bot.Interval, bot.StrategyName, bot.Autonomous, bot.Active, bot.ID,
+		bot.Symbol, bot.Interval, bot.StrategyName, bot.Autonomous, bot.Active, bot.Paused, bot.ID,
 	)
 	if err != nil {
 		return nil, err
 	}
 	return bot, nil
 }
+
+func (r *BotRepository) Delete(id uuid.UUID) error {
+	query := `DELETE FROM bots WHERE id = $1`
+	_, err := r.db.Exec(context.Background(), query, id)
+	return err
+}
@@ -0,0 +1,127 @@
+// internal/infra/repository/postgres/postgres_candle_repository.go
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type CandleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCandleRepository(db *pgxpool.Pool) *CandleRepository {
+	return &CandleRepository{db: db}
+}
+
+// SaveMany grava os candles em lote; candles com o mesmo open_time são sobrescritos.
+func (r *CandleRepository) SaveMany(symbol, interval string, candles []entity.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	query := `
+        INSERT INTO candles (symbol, interval, open_time, close_time, open, high, low, close, volume,
+            quote_volume, trades, taker_buy_volume, taker_buy_quote_volume)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+        ON CONFLICT (symbol, interval, open_time) DO UPDATE SET close_time = EXCLUDED.close_time,
+            open = EXCLUDED.open, high = EXCLUDED.high, low = EXCLUDED.low, close = EXCLUDED.close, volume = EXCLUDED.volume,
+            quote_volume = EXCLUDED.quote_volume, trades = EXCLUDED.trades,
+            taker_buy_volume = EXCLUDED.taker_buy_volume, taker_buy_quote_volume = EXCLUDED.taker_buy_quote_volume
+    `
+	batch := &pgx.Batch{}
+	for _, c := range candles {
+		batch.Queue(query, symbol, interval, c.OpenTime, c.CloseTime, c.Open, c.High, c.Low, c.Close, c.Volume,
+			c.QuoteVolume, c.Trades, c.TakerBuyVolume, c.TakerBuyQuoteVolume)
+	}
+	return r.db.SendBatch(context.Background(), batch).Close()
+}
+
+// GetLatest retorna os últimos limit candles, do mais antigo para o mais recente.
+func (r *CandleRepository) GetLatest(symbol, interval string, limit int) ([]entity.Candle, error) {
+	query := `
+        SELECT open_time, close_time, open, high, low, close, volume,
+            quote_volume, trades, taker_buy_volume, taker_buy_quote_volume
+        FROM (
+            SELECT open_time, close_time, open, high, low, close, volume,
+                quote_volume, trades, taker_buy_volume, taker_buy_quote_volume
+            FROM candles
+            WHERE symbol = $1 AND interval = $2
+            ORDER BY open_time DESC
+            LIMIT $3
+        ) latest
+        ORDER BY open_time
+    `
+	rows, err := r.db.Query(context.Background(), query, symbol, interval, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCandles(rows)
+}
+
+// GetRange retorna até limit candles com close_time entre from e to (0 = sem limite), os mais
+// recentes do intervalo, do mais antigo para o mais recente.
+func (r *CandleRepository) GetRange(symbol, interval string, from, to int64, limit int) ([]entity.Candle, error) {
+	query := `
+        SELECT open_time, close_time, open, high, low, close, volume,
+            quote_volume, trades, taker_buy_volume, taker_buy_quote_volume
+        FROM (
+            SELECT open_time, close_time, open, high, low, close, volume,
+                quote_volume, trades, taker_buy_volume, taker_buy_quote_volume
+            FROM candles
+            WHERE symbol = $1 AND interval = $2
+                AND ($3::bigint = 0 OR close_time >= $3::bigint) AND ($4::bigint = 0 OR close_time <= $4::bigint)
+            ORDER BY open_time DESC
+            LIMIT $5
+        ) latest
+        ORDER BY open_time
+    `
+	rows, err := r.db.Query(context.Background(), query, symbol, interval, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCandles(rows)
+}
+
+// GetBefore retorna os últimos limit candles abertos antes de openTime, do mais antigo para o
+// mais recente.
+func (r *CandleRepository) GetBefore(symbol, interval string, openTime int64, limit int) ([]entity.Candle, error) {
+	query := `
+        SELECT open_time, close_time, open, high, low, close, volume,
+            quote_volume, trades, taker_buy_volume, taker_buy_quote_volume
+        FROM (
+            SELECT open_time, close_time, open, high, low, close, volume,
+                quote_volume, trades, taker_buy_volume, taker_buy_quote_volume
+            FROM candles
+            WHERE symbol = $1 AND interval = $2 AND open_time < $3
+            ORDER BY open_time DESC
+            LIMIT $4
+        ) latest
+        ORDER BY open_time
+    `
+	rows, err := r.db.Query(context.Background(), query, symbol, interval, openTime, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanCandles(rows)
+}
+
+// scanCandles lê as linhas de candles das consultas acima.
+func scanCandles(rows pgx.Rows) ([]entity.Candle, error) {
+	defer rows.Close()
+
+	var candles []entity.Candle
+	for rows.Next() {
+		var c entity.Candle
+		if err := rows.Scan(&c.OpenTime, &c.CloseTime, &c.Open, &c.High, &c.Low, &c.Close, &c.Volume,
+			&c.QuoteVolume, &c.Trades, &c.TakerBuyVolume, &c.TakerBuyQuoteVolume); err != nil {
+			return nil, err
+		}
+		c.Time = c.CloseTime / 1000
+		candles = append(candles, c)
+	}
+	return candles, rows.Err()
+}
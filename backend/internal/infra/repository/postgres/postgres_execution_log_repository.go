@@ -2,7 +2,10 @@ package postgres
 
 import (
     "context"
+    "time"
+
     "github.com/google/uuid"
+    "github.com/jackc/pgx/v5"
     "github.com/jackc/pgx/v5/pgxpool"
     "github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 )
@@ -19,15 +22,15 @@ func (r *ExecutionLogRepository) Save(exec entity.ExecutionLog) error {
     query := `
         INSERT INTO executions (
             id, bot_id, entry_price, entry_time, exit_price, exit_time,
-            duration, profit, roi_pct, strategy, created_at
+            duration, quantity, profit, roi_pct, exit_reason, strategy, created_at
         ) VALUES (
             $1, $2, $3, $4, $5, $6,
-            $7, $8, $9, $10, now()
+            $7, $8, $9, $10, $11, $12, now()
         )
     `
     _, err := r.db.Exec(context.Background(), query,
         uuid.New(), exec.BotID, exec.Entry.Price, exec.Entry.Timestamp,
-        exec.Exit.Price, exec.Exit.Timestamp, exec.Duration, exec.Profit, exec.ROIPct,
+        exec.Exit.Price, exec.Exit.Timestamp, exec.Duration, exec.Quantity, exec.Profit, exec.ROIPct, exec.ExitReason,
         exec.Strategy.Name,
     )
     return err
@@ -36,7 +39,7 @@ func (r *ExecutionLogRepository) Save(exec entity.ExecutionLog) error {
 func (r *ExecutionLogRepository) GetAll() ([]entity.ExecutionLog, error) {
     query := `
         SELECT bot_id, entry_price, entry_time, exit_price, exit_time,
-               duration, profit, roi_pct, strategy
+               duration, quantity, profit, roi_pct, exit_reason, strategy
         FROM executions
         ORDER BY created_at DESC
     `
@@ -52,7 +55,7 @@ func (r *ExecutionLogRepository) GetAll() ([]entity.ExecutionLog, error) {
         err := rows.Scan(
             &e.BotID, &e.Entry.Price, &e.Entry.Timestamp,
             &e.Exit.Price, &e.Exit.Timestamp, &e.Duration,
-            &e.Profit, &e.ROIPct, &e.Strategy.Name,
+            &e.Quantity, &e.Profit, &e.ROIPct, &e.ExitReason, &e.Strategy.Name,
         )
         if err != nil {
             return nil, err
@@ -61,4 +64,59 @@ func (r *ExecutionLogRepository) GetAll() ([]entity.ExecutionLog, error) {
     }
 
     return logs, nil
+}
+
+// GetByAccountSince retorna as execuções dos bots da conta desde since, da mais recente para a mais antiga.
+func (r *ExecutionLogRepository) GetByAccountSince(accountID uuid.UUID, since time.Time) ([]entity.ExecutionLog, error) {
+    query := `
+        SELECT e.bot_id, b.symbol, b.interval, e.entry_price, e.entry_time, e.exit_price, e.exit_time,
+               e.duration, e.quantity, e.profit, e.roi_pct, e.exit_reason, e.strategy, e.created_at
+        FROM executions e
+        JOIN bots b ON b.id = e.bot_id
+        WHERE b.account_id = $1 AND e.created_at >= $2
+        ORDER BY e.created_at DESC
+    `
+    rows, err := r.db.Query(context.Background(), query, accountID, since)
+    if err != nil {
+        return nil, err
+    }
+    return scanAccountExecutions(rows)
+}
+
+// GetLatestByAccount retorna as últimas limit execuções dos bots da conta, da mais recente para a mais antiga.
+func (r *ExecutionLogRepository) GetLatestByAccount(accountID uuid.UUID, limit int) ([]entity.ExecutionLog, error) {
+    query := `
+        SELECT e.bot_id, b.symbol, b.interval, e.entry_price, e.entry_time, e.exit_price, e.exit_time,
+               e.duration, e.quantity, e.profit, e.roi_pct, e.exit_reason, e.strategy, e.created_at
+        FROM executions e
+        JOIN bots b ON b.id = e.bot_id
+        WHERE b.account_id = $1
+        ORDER BY e.created_at DESC
+        LIMIT $2
+    `
+    rows, err := r.db.Query(context.Background(), query, accountID, limit)
+    if err != nil {
+        return nil, err
+    }
+    return scanAccountExecutions(rows)
+}
+
+func scanAccountExecutions(rows pgx.Rows) ([]entity.ExecutionLog, error) {
+    defer rows.Close()
+
+    var logs []entity.ExecutionLog
+    for rows.Next() {
+        var e entity.ExecutionLog
+        err := rows.Scan(
+            &e.BotID, &e.Symbol, &e.Interval, &e.Entry.Price, &e.Entry.Timestamp,
+            &e.Exit.Price, &e.Exit.Timestamp, &e.Duration,
+            &e.Quantity, &e.Profit, &e.ROIPct, &e.ExitReason, &e.Strategy.Name, &e.CreatedAt,
+        )
+        if err != nil {
+            return nil, err
+        }
+        logs = append(logs, e)
+    }
+
+    return logs, rows.Err()
 }
\ No newline at end of file
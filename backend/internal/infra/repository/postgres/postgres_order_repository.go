@@ -0,0 +1,101 @@
+// internal/infra/repository/postgres/postgres_order_repository.go
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type OrderRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOrderRepository(db *pgxpool.Pool) *OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+// Save grava uma nova ordem.
+func (r *OrderRepository) Save(o entity.Order) (*entity.Order, error) {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	query := `
+        INSERT INTO orders (
+            id, account_id, bot_id, exchange_order_id, client_order_id, symbol, side, type, status,
+            quantity, price, executed_quantity, quote_quantity, fee, fee_asset, paper, created_at, updated_at
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, now(), now())
+        RETURNING created_at, updated_at
+    `
+	err := r.db.QueryRow(context.Background(), query,
+		o.ID, o.AccountID, o.BotID, o.ExchangeOrderID, o.ClientOrderID, o.Symbol, o.Side, o.Type, o.Status,
+		o.Quantity, o.Price, o.ExecutedQuantity, o.QuoteQuantity, o.Fee, o.FeeAsset, o.Paper,
+	).Scan(&o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// Update grava o estado de execução da ordem.
+func (r *OrderRepository) Update(o entity.Order) error {
+	query := `
+        UPDATE orders
+        SET status = $1, executed_quantity = $2, quote_quantity = $3, fee = $4, fee_asset = $5, updated_at = now()
+        WHERE id = $6
+    `
+	_, err := r.db.Exec(context.Background(), query,
+		o.Status, o.ExecutedQuantity, o.QuoteQuantity, o.Fee, o.FeeAsset, o.ID,
+	)
+	return err
+}
+
+// GetOpenByBot retorna as ordens do bot ainda não finalizadas, da mais antiga para a mais recente.
+func (r *OrderRepository) GetOpenByBot(botID uuid.UUID) ([]entity.Order, error) {
+	query := `
+        SELECT id, account_id, bot_id, exchange_order_id, client_order_id, symbol, side, type, status,
+               quantity, price, executed_quantity, quote_quantity, fee, COALESCE(fee_asset, ''), paper, created_at, updated_at
+        FROM orders
+        WHERE bot_id = $1 AND status IN ('NEW', 'PARTIALLY_FILLED')
+        ORDER BY created_at
+    `
+	return r.queryOrders(query, botID)
+}
+
+// GetOpenByAccount retorna as ordens ainda não finalizadas de todos os bots da conta.
+func (r *OrderRepository) GetOpenByAccount(accountID uuid.UUID) ([]entity.Order, error) {
+	query := `
+        SELECT id, account_id, bot_id, exchange_order_id, client_order_id, symbol, side, type, status,
+               quantity, price, executed_quantity, quote_quantity, fee, COALESCE(fee_asset, ''), paper, created_at, updated_at
+        FROM orders
+        WHERE account_id = $1 AND status IN ('NEW', 'PARTIALLY_FILLED')
+        ORDER BY created_at
+    `
+	return r.queryOrders(query, accountID)
+}
+
+func (r *OrderRepository) queryOrders(query string, args ...any) ([]entity.Order, error) {
+	rows, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []entity.Order
+	for rows.Next() {
+		var o entity.Order
+		err := rows.Scan(
+			&o.ID, &o.AccountID, &o.BotID, &o.ExchangeOrderID, &o.ClientOrderID, &o.Symbol, &o.Side, &o.Type, &o.Status,
+			&o.Quantity, &o.Price, &o.ExecutedQuantity, &o.QuoteQuantity, &o.Fee, &o.FeeAsset, &o.Paper, &o.CreatedAt, &o.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, rows.Err()
+}
@@ -0,0 +1,61 @@
+// internal/infra/repository/postgres/postgres_paper_ledger_repository.go
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type PaperLedgerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPaperLedgerRepository(db *pgxpool.Pool) *PaperLedgerRepository {
+	return &PaperLedgerRepository{db: db}
+}
+
+// Save grava um lançamento do ledger de paper trading.
+func (r *PaperLedgerRepository) Save(entry entity.PaperLedgerEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	query := `
+        INSERT INTO paper_ledger (id, account_id, order_id, symbol, asset, amount, balance, reason, created_at)
+        VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8, now())
+    `
+	_, err := r.db.Exec(context.Background(), query,
+		entry.ID, entry.AccountID, entry.OrderID, entry.Symbol,
+		entry.Asset, entry.Amount, entry.Balance, entry.Reason,
+	)
+	return err
+}
+
+// GetBalances retorna o saldo atual de cada ativo da conta (último lançamento por ativo).
+func (r *PaperLedgerRepository) GetBalances(accountID uuid.UUID) (map[string]float64, error) {
+	query := `
+        SELECT DISTINCT ON (asset) asset, balance
+        FROM paper_ledger
+        WHERE account_id = $1
+        ORDER BY asset, seq DESC
+    `
+	rows, err := r.db.Query(context.Background(), query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make(map[string]float64)
+	for rows.Next() {
+		var asset string
+		var balance float64
+		if err := rows.Scan(&asset, &balance); err != nil {
+			return nil, err
+		}
+		balances[asset] = balance
+	}
+	return balances, rows.Err()
+}
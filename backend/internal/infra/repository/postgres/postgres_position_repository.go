@@ -16,20 +16,22 @@ func NewPositionRepository(db *pgxpool.Pool) *PositionRepository {
 	return &PositionRepository{db: db}
 }
 
+// Save grava a posição. O encerramento pendente é mantido (ver RequestClose).
 func (r *PositionRepository) Save(p entity.OpenPosition) error {
 	query := `
-        INSERT INTO positions (id, bot_id, entry_price, timestamp)
-        VALUES ($1, $2, $3, $4)
-        ON CONFLICT (bot_id) DO UPDATE SET entry_price = EXCLUDED.entry_price, timestamp = EXCLUDED.timestamp
+        INSERT INTO positions (id, bot_id, entry_price, quantity, timestamp, highest_price, stop_price, stop_reason)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (bot_id) DO UPDATE SET entry_price = EXCLUDED.entry_price, quantity = EXCLUDED.quantity, timestamp = EXCLUDED.timestamp,
+            highest_price = EXCLUDED.highest_price, stop_price = EXCLUDED.stop_price, stop_reason = EXCLUDED.stop_reason
     `
 	_, err := r.db.Exec(context.Background(), query,
-		uuid.New(), p.BotID, p.EntryPrice, p.Timestamp,
+		uuid.New(), p.BotID, p.EntryPrice, p.Quantity, p.Timestamp, p.HighestPrice, p.StopPrice, p.StopReason,
 	)
 	return err
 }
 
 func (r *PositionRepository) GetAll() ([]entity.OpenPosition, error) {
-	query := `SELECT bot_id, entry_price, timestamp FROM positions`
+	query := `SELECT bot_id, entry_price, quantity, timestamp, highest_price, stop_price, stop_reason, close_requested FROM positions`
 	rows, err := r.db.Query(context.Background(), query)
 	if err != nil {
 		return nil, err
@@ -39,7 +41,7 @@ func (r *PositionRepository) GetAll() ([]entity.OpenPosition, error) {
 	var positions []entity.OpenPosition
 	for rows.Next() {
 		var p entity.OpenPosition
-		err := rows.Scan(&p.BotID, &p.EntryPrice, &p.Timestamp)
+		err := rows.Scan(&p.BotID, &p.EntryPrice, &p.Quantity, &p.Timestamp, &p.HighestPrice, &p.StopPrice, &p.StopReason, &p.CloseRequested)
 		if err != nil {
 			return nil, err
 		}
@@ -50,11 +52,11 @@ func (r *PositionRepository) GetAll() ([]entity.OpenPosition, error) {
 }
 
 func (r *PositionRepository) Get(botID uuid.UUID) (*entity.OpenPosition, error) {
-	query := `SELECT bot_id, entry_price, timestamp FROM positions WHERE bot_id = $1`
+	query := `SELECT bot_id, entry_price, quantity, timestamp, highest_price, stop_price, stop_reason, close_requested FROM positions WHERE bot_id = $1`
 	row := r.db.QueryRow(context.Background(), query, botID)
 
 	var p entity.OpenPosition
-	err := row.Scan(&p.BotID, &p.EntryPrice, &p.Timestamp)
+	err := row.Scan(&p.BotID, &p.EntryPrice, &p.Quantity, &p.Timestamp, &p.HighestPrice, &p.StopPrice, &p.StopReason, &p.CloseRequested)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +64,42 @@ func (r *PositionRepository) Get(botID uuid.UUID) (*entity.OpenPosition, error)
 	return &p, nil
 }
 
+// RequestClose marca a posição do bot com um encerramento forçado pendente; reason vazio
+// desfaz a marcação.
+func (r *PositionRepository) RequestClose(botID uuid.UUID, reason string) error {
+	query := `UPDATE positions SET close_requested = $2 WHERE bot_id = $1`
+	_, err := r.db.Exec(context.Background(), query, botID, reason)
+	return err
+}
+
 func (r *PositionRepository) Delete(botID uuid.UUID) error {
 	query := `DELETE FROM positions WHERE bot_id = $1`
 	_, err := r.db.Exec(context.Background(), query, botID)
 	return err
 }
+
+// GetByAccount retorna as posições abertas dos bots da conta, com o par de cada bot.
+func (r *PositionRepository) GetByAccount(accountID uuid.UUID) ([]entity.OpenPosition, error) {
+	query := `
+        SELECT p.bot_id, b.symbol, p.entry_price, p.quantity, p.timestamp, p.highest_price, p.stop_price, p.stop_reason, p.close_requested
+        FROM positions p
+        JOIN bots b ON b.id = p.bot_id
+        WHERE b.account_id = $1
+    `
+	rows, err := r.db.Query(context.Background(), query, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []entity.OpenPosition
+	for rows.Next() {
+		var p entity.OpenPosition
+		err := rows.Scan(&p.BotID, &p.Symbol, &p.EntryPrice, &p.Quantity, &p.Timestamp, &p.HighestPrice, &p.StopPrice, &p.StopReason, &p.CloseRequested)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+	return positions, rows.Err()
+}
@@ -0,0 +1,67 @@
+// internal/infra/repository/postgres/postgres_risk_limits_repository.go
+
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+type RiskLimitsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRiskLimitsRepository(db *pgxpool.Pool) *RiskLimitsRepository {
+	return &RiskLimitsRepository{db: db}
+}
+
+// Get retorna os limites de risco da conta, ou nil se nunca foram configurados.
+func (r *RiskLimitsRepository) Get(accountID uuid.UUID) (*entity.RiskLimits, error) {
+	query := `
+        SELECT account_id, max_open_positions, max_total_exposure, max_asset_exposure,
+               max_daily_loss, max_consecutive_losses, kill_switch, kill_switch_at, updated_at
+        FROM account_risk_limits
+        WHERE account_id = $1
+    `
+	var l entity.RiskLimits
+	err := r.db.QueryRow(context.Background(), query, accountID).Scan(
+		&l.AccountID, &l.MaxOpenPositions, &l.MaxTotalExposure, &l.MaxAssetExposure,
+		&l.MaxDailyLoss, &l.MaxConsecutiveLosses, &l.KillSwitch, &l.KillSwitchAt, &l.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Save cria ou atualiza os limites de risco da conta.
+func (r *RiskLimitsRepository) Save(l entity.RiskLimits) error {
+	query := `
+        INSERT INTO account_risk_limits (
+            account_id, max_open_positions, max_total_exposure, max_asset_exposure,
+            max_daily_loss, max_consecutive_losses, kill_switch, kill_switch_at, updated_at
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+        ON CONFLICT (account_id) DO UPDATE SET
+            max_open_positions = EXCLUDED.max_open_positions,
+            max_total_exposure = EXCLUDED.max_total_exposure,
+            max_asset_exposure = EXCLUDED.max_asset_exposure,
+            max_daily_loss = EXCLUDED.max_daily_loss,
+            max_consecutive_losses = EXCLUDED.max_consecutive_losses,
+            kill_switch = EXCLUDED.kill_switch,
+            kill_switch_at = EXCLUDED.kill_switch_at,
+            updated_at = now()
+    `
+	_, err := r.db.Exec(context.Background(), query,
+		l.AccountID, l.MaxOpenPositions, l.MaxTotalExposure, l.MaxAssetExposure,
+		l.MaxDailyLoss, l.MaxConsecutiveLosses, l.KillSwitch, l.KillSwitchAt,
+	)
+	return err
+}
@@ -0,0 +1,198 @@
+// internal/infra/secrets/cipher.go
+
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Formato dos valores cifrados: enc:v1:<key_id>:<chave de dados cifrada>:<segredo cifrado>.
+// Cada segredo recebe uma chave de dados (DEK) aleatória; a DEK é cifrada com a chave
+// mestra identificada por key_id (envelope encryption). Ambos usam AES-256-GCM com o
+// nonce prefixado ao texto cifrado.
+const (
+	prefix       = "enc:v1:"
+	dataKeyBytes = 32
+)
+
+// ErrUnknownKey indica um valor cifrado com uma chave mestra que não está configurada.
+var ErrUnknownKey = errors.New("chave mestra desconhecida")
+
+// Cipher cifra e decifra segredos com a chave mestra ativa. Chaves anteriores continuam
+// disponíveis apenas para decifrar, permitindo a rotação (ver cmd/rotate-secrets).
+type Cipher struct {
+	activeID string
+	keys     map[string][]byte
+}
+
+// NewCipher cria um Cipher a partir das chaves mestras (32 bytes cada), indexadas pelo ID.
+func NewCipher(activeID string, keys map[string][]byte) (*Cipher, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, fmt.Errorf("chave mestra ativa %q não informada", activeID)
+	}
+	for id, key := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("ID de chave mestra inválido: %q", id)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("chave mestra %q deve ter 32 bytes (tem %d)", id, len(key))
+		}
+	}
+	return &Cipher{activeID: activeID, keys: keys}, nil
+}
+
+// NewCipherFromEnv lê as chaves mestras do ambiente:
+//
+//	SECRETS_MASTER_KEY      chave ativa, 32 bytes em base64
+//	SECRETS_MASTER_KEY_ID   ID da chave ativa (padrão "v1")
+//	SECRETS_PREVIOUS_KEYS   chaves antigas, "id:base64,id:base64", usadas só para decifrar
+func NewCipherFromEnv() (*Cipher, error) {
+	activeID := os.Getenv("SECRETS_MASTER_KEY_ID")
+	if activeID == "" {
+		activeID = "v1"
+	}
+
+	encoded := os.Getenv("SECRETS_MASTER_KEY")
+	if encoded == "" {
+		return nil, errors.New("SECRETS_MASTER_KEY não configurada")
+	}
+	active, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("SECRETS_MASTER_KEY inválida: %w", err)
+	}
+
+	keys := map[string][]byte{activeID: active}
+	if previous := os.Getenv("SECRETS_PREVIOUS_KEYS"); previous != "" {
+		for _, entry := range strings.Split(previous, ",") {
+			id, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+			if !ok {
+				return nil, fmt.Errorf("SECRETS_PREVIOUS_KEYS inválida: %q", entry)
+			}
+			key, err := base64.StdEncoding.DecodeString(value)
+			if err != nil {
+				return nil, fmt.Errorf("SECRETS_PREVIOUS_KEYS inválida para %q: %w", id, err)
+			}
+			if _, exists := keys[id]; !exists {
+				keys[id] = key
+			}
+		}
+	}
+
+	return NewCipher(activeID, keys)
+}
+
+// Encrypt cifra o segredo com uma nova chave de dados, protegida pela chave mestra ativa.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	dataKey := make([]byte, dataKeyBytes)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("erro ao gerar chave de dados: %w", err)
+	}
+
+	wrappedKey, err := seal(c.keys[c.activeID], dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + c.activeID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decifra um valor gerado por Encrypt. Valores sem o prefixo de cifra
+// (legados, gravados em texto puro) são retornados sem alteração.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", errors.New("segredo cifrado em formato inválido")
+	}
+
+	masterKey, ok := c.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownKey, parts[0])
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("chave de dados em formato inválido: %w", err)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("segredo cifrado em formato inválido: %w", err)
+	}
+
+	dataKey, err := open(masterKey, wrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("erro ao decifrar chave de dados: %w", err)
+	}
+	plaintext, err := open(dataKey, sealed)
+	if err != nil {
+		return "", fmt.Errorf("erro ao decifrar segredo: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation informa se o valor está em texto puro ou cifrado com uma chave que não é a ativa.
+func (c *Cipher) NeedsRotation(value string) bool {
+	if !IsEncrypted(value) {
+		return true
+	}
+	id, _, _ := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	return id != c.activeID
+}
+
+// ActiveKeyID retorna o ID da chave mestra usada para cifrar.
+func (c *Cipher) ActiveKeyID() string {
+	return c.activeID
+}
+
+// IsEncrypted informa se o valor foi gerado por Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("erro ao gerar nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("texto cifrado muito curto")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cifra AES: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
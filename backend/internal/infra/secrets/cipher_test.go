@@ -0,0 +1,58 @@
+// internal/infra/secrets/cipher_test.go
+
+package secrets_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/infra/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	c, err := secrets.NewCipher("v1", map[string][]byte{"v1": bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+
+	encrypted, err := c.Encrypt("meu-segredo-binance")
+	require.NoError(t, err)
+	assert.True(t, secrets.IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "meu-segredo-binance")
+
+	plaintext, err := c.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "meu-segredo-binance", plaintext)
+
+	// Valores legados em texto puro passam direto
+	legacy, err := c.Decrypt("texto-puro")
+	require.NoError(t, err)
+	assert.Equal(t, "texto-puro", legacy)
+}
+
+func TestRotationKeepsPreviousKeysReadable(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)
+
+	old, err := secrets.NewCipher("v1", map[string][]byte{"v1": oldKey})
+	require.NoError(t, err)
+	encrypted, err := old.Encrypt("segredo")
+	require.NoError(t, err)
+
+	rotated, err := secrets.NewCipher("v2", map[string][]byte{"v1": oldKey, "v2": newKey})
+	require.NoError(t, err)
+	assert.True(t, rotated.NeedsRotation(encrypted))
+
+	plaintext, err := rotated.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "segredo", plaintext)
+
+	reencrypted, err := rotated.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.False(t, rotated.NeedsRotation(reencrypted))
+
+	// Sem a chave antiga o valor antigo não pode ser lido
+	onlyNew, err := secrets.NewCipher("v2", map[string][]byte{"v2": newKey})
+	require.NoError(t, err)
+	_, err = onlyNew.Decrypt(encrypted)
+	assert.ErrorIs(t, err, secrets.ErrUnknownKey)
+}
@@ -53,6 +53,10 @@ func InitLogger() {
 	if strings.Contains(os.Getenv("APP_MODE"), "dev") {
 		level = slog.LevelDebug
 	}
+	// LOG_LEVEL (debug, info, warn, error) tem precedência sobre o APP_MODE
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		_ = level.UnmarshalText([]byte(v))
+	}
 
 	// Definir saída JSON ou texto legível com base no ambiente
 	var handler slog.Handler
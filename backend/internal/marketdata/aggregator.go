@@ -0,0 +1,142 @@
+// internal/marketdata/aggregator.go
+
+package marketdata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// BaseInterval é o intervalo dos candles usados para montar os intervalos agregados.
+const BaseInterval = "1m"
+
+// weekOffset alinha semanas à segunda-feira, como a Binance (01/01/1970 foi uma quinta-feira).
+const weekOffset = int64(4 * 24 * time.Hour / time.Millisecond)
+
+// SourceInterval retorna o intervalo a assinar ou buscar na exchange para obter interval: o
+// próprio intervalo, se a Binance o oferece, ou BaseInterval, se ele for agregado.
+func SourceInterval(interval string) string {
+	if utils.IsAggregatedInterval(interval) {
+		return BaseInterval
+	}
+	return interval
+}
+
+// Aggregator monta candles de um intervalo maior a partir de candles fechados de um intervalo
+// base, com os períodos alinhados ao UTC (ex: 10m fecha em :09:59.999, :19:59.999...) e os
+// volumes e negócios somados. Um candle agregado só é emitido quando todos os candles base do
+// período foram recebidos; períodos com candles faltando são descartados, para que o
+// consumidor detecte a lacuna.
+type Aggregator struct {
+	interval string
+	base     int64 // duração do candle base (ms)
+	period   int64 // duração do candle agregado (ms)
+	offset   int64 // deslocamento do alinhamento (ms)
+
+	current  entity.Candle // período em formação
+	count    int64         // candles base recebidos no período
+	lastOpen int64         // abertura do último candle base recebido
+}
+
+// NewAggregator cria um agregador de candles base para interval, que deve ser múltiplo de base.
+func NewAggregator(base, interval string) (*Aggregator, error) {
+	baseDuration, ok := utils.IntervalDuration(base)
+	if !ok {
+		return nil, fmt.Errorf("intervalo base inválido: %s", base)
+	}
+	period, ok := utils.IntervalDuration(interval)
+	if !ok || interval == "1M" {
+		return nil, fmt.Errorf("intervalo inválido para agregação: %s", interval)
+	}
+	if period < baseDuration || period%baseDuration != 0 {
+		return nil, fmt.Errorf("%s não é múltiplo de %s", interval, base)
+	}
+
+	var offset int64
+	if period%(7*24*time.Hour) == 0 {
+		offset = weekOffset
+	}
+	return &Aggregator{
+		interval: interval,
+		base:     baseDuration.Milliseconds(),
+		period:   period.Milliseconds(),
+		offset:   offset,
+	}, nil
+}
+
+// Interval retorna o intervalo dos candles agregados.
+func (a *Aggregator) Interval() string {
+	return a.interval
+}
+
+// Add recebe o próximo candle base fechado e retorna o candle agregado quando ele completa um
+// período. Candles repetidos ou fora de ordem são ignorados.
+func (a *Aggregator) Add(candle entity.Candle) (entity.Candle, bool) {
+	if candle.OpenTime <= a.lastOpen && a.lastOpen != 0 {
+		return entity.Candle{}, false
+	}
+	a.lastOpen = candle.OpenTime
+
+	start := a.periodStart(candle.OpenTime)
+	if a.count == 0 || a.current.OpenTime != start {
+		a.current = entity.Candle{
+			Open:     candle.Open,
+			High:     candle.High,
+			Low:      candle.Low,
+			OpenTime: start,
+		}
+		a.count = 0
+	}
+
+	a.current.High = max(a.current.High, candle.High)
+	a.current.Low = min(a.current.Low, candle.Low)
+	a.current.Close = candle.Close
+	a.current.Volume += candle.Volume
+	a.current.QuoteVolume += candle.QuoteVolume
+	a.current.Trades += candle.Trades
+	a.current.TakerBuyVolume += candle.TakerBuyVolume
+	a.current.TakerBuyQuoteVolume += candle.TakerBuyQuoteVolume
+	a.count++
+
+	if a.count < a.period/a.base {
+		return entity.Candle{}, false
+	}
+
+	aggregated := a.current
+	aggregated.CloseTime = start + a.period - 1
+	aggregated.Time = aggregated.CloseTime / 1000
+	a.count = 0
+	return aggregated, true
+}
+
+// periodStart retorna a abertura (ms) do período que contém openTime.
+func (a *Aggregator) periodStart(openTime int64) int64 {
+	shifted := openTime - a.offset
+	start := shifted - shifted%a.period
+	if shifted%a.period < 0 {
+		start -= a.period
+	}
+	return start + a.offset
+}
+
+// Aggregate monta os candles de interval a partir de candles fechados de base em ordem
+// cronológica (ex: backtests). Se interval for o próprio base, os candles são devolvidos.
+func Aggregate(base, interval string, candles []entity.Candle) ([]entity.Candle, error) {
+	if base == interval {
+		return candles, nil
+	}
+	aggregator, err := NewAggregator(base, interval)
+	if err != nil {
+		return nil, err
+	}
+	var aggregated []entity.Candle
+	for _, c := range candles {
+		if out, ok := aggregator.Add(c); ok {
+			aggregated = append(aggregated, out)
+		}
+	}
+	return aggregated, nil
+}
@@ -0,0 +1,107 @@
+// internal/marketdata/aggregator_test.go
+
+package marketdata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// minuteCandles gera n candles de 1m a partir de start, com close = índice + 1.
+func minuteCandles(start time.Time, n int) []entity.Candle {
+	candles := make([]entity.Candle, n)
+	for i := range candles {
+		open := start.Add(time.Duration(i) * time.Minute).UnixMilli()
+		price := float64(i + 1)
+		candles[i] = entity.Candle{
+			Open:      price - 0.5,
+			High:      price + 1,
+			Low:       price - 1,
+			Close:     price,
+			Volume:    1,
+			Time:      (open + 59_999) / 1000,
+			OpenTime:  open,
+			CloseTime: open + 59_999,
+		}
+	}
+	return candles
+}
+
+func TestAggregateAlignsToUTC(t *testing.T) {
+	// Começa às 00:07: o primeiro período de 10m (00:00-00:09) está incompleto e é descartado
+	start := time.Date(2024, 3, 1, 0, 7, 0, 0, time.UTC)
+	candles, err := marketdata.Aggregate("1m", "10m", minuteCandles(start, 35))
+	require.NoError(t, err)
+
+	// Períodos completos: 00:10, 00:20, 00:30 (00:40 tem apenas 2 candles e ainda não fechou)
+	require.Len(t, candles, 3)
+	first := candles[0]
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 10, 0, 0, time.UTC).UnixMilli(), first.OpenTime)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 20, 0, 0, time.UTC).UnixMilli()-1, first.CloseTime)
+	assert.Equal(t, first.CloseTime/1000, first.Time)
+	assert.Equal(t, 3.5, first.Open, "abertura do primeiro minuto (00:10)")
+	assert.Equal(t, 13.0, first.Close, "fechamento do último minuto (00:19)")
+	assert.Equal(t, 14.0, first.High)
+	assert.Equal(t, 3.0, first.Low)
+	assert.Equal(t, 10.0, first.Volume)
+	assert.Equal(t, first.CloseTime+1, candles[1].OpenTime)
+}
+
+func TestAggregatorDropsIncompletePeriods(t *testing.T) {
+	aggregator, err := marketdata.NewAggregator("1m", "3m")
+	require.NoError(t, err)
+
+	candles := minuteCandles(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 6)
+	var out []entity.Candle
+	for i, c := range candles {
+		if i == 1 {
+			continue // minuto perdido no primeiro período
+		}
+		if agg, ok := aggregator.Add(c); ok {
+			out = append(out, agg)
+		}
+		_, ok := aggregator.Add(c)
+		assert.False(t, ok, "candle repetido é ignorado")
+	}
+
+	require.Len(t, out, 1)
+	assert.Equal(t, candles[3].OpenTime, out[0].OpenTime)
+	assert.Equal(t, candles[5].CloseTime, out[0].CloseTime)
+}
+
+func TestAggregatorWeeksStartOnMonday(t *testing.T) {
+	aggregator, err := marketdata.NewAggregator("1d", "1w")
+	require.NoError(t, err)
+
+	// 2024-03-04 é uma segunda-feira
+	var out []entity.Candle
+	for day := 0; day < 10; day++ {
+		open := time.Date(2024, 3, 1+day, 0, 0, 0, 0, time.UTC).UnixMilli()
+		c := entity.Candle{Open: 1, High: 2, Low: 0.5, Close: 1.5, OpenTime: open, CloseTime: open + 86_399_999}
+		if agg, ok := aggregator.Add(c); ok {
+			out = append(out, agg)
+		}
+	}
+	require.Len(t, out, 1)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC).UnixMilli(), out[0].OpenTime)
+}
+
+func TestNewAggregatorValidatesIntervals(t *testing.T) {
+	// Semanas e meses não são agregados: só os nativos 1w e 1M são aceitos pela Binance
+	for _, interval := range []string{"90s", "1M", "2M", "3M", "2w", "7d", "10080m", "abc", "0m"} {
+		_, err := marketdata.NewAggregator("1m", interval)
+		assert.Error(t, err, interval)
+	}
+	_, err := marketdata.NewAggregator("3m", "10m")
+	assert.Error(t, err, "10m não é múltiplo de 3m")
+
+	assert.Equal(t, "1m", marketdata.SourceInterval("10m"))
+	assert.Equal(t, "1h", marketdata.SourceInterval("1h"))
+	assert.Equal(t, "1m", marketdata.SourceInterval("2d"))
+	assert.Equal(t, "2w", marketdata.SourceInterval("2w"), "intervalo não agregável")
+}
@@ -0,0 +1,90 @@
+// internal/marketdata/series.go
+
+package marketdata
+
+import "github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+
+// CandleSeries é uma janela circular de tamanho fixo de candles fechados: incluir um candle
+// é O(1) e, cheia a janela, descarta o mais antigo sem realocar memória.
+type CandleSeries struct {
+	buf   []entity.Candle
+	start int // posição do candle mais antigo em buf
+	n     int
+}
+
+// NewCandleSeries cria uma série com capacidade para capacity candles (mínimo 1).
+func NewCandleSeries(capacity int) *CandleSeries {
+	return &CandleSeries{buf: make([]entity.Candle, max(capacity, 1))}
+}
+
+// Push inclui o candle mais recente. Com a série cheia, retorna o candle descartado.
+func (s *CandleSeries) Push(candle entity.Candle) (evicted entity.Candle, ok bool) {
+	if s.n < len(s.buf) {
+		s.buf[(s.start+s.n)%len(s.buf)] = candle
+		s.n++
+		return entity.Candle{}, false
+	}
+	evicted = s.buf[s.start]
+	s.buf[s.start] = candle
+	s.start = (s.start + 1) % len(s.buf)
+	return evicted, true
+}
+
+// Len retorna a quantidade de candles na série.
+func (s *CandleSeries) Len() int {
+	return s.n
+}
+
+// Cap retorna a capacidade da série.
+func (s *CandleSeries) Cap() int {
+	return len(s.buf)
+}
+
+// At retorna o i-ésimo candle, do mais antigo (0) para o mais recente (Len()-1).
+func (s *CandleSeries) At(i int) entity.Candle {
+	if i < 0 || i >= s.n {
+		panic("marketdata: índice fora da série")
+	}
+	return s.buf[(s.start+i)%len(s.buf)]
+}
+
+// Last retorna o candle mais recente.
+func (s *CandleSeries) Last() (entity.Candle, bool) {
+	if s.n == 0 {
+		return entity.Candle{}, false
+	}
+	return s.At(s.n - 1), true
+}
+
+// Slice retorna uma cópia dos candles em ordem cronológica.
+func (s *CandleSeries) Slice() []entity.Candle {
+	candles := make([]entity.Candle, s.n)
+	for i := range candles {
+		candles[i] = s.At(i)
+	}
+	return candles
+}
+
+// Closes retorna os preços de fechamento em ordem cronológica.
+func (s *CandleSeries) Closes() []float64 {
+	closes := make([]float64, s.n)
+	for i := range closes {
+		closes[i] = s.At(i).Close
+	}
+	return closes
+}
+
+// Resize altera a capacidade da série, mantendo os candles mais recentes.
+func (s *CandleSeries) Resize(capacity int) {
+	capacity = max(capacity, 1)
+	if capacity == len(s.buf) {
+		return
+	}
+	candles := s.Slice()
+	if len(candles) > capacity {
+		candles = candles[len(candles)-capacity:]
+	}
+	s.buf = make([]entity.Candle, capacity)
+	s.start = 0
+	s.n = copy(s.buf, candles)
+}
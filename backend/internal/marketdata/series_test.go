@@ -0,0 +1,56 @@
+// internal/marketdata/series_test.go
+
+package marketdata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleSeriesEvictsOldest(t *testing.T) {
+	candles := minuteCandles(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 5)
+	series := marketdata.NewCandleSeries(3)
+
+	for i, c := range candles {
+		evicted, ok := series.Push(c)
+		if i < 3 {
+			assert.False(t, ok)
+			continue
+		}
+		require.True(t, ok)
+		assert.Equal(t, candles[i-3], evicted)
+	}
+
+	assert.Equal(t, 3, series.Len())
+	assert.Equal(t, candles[2:], series.Slice())
+	assert.Equal(t, []float64{3, 4, 5}, series.Closes())
+	assert.Equal(t, candles[2], series.At(0))
+
+	last, ok := series.Last()
+	require.True(t, ok)
+	assert.Equal(t, candles[4], last)
+	assert.Panics(t, func() { series.At(3) })
+}
+
+func TestCandleSeriesResizeKeepsMostRecent(t *testing.T) {
+	candles := minuteCandles(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 5)
+	series := marketdata.NewCandleSeries(4)
+	for _, c := range candles {
+		series.Push(c)
+	}
+
+	series.Resize(2)
+	assert.Equal(t, 2, series.Cap())
+	assert.Equal(t, []float64{4, 5}, series.Closes())
+
+	series.Resize(4)
+	series.Push(candles[0])
+	assert.Equal(t, []float64{4, 5, 1}, series.Closes())
+
+	_, ok := marketdata.NewCandleSeries(0).Last()
+	assert.False(t, ok)
+}
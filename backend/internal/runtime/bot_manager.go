@@ -0,0 +1,451 @@
+// internal/runtime/bot_manager.go
+
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	serverws "github.com/jeancarlosdanese/crypto-bot/internal/server/ws"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+// Status de execução de um bot.
+const (
+	BotStatusRunning = "running"
+	BotStatusPaused  = "paused"
+	BotStatusStopped = "stopped"
+)
+
+// StreamFactory cria o stream de mercado que alimenta uma estratégia.
+type StreamFactory func(strategy *usecases.StrategyUseCase) services.StreamService
+
+// ExchangeProvider resolve o serviço de exchange usado pelos bots de uma conta
+// no modo de negociação informado (entity.TradingModePaper ou entity.TradingModeLive).
+type ExchangeProvider func(account entity.Account, tradingMode string) (services.ExchangeService, error)
+
+// runningBot guarda o que foi aplicado a um bot em execução, para detectar mudanças.
+type runningBot struct {
+	account  entity.Account
+	exchange services.ExchangeService
+	bot      entity.Bot
+	configID uuid.UUID
+	config   map[string]any
+	strategy *usecases.StrategyUseCase
+	stream   services.StreamService
+}
+
+// BotManager controla o ciclo de vida dos bots em execução (estratégia + stream)
+// e mantém o BotsMap sincronizado. Cada bot carrega a própria conta e o serviço de
+// exchange dela, de modo que contas diferentes não compartilham estado de execução.
+type BotManager struct {
+	mu            sync.Mutex
+	running       map[uuid.UUID]*runningBot
+	botConfigRepo repository.BotConfigRepository
+	positionRepo  repository.PositionRepository
+	decisionRepo  repository.DecisionLogRepository
+	executionRepo repository.ExecutionLogRepository
+	orderManager  *usecases.OrderManager
+	riskGuard     *usecases.RiskGuard
+	exchangeFor   ExchangeProvider
+	streamFactory StreamFactory
+	windowSize    int
+}
+
+// NewBotManager cria um gerenciador de bots.
+func NewBotManager(
+	botConfigRepo repository.BotConfigRepository,
+	positionRepo repository.PositionRepository,
+	decisionRepo repository.DecisionLogRepository,
+	executionRepo repository.ExecutionLogRepository,
+	orderManager *usecases.OrderManager,
+	riskGuard *usecases.RiskGuard,
+	exchangeFor ExchangeProvider,
+	streamFactory StreamFactory,
+	windowSize int,
+) *BotManager {
+	return &BotManager{
+		running:       make(map[uuid.UUID]*runningBot),
+		botConfigRepo: botConfigRepo,
+		positionRepo:  positionRepo,
+		decisionRepo:  decisionRepo,
+		executionRepo: executionRepo,
+		orderManager:  orderManager,
+		riskGuard:     riskGuard,
+		exchangeFor:   exchangeFor,
+		streamFactory: streamFactory,
+		windowSize:    windowSize,
+	}
+}
+
+// IsRunning informa se o bot está em execução.
+func (m *BotManager) IsRunning(botID uuid.UUID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.running[botID]
+	return ok
+}
+
+// Status retorna o status de execução do bot (running, paused ou stopped).
+func (m *BotManager) Status(botID uuid.UUID) string {
+	m.mu.Lock()
+	rb, ok := m.running[botID]
+	m.mu.Unlock()
+
+	switch {
+	case !ok:
+		return BotStatusStopped
+	case rb.strategy.IsPaused():
+		return BotStatusPaused
+	default:
+		return BotStatusRunning
+	}
+}
+
+// SetPaused pausa ou retoma a avaliação de um bot em execução. A persistência fica com quem
+// chama (bots.paused); Reconcile e startLocked restauram o estado gravado.
+func (m *BotManager) SetPaused(botID uuid.UUID, paused bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rb, ok := m.running[botID]
+	if !ok {
+		return fmt.Errorf("bot %s não está em execução", botID)
+	}
+	rb.strategy.SetPaused(paused)
+	rb.bot.Paused = paused
+
+	logger.Info("⏯️ Estado de pausa alterado", "bot_id", botID.String(), "symbol", rb.bot.Symbol, "paused", paused)
+	return nil
+}
+
+// RunningBotIDs retorna os IDs dos bots em execução.
+func (m *BotManager) RunningBotIDs() []uuid.UUID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(m.running))
+	for id := range m.running {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// StartBot resolve a estratégia e a configuração do bot, restaura a posição aberta
+// e inicia o stream de mercado. Estratégias desconhecidas retornam erro.
+func (m *BotManager) StartBot(account entity.Account, bot entity.Bot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.running[bot.ID]; ok {
+		return fmt.Errorf("bot %s já está em execução", bot.ID)
+	}
+	return m.startLocked(account, bot)
+}
+
+func (m *BotManager) startLocked(account entity.Account, bot entity.Bot) error {
+	impl, err := usecases.GetStrategy(bot.StrategyName)
+	if err != nil {
+		return err
+	}
+
+	// ⚙️ Configuração dinâmica do bot (bot_configs)
+	rb := &runningBot{account: account, bot: bot}
+	cfg, err := m.botConfigRepo.GetLatest(bot.ID)
+	if err != nil {
+		logger.Error("Erro ao carregar configuração do bot", err, "bot_id", bot.ID.String())
+	} else if cfg != nil {
+		rb.configID = cfg.ID
+		rb.config = cfg.Config
+	}
+
+	mode := entity.TradingMode(rb.config)
+	exchange, err := m.exchangeFor(account, mode)
+	if err != nil {
+		return fmt.Errorf("erro ao obter exchange da conta %s: %w", account.ID, err)
+	}
+	rb.exchange = exchange
+
+	strategy := usecases.NewStrategyUseCase(account, bot, impl, exchange, m.decisionRepo, m.executionRepo, m.positionRepo, m.windowSize)
+	strategy.OrderManager = m.orderManager
+	strategy.RiskGuard = m.riskGuard
+	strategy.SetPaused(bot.Paused)
+	if err := strategy.SetParams(rb.config); err != nil {
+		return fmt.Errorf("configuração inválida do bot %s: %w", bot.ID, err)
+	}
+	rb.strategy = strategy
+
+	logger.Info("⚙️ Estratégia configurada",
+		"account_id", account.ID.String(),
+		"bot_id", bot.ID.String(),
+		"symbol", bot.Symbol,
+		"strategy", impl.Name(),
+		"trading_mode", mode,
+		"params", strategy.Params,
+	)
+
+	if pos, _ := m.positionRepo.Get(bot.ID); pos != nil {
+		if exits.Reason(pos.CloseRequested) == exits.ReasonKillSwitch && !m.killSwitchActive(account.ID) {
+			logger.Info("🔓 Kill switch desativado, encerramento da posição cancelado", "bot_id", bot.ID.String(), "symbol", bot.Symbol)
+			if err := m.positionRepo.RequestClose(bot.ID, ""); err != nil {
+				logger.Error("Erro ao desmarcar encerramento da posição", err, "bot_id", bot.ID.String())
+			}
+			pos.CloseRequested = ""
+		}
+		strategy.RestorePosition(*pos)
+		logger.Info(fmt.Sprintf("🔁 [%s] Posição reaberta a %.2f", bot.Symbol, pos.EntryPrice), "quantity", pos.Quantity)
+	}
+
+	// 🧾 Ordem enviada antes do reinício continua sendo acompanhada
+	if m.orderManager != nil {
+		orders, err := m.orderManager.OpenOrders(bot.ID)
+		if err != nil {
+			logger.Error("Erro ao carregar ordens abertas do bot", err, "bot_id", bot.ID.String())
+		} else if len(orders) > 0 {
+			strategy.PendingOrder = &orders[len(orders)-1]
+			logger.Info("🧾 Ordem pendente restaurada", "bot_id", bot.ID.String(), "exchange_order_id", strategy.PendingOrder.ExchangeOrderID)
+		}
+	}
+
+	// 🧯 Encerramento pedido enquanto o bot estava parado (repetido nas avaliações até executar)
+	if reason := strategy.CloseRequested(); reason != "" && !strategy.RequestClose(reason) {
+		logger.Warn("⚠️ Posição marcada para encerramento ainda não foi encerrada", "bot_id", bot.ID.String(), "symbol", bot.Symbol, "reason", reason)
+	}
+
+	// Salvar no mapa global
+	BotsMap.Lock()
+	BotsMap.Items[bot.ID] = strategy
+	BotsMap.Unlock()
+
+	rb.stream = m.streamFactory(strategy)
+	m.running[bot.ID] = rb
+
+	// O download do histórico é lento; o stream sobe em paralelo
+	go func() {
+		if err := rb.stream.Start(bot.Symbol, bot.Interval); err != nil {
+			logger.Error("Erro ao iniciar stream do bot", err, "bot_id", bot.ID.String(), "symbol", bot.Symbol)
+		}
+	}()
+	return nil
+}
+
+// StopBot encerra o stream do bot e o remove do BotsMap.
+func (m *BotManager) StopBot(botID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopLocked(botID)
+}
+
+func (m *BotManager) stopLocked(botID uuid.UUID) {
+	rb, ok := m.running[botID]
+	if !ok {
+		return
+	}
+
+	rb.stream.StopAll()
+	delete(m.running, botID)
+
+	BotsMap.Lock()
+	delete(BotsMap.Items, botID)
+	BotsMap.Unlock()
+
+	logger.Info("⏹️ Bot parado", "bot_id", botID.String(), "symbol", rb.bot.Symbol)
+}
+
+// Reconcile compara o bot (e sua configuração mais recente) com o que está em execução:
+// inicia bots ativados, para bots desativados, reinicia o stream quando par/intervalo mudam
+// e aplica estratégia/parâmetros a quente nos demais casos.
+func (m *BotManager) Reconcile(account entity.Account, bot entity.Bot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rb, running := m.running[bot.ID]
+
+	switch {
+	case !running && bot.Active:
+		return m.startLocked(account, bot)
+
+	case running && !bot.Active:
+		m.stopLocked(bot.ID)
+		return nil
+
+	case !running:
+		return nil
+
+	case rb.bot.Symbol != bot.Symbol || rb.bot.Interval != bot.Interval:
+		logger.Info("🔄 Par ou intervalo alterado, reiniciando stream",
+			"bot_id", bot.ID.String(),
+			"de", rb.bot.Symbol+"@"+rb.bot.Interval,
+			"para", bot.Symbol+"@"+bot.Interval,
+		)
+		m.stopLocked(bot.ID)
+		if err := m.startLocked(account, bot); err != nil {
+			return err
+		}
+		m.publishConfigApplied(m.running[bot.ID], true)
+		return nil
+	}
+
+	cfg, err := m.botConfigRepo.GetLatest(bot.ID)
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração do bot %s: %w", bot.ID, err)
+	}
+	configID := uuid.Nil
+	var config map[string]any
+	if cfg != nil {
+		configID = cfg.ID
+		config = cfg.Config
+	}
+
+	if !reflect.DeepEqual(rb.account, account) {
+		rb.strategy.SetAccount(account)
+		rb.account = account
+	}
+
+	// 🔑 Credenciais ou modo (paper/live) alterados: a fábrica devolve outro serviço
+	mode := entity.TradingMode(config)
+	if exchange, err := m.exchangeFor(account, mode); err != nil {
+		logger.Error("Erro ao obter exchange da conta", err, "account_id", account.ID.String())
+	} else if exchange != rb.exchange {
+		rb.strategy.SetExchange(exchange)
+		rb.exchange = exchange
+		logger.Info("🔑 Exchange do bot atualizada",
+			"account_id", account.ID.String(),
+			"bot_id", bot.ID.String(),
+			"trading_mode", mode,
+		)
+	}
+
+	// ⏯️ Pausa persistida no bot (alterada pela API ou direto no banco)
+	if rb.bot.Paused != bot.Paused {
+		rb.strategy.SetPaused(bot.Paused)
+		rb.bot.Paused = bot.Paused
+		logger.Info("⏯️ Estado de pausa alterado", "bot_id", bot.ID.String(), "symbol", bot.Symbol, "paused", bot.Paused)
+	}
+
+	if rb.bot == bot && rb.configID == configID && reflect.DeepEqual(rb.config, config) {
+		return nil
+	}
+
+	impl, err := usecases.GetStrategy(bot.StrategyName)
+	if err != nil {
+		return err
+	}
+
+	if err := rb.strategy.ApplyConfig(bot, impl, config); err != nil {
+		return fmt.Errorf("configuração inválida do bot %s: %w", bot.ID, err)
+	}
+	rb.bot = bot
+	rb.configID = configID
+	rb.config = config
+
+	logger.Info("⚙️ Configuração aplicada a quente",
+		"bot_id", bot.ID.String(),
+		"symbol", bot.Symbol,
+		"strategy", impl.Name(),
+		"params", rb.strategy.CurrentParams(),
+	)
+	m.publishConfigApplied(rb, false)
+	return nil
+}
+
+// StopAccount para todos os bots em execução de uma conta.
+func (m *BotManager) StopAccount(accountID uuid.UUID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, rb := range m.running {
+		if rb.account.ID == accountID {
+			m.stopLocked(id)
+		}
+	}
+}
+
+// CloseAccountPositions encerra a mercado as posições abertas dos bots em execução da conta.
+// Todas as posições ficam marcadas com o motivo até a saída executar: bots em execução repetem
+// a saída a cada avaliação e bots parados a disparam ao voltar a rodar (ver startLocked).
+// Retorna quantas saídas foram disparadas e quantas posições de bots parados ficaram marcadas.
+func (m *BotManager) CloseAccountPositions(accountID uuid.UUID, reason exits.Reason) (closed, flagged int) {
+	m.mu.Lock()
+	var strategies []*usecases.StrategyUseCase
+	for _, rb := range m.running {
+		if rb.account.ID == accountID {
+			strategies = append(strategies, rb.strategy)
+		}
+	}
+	positions, err := m.positionRepo.GetByAccount(accountID)
+	if err != nil {
+		logger.Error("Erro ao carregar posições da conta", err, "account_id", accountID.String())
+	}
+	for _, pos := range positions {
+		if _, running := m.running[pos.BotID]; running {
+			continue
+		}
+		if err := m.positionRepo.RequestClose(pos.BotID, string(reason)); err != nil {
+			logger.Error("Erro ao marcar posição de bot parado", err, "bot_id", pos.BotID.String())
+			continue
+		}
+		flagged++
+	}
+	m.mu.Unlock()
+
+	for _, strategy := range strategies {
+		if strategy.RequestClose(reason) {
+			closed++
+		}
+	}
+	logger.Info("🧯 Posições da conta encerradas", "account_id", accountID.String(), "reason", reason, "closed", closed, "flagged", flagged)
+	return closed, flagged
+}
+
+// killSwitchActive informa se o kill switch da conta continua ligado. Sem os limites de
+// risco (ou com erro ao lê-los) o encerramento pendente é mantido.
+func (m *BotManager) killSwitchActive(accountID uuid.UUID) bool {
+	if m.riskGuard == nil {
+		return true
+	}
+	limits, err := m.riskGuard.Limits(accountID)
+	if err != nil {
+		logger.Error("Erro ao carregar limites de risco da conta", err, "account_id", accountID.String())
+		return true
+	}
+	return limits.KillSwitch
+}
+
+// StopMissing para os bots em execução que não estão mais presentes em botIDs.
+func (m *BotManager) StopMissing(botIDs map[uuid.UUID]bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id := range m.running {
+		if !botIDs[id] {
+			m.stopLocked(id)
+		}
+	}
+}
+
+// publishConfigApplied avisa o frontend (via WebSocket do bot) que a configuração foi aplicada.
+func (m *BotManager) publishConfigApplied(rb *runningBot, restarted bool) {
+	if rb == nil {
+		return
+	}
+	serverws.Publish(rb.bot.ID.String(), serverws.Event{
+		Type:   "config_applied",
+		Symbol: rb.bot.Symbol,
+		Data: map[string]interface{}{
+			"bot_id":    rb.bot.ID.String(),
+			"interval":  rb.bot.Interval,
+			"strategy":  rb.bot.StrategyName,
+			"config_id": rb.configID.String(),
+			"params":    rb.strategy.CurrentParams(),
+			"restarted": restarted,
+		},
+	})
+}
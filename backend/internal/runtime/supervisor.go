@@ -0,0 +1,114 @@
+// internal/runtime/supervisor.go
+
+package runtime
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+)
+
+const defaultSupervisorInterval = 15 * time.Second
+
+// BotSupervisor mantém em execução os bots ativos de todas as contas.
+// A cada rodada ele relê as tabelas bots e bot_configs: bots novos (inclusive de contas
+// novas) sobem, bots desativados ou removidos param e alterações de configuração são
+// aplicadas a quente, sem reiniciar o processo. Falhas de uma conta não afetam as demais.
+type BotSupervisor struct {
+	manager     *BotManager
+	accountRepo repository.AccountRepository
+	botRepo     repository.BotRepository
+	interval    time.Duration
+}
+
+// NewBotSupervisor cria o supervisor. O intervalo de polling vem de BOT_CONFIG_WATCH_INTERVAL
+// (ex.: "15s"), com padrão de 15 segundos.
+func NewBotSupervisor(
+	manager *BotManager,
+	accountRepo repository.AccountRepository,
+	botRepo repository.BotRepository,
+) *BotSupervisor {
+	interval := defaultSupervisorInterval
+	if v := os.Getenv("BOT_CONFIG_WATCH_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			interval = d
+		} else {
+			logger.Warn("BOT_CONFIG_WATCH_INTERVAL inválido, usando padrão", "valor", v, "padrao", interval)
+		}
+	}
+
+	return &BotSupervisor{
+		manager:     manager,
+		accountRepo: accountRepo,
+		botRepo:     botRepo,
+		interval:    interval,
+	}
+}
+
+// Run sincroniza imediatamente e depois a cada intervalo, até o contexto ser cancelado.
+func (s *BotSupervisor) Run(ctx context.Context) {
+	logger.Info("👀 Supervisor de bots iniciado", "intervalo", s.interval.String())
+
+	s.Sync(ctx)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.manager.StopMissing(nil)
+			return
+		case <-ticker.C:
+			s.Sync(ctx)
+		}
+	}
+}
+
+// Sync executa uma rodada de reconciliação entre o banco e os bots em execução.
+func (s *BotSupervisor) Sync(ctx context.Context) {
+	bots, err := s.botRepo.GetActive()
+	if err != nil {
+		// Sem a lista de bots não é seguro parar nada; tenta de novo na próxima rodada
+		logger.Error("[Supervisor] Erro ao carregar bots ativos", err)
+		return
+	}
+
+	accounts := make(map[uuid.UUID]*entity.Account)
+	seen := make(map[uuid.UUID]bool, len(bots))
+
+	for _, bot := range bots {
+		account, loaded := accounts[bot.AccountID]
+		if !loaded {
+			account, err = s.accountRepo.GetByID(ctx, bot.AccountID)
+			if err != nil {
+				logger.Error("[Supervisor] Erro ao carregar conta", err, "account_id", bot.AccountID.String())
+				account = nil
+			}
+			accounts[bot.AccountID] = account
+		}
+
+		if account == nil {
+			// Mantém os bots já em execução da conta até ela voltar a ser carregada
+			seen[bot.ID] = s.manager.IsRunning(bot.ID)
+			continue
+		}
+
+		seen[bot.ID] = true
+		if err := s.manager.Reconcile(*account, bot); err != nil {
+			logger.Error("[Supervisor] Erro ao aplicar configuração", err,
+				"account_id", account.ID.String(),
+				"bot_id", bot.ID.String(),
+				"symbol", bot.Symbol,
+			)
+		}
+	}
+
+	// Bots desativados ou removidos do banco saem de execução
+	s.manager.StopMissing(seen)
+}
@@ -0,0 +1,214 @@
+package runtime_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
+	"github.com/jeancarlosdanese/crypto-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStream registra os starts e stops pedidos pelo BotManager, sem conectar na exchange.
+type fakeStream struct {
+	mu      sync.Mutex
+	starts  []string
+	stopped bool
+}
+
+func (f *fakeStream) Start(symbol, interval string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.starts = append(f.starts, symbol+"@"+interval)
+	return nil
+}
+
+func (f *fakeStream) StartMany(pairs map[string]string) error { return nil }
+func (f *fakeStream) Stop(symbol string)                      {}
+
+func (f *fakeStream) StopAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = true
+}
+
+type supervisorFixture struct {
+	accounts   *mocks.MockAccountRepository
+	bots       *mocks.MockBotRepository
+	configs    *mocks.MockBotConfigRepository
+	manager    *runtime.BotManager
+	supervisor *runtime.BotSupervisor
+
+	mu         sync.Mutex
+	streams    []*fakeStream
+	strategies map[uuid.UUID]*usecases.StrategyUseCase
+}
+
+func newSupervisorFixture() *supervisorFixture {
+	logger.InitLogger()
+	f := &supervisorFixture{
+		accounts:   mocks.NewMockAccountRepository(),
+		bots:       mocks.NewMockBotRepository(),
+		configs:    mocks.NewMockBotConfigRepository(),
+		strategies: make(map[uuid.UUID]*usecases.StrategyUseCase),
+	}
+	exchangeFor := func(entity.Account, string) (services.ExchangeService, error) { return nil, nil }
+	streamFactory := func(strategy *usecases.StrategyUseCase) services.StreamService {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		stream := &fakeStream{}
+		f.streams = append(f.streams, stream)
+		f.strategies[strategy.CurrentBot().ID] = strategy
+		return stream
+	}
+	f.manager = runtime.NewBotManager(f.configs, mocks.NewMockPositionRepository(), nil, nil, nil, nil, exchangeFor, streamFactory, 30)
+	f.supervisor = runtime.NewBotSupervisor(f.manager, f.accounts, f.bots)
+	return f
+}
+
+func (f *supervisorFixture) streamCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.streams)
+}
+
+func (f *supervisorFixture) strategy(botID uuid.UUID) *usecases.StrategyUseCase {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.strategies[botID]
+}
+
+func TestSupervisorReconcilesBotsAndConfigs(t *testing.T) {
+	f := newSupervisorFixture()
+	ctx := context.Background()
+
+	account := entity.Account{ID: uuid.New(), Name: "conta"}
+	f.accounts.Accounts[account.ID] = account
+	bot := entity.Bot{ID: uuid.New(), AccountID: account.ID, Symbol: "BTC/USDT", Interval: "1m", StrategyName: "EvaluateCrossover", Active: true}
+	f.bots.Bots[bot.ID] = bot
+	_, _ = f.configs.Save(entity.BotConfig{BotID: bot.ID, Config: map[string]any{"ma_long": float64(40)}})
+
+	// 🆕 Bot ativo sobe com a configuração salva
+	f.supervisor.Sync(ctx)
+	require.True(t, f.manager.IsRunning(bot.ID))
+	require.Equal(t, 1, f.streamCount())
+	assert.Equal(t, float64(40), f.strategy(bot.ID).CurrentParams()["ma_long"])
+
+	// 🔁 Nova versão do config é aplicada a quente, sem recriar o stream
+	_, _ = f.configs.Save(entity.BotConfig{BotID: bot.ID, Config: map[string]any{"ma_long": float64(50)}})
+	f.supervisor.Sync(ctx)
+	assert.Equal(t, 1, f.streamCount())
+	assert.Equal(t, float64(50), f.strategy(bot.ID).CurrentParams()["ma_long"])
+
+	// ❌ Config inválido é rejeitado por ApplyConfig e o bot segue com o anterior
+	_, _ = f.configs.Save(entity.BotConfig{BotID: bot.ID, Config: map[string]any{"ma_long": float64(0)}})
+	f.supervisor.Sync(ctx)
+	assert.Equal(t, float64(50), f.strategy(bot.ID).CurrentParams()["ma_long"])
+	_, _ = f.configs.Save(entity.BotConfig{BotID: bot.ID, Config: map[string]any{"ma_long": float64(50)}})
+
+	// ⏯️ Pausa gravada no bot chega ao bot em execução
+	bot.Paused = true
+	f.bots.Bots[bot.ID] = bot
+	f.supervisor.Sync(ctx)
+	assert.Equal(t, runtime.BotStatusPaused, f.manager.Status(bot.ID))
+
+	// 🔄 Troca de intervalo reinicia o stream, mantendo a pausa
+	bot.Interval = "5m"
+	f.bots.Bots[bot.ID] = bot
+	f.supervisor.Sync(ctx)
+	require.Equal(t, 2, f.streamCount())
+	assert.True(t, f.streams[0].stopped)
+	assert.Equal(t, runtime.BotStatusPaused, f.manager.Status(bot.ID))
+	assert.Equal(t, "5m", f.strategy(bot.ID).CurrentBot().Interval)
+
+	// ⏹️ Bot desativado sai de execução
+	bot.Active = false
+	f.bots.Bots[bot.ID] = bot
+	f.supervisor.Sync(ctx)
+	assert.False(t, f.manager.IsRunning(bot.ID))
+	assert.True(t, f.streams[1].stopped)
+}
+
+func TestSupervisorKeepsBotsWhenAccountFailsToLoad(t *testing.T) {
+	f := newSupervisorFixture()
+	ctx := context.Background()
+
+	account := entity.Account{ID: uuid.New(), Name: "conta"}
+	f.accounts.Accounts[account.ID] = account
+	bot := entity.Bot{ID: uuid.New(), AccountID: account.ID, Symbol: "ETH/USDT", Interval: "1m", StrategyName: "EvaluateCrossover", Active: true}
+	f.bots.Bots[bot.ID] = bot
+
+	f.supervisor.Sync(ctx)
+	require.True(t, f.manager.IsRunning(bot.ID))
+
+	// Conta indisponível: o bot em execução não é derrubado
+	delete(f.accounts.Accounts, account.ID)
+	f.supervisor.Sync(ctx)
+	assert.True(t, f.manager.IsRunning(bot.ID))
+
+	// Bot removido do banco para
+	delete(f.bots.Bots, bot.ID)
+	f.supervisor.Sync(ctx)
+	assert.False(t, f.manager.IsRunning(bot.ID))
+}
+
+func TestCloseAccountPositionsFlagsStoppedBots(t *testing.T) {
+	f := newSupervisorFixture()
+	positions := mocks.NewMockPositionRepository()
+	f.manager = runtime.NewBotManager(f.configs, positions, nil, nil, nil, nil,
+		func(entity.Account, string) (services.ExchangeService, error) { return nil, nil },
+		func(*usecases.StrategyUseCase) services.StreamService { return &fakeStream{} }, 30)
+
+	stopped := uuid.New()
+	_ = positions.Save(entity.OpenPosition{BotID: stopped, Symbol: "BTC/USDT", EntryPrice: 100, Quantity: 1})
+
+	closed, flagged := f.manager.CloseAccountPositions(uuid.New(), exits.ReasonKillSwitch)
+	assert.Equal(t, 0, closed)
+	assert.Equal(t, 1, flagged)
+	assert.Equal(t, string(exits.ReasonKillSwitch), positions.Positions[stopped].CloseRequested)
+	assert.Empty(t, positions.Positions[stopped].StopReason)
+}
+
+func TestStartClearsCloseRequestWhenKillSwitchIsOff(t *testing.T) {
+	f := newSupervisorFixture()
+	ctx := context.Background()
+	positions := mocks.NewMockPositionRepository()
+	limits := mocks.NewMockRiskLimitsRepository()
+	guard := usecases.NewRiskGuard(limits, positions, mocks.NewMockOrderRepository(), &mocks.MockExecutionLogRepository{}, nil)
+	f.manager = runtime.NewBotManager(f.configs, positions, nil, nil, nil, guard,
+		func(entity.Account, string) (services.ExchangeService, error) { return nil, nil },
+		func(*usecases.StrategyUseCase) services.StreamService { return &fakeStream{} }, 30)
+	f.supervisor = runtime.NewBotSupervisor(f.manager, f.accounts, f.bots)
+
+	account := entity.Account{ID: uuid.New(), Name: "conta"}
+	f.accounts.Accounts[account.ID] = account
+	flagged := entity.Bot{ID: uuid.New(), AccountID: account.ID, Symbol: "BTC/USDT", Interval: "1m", StrategyName: "EvaluateCrossover", Active: true}
+	f.bots.Bots[flagged.ID] = flagged
+	_ = positions.Save(entity.OpenPosition{BotID: flagged.ID, EntryPrice: 100, Quantity: 1})
+	_ = positions.RequestClose(flagged.ID, string(exits.ReasonKillSwitch))
+
+	// Kill switch ainda ligado: o pedido de encerramento segue com o bot
+	limits.Save(entity.RiskLimits{AccountID: account.ID, KillSwitch: true})
+	f.supervisor.Sync(ctx)
+	require.True(t, f.manager.IsRunning(flagged.ID))
+	assert.Equal(t, string(exits.ReasonKillSwitch), positions.Positions[flagged.ID].CloseRequested)
+
+	// Kill switch desligado enquanto o bot estava parado: o pedido é desfeito ao iniciar
+	delete(f.bots.Bots, flagged.ID)
+	f.supervisor.Sync(ctx)
+	require.False(t, f.manager.IsRunning(flagged.ID))
+	limits.Save(entity.RiskLimits{AccountID: account.ID})
+	f.bots.Bots[flagged.ID] = flagged
+	f.supervisor.Sync(ctx)
+	require.True(t, f.manager.IsRunning(flagged.ID))
+	assert.Empty(t, positions.Positions[flagged.ID].CloseRequested)
+	assert.Equal(t, 1.0, positions.Positions[flagged.ID].Quantity)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
 	middleware "github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
 	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
 )
 
@@ -25,14 +26,16 @@ type AccountHandle interface {
 }
 
 type accountHandle struct {
-	log         *slog.Logger
-	accountRepo repository.AccountRepository
+	log             *slog.Logger
+	accountRepo     repository.AccountRepository
+	exchangeFactory services.ExchangeFactory
 }
 
-func NewAccountHandle(accountRepo repository.AccountRepository) AccountHandle {
+func NewAccountHandle(accountRepo repository.AccountRepository, exchangeFactory services.ExchangeFactory) AccountHandle {
 	return &accountHandle{
-		log:         logger.GetLogger(),
-		accountRepo: accountRepo,
+		log:             logger.GetLogger(),
+		accountRepo:     accountRepo,
+		exchangeFactory: exchangeFactory,
 	}
 }
 
@@ -192,6 +195,9 @@ func (h *accountHandle) UpdateAccountHandler() http.HandlerFunc {
 			return
 		}
 
+		// 🔑 Próximo uso da exchange pela conta (e seus bots) usa as credenciais atualizadas
+		h.exchangeFactory.Invalidate(accountID)
+
 		h.log.Info("Conta atualizada com sucesso", "account_id", updatedAccount.ID.String())
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(dto.NewAccountResponseDTO(updatedAccount))
@@ -224,6 +230,8 @@ func (h *accountHandle) DeleteAccountHandler() http.HandlerFunc {
 			return
 		}
 
+		h.exchangeFactory.Invalidate(accountID)
+
 		h.log.Info("Conta deletada", "account_id", accountID.String())
 		w.WriteHeader(http.StatusOK)
 		w.WriteHeader(http.StatusNoContent)
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/jeancarlosdanese/crypto-bot/internal/auth"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/dto"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
 	middleware "github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
@@ -148,6 +149,6 @@ func (h *authHandle) MeHandler() http.HandlerFunc {
 
 		h.log.Info("✅ Dados do usuário retornados", "email", account.Email, "name", account.Name)
 
-		json.NewEncoder(w).Encode(account)
+		json.NewEncoder(w).Encode(dto.NewAccountResponseDTO(account))
 	}
 }
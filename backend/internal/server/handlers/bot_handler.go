@@ -4,14 +4,21 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/dto"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
 	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
 	"github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
 	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
 )
 
@@ -19,14 +26,41 @@ type BotHandle interface {
 	ListBotsHandle() http.HandlerFunc
 	GetBotByIDHandle() http.HandlerFunc
 	GetCandlesHandler() http.HandlerFunc
+	GetIndicatorsHandler() http.HandlerFunc
+	CreateBotHandler() http.HandlerFunc
+	UpdateBotHandler() http.HandlerFunc
+	DeleteBotHandler() http.HandlerFunc
+	StartBotHandler() http.HandlerFunc
+	StopBotHandler() http.HandlerFunc
+	PauseBotHandler() http.HandlerFunc
+	ResumeBotHandler() http.HandlerFunc
 }
 
 type botHandle struct {
-	repo repository.BotRepository
+	repo        repository.BotRepository
+	configRepo  repository.BotConfigRepository
+	accountRepo repository.AccountRepository
+	candles     repository.CandleRepository
+	exchange    services.ExchangeService
+	manager     *runtime.BotManager
 }
 
-func NewBotHandle(repo repository.BotRepository) BotHandle {
-	return &botHandle{repo: repo}
+func NewBotHandle(
+	repo repository.BotRepository,
+	configRepo repository.BotConfigRepository,
+	accountRepo repository.AccountRepository,
+	candles repository.CandleRepository,
+	exchange services.ExchangeService,
+	manager *runtime.BotManager,
+) BotHandle {
+	return &botHandle{
+		repo:        repo,
+		configRepo:  configRepo,
+		accountRepo: accountRepo,
+		candles:     candles,
+		exchange:    exchange,
+		manager:     manager,
+	}
 }
 
 func (h *botHandle) ListBotsHandle() http.HandlerFunc {
@@ -46,7 +80,7 @@ func (h *botHandle) ListBotsHandle() http.HandlerFunc {
 
 		var result []dto.BotResponseDTO
 		for _, bot := range bots {
-			result = append(result, dto.NewBotResponseDTO(&bot))
+			result = append(result, h.newBotResponse(&bot))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -56,32 +90,314 @@ func (h *botHandle) ListBotsHandle() http.HandlerFunc {
 
 func (h *botHandle) GetBotByIDHandle() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		id := utils.GetUUIDFromRequestPath(r, w, "id")
+		bot, _, ok := h.loadOwnedBot(w, r)
+		if !ok {
+			return
+		}
 
-		runtime.BotsMap.RLock()
-		strategy := runtime.BotsMap.Items[id]
-		runtime.BotsMap.RUnlock()
-		if strategy == nil {
-			utils.SendError(w, http.StatusNotFound, "Bot não está ativo")
+		utils.SendJSON(w, http.StatusOK, h.newBotResponse(bot))
+	}
+}
+
+// CreateBotHandler cria um bot (e sua configuração inicial) e o inicia se estiver ativo
+func (h *botHandle) CreateBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		account, ok := middlewares.GetAuthenticatedAccount(r.Context())
+		if !ok {
+			utils.SendError(w, http.StatusUnauthorized, "Não autorizado")
 			return
 		}
 
-		bot, err := h.repo.GetByID(id)
+		var createDTO dto.BotCreateDTO
+		if err := json.NewDecoder(r.Body).Decode(&createDTO); err != nil {
+			logger.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		if err := createDTO.Validate(); err != nil {
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		symbol, err := h.validateBotSettings(createDTO.Symbol, createDTO.Interval, createDTO.StrategyName, createDTO.Config)
 		if err != nil {
-			logger.Error("Erro ao buscar bot", err)
-			http.Error(w, "Erro ao buscar bot", http.StatusInternalServerError)
+			utils.SendError(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		if bot == nil {
-			utils.SendError(w, http.StatusNotFound, "Bot não encontrado")
+
+		bot := &entity.Bot{
+			ID:           uuid.New(),
+			AccountID:    account.ID,
+			Symbol:       symbol,
+			Interval:     createDTO.Interval,
+			StrategyName: createDTO.StrategyName,
+			Autonomous:   createDTO.Autonomous,
+			Active:       createDTO.Active,
+		}
+
+		if _, err := h.repo.Create(bot); err != nil {
+			logger.Error("Erro ao criar bot", err)
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao criar bot")
 			return
 		}
 
-		result := dto.NewBotResponseDTO(bot)
-		utils.SendJSON(w, http.StatusOK, result)
+		if createDTO.Config != nil {
+			if _, err := h.configRepo.Save(entity.BotConfig{BotID: bot.ID, Config: createDTO.Config}); err != nil {
+				logger.Error("Erro ao salvar configuração do bot", err, "bot_id", bot.ID.String())
+				utils.SendError(w, http.StatusInternalServerError, "Bot criado, mas houve erro ao salvar a configuração")
+				return
+			}
+		}
+
+		if bot.Active {
+			if err := h.manager.StartBot(*account, *bot); err != nil {
+				logger.Error("Erro ao iniciar bot", err, "bot_id", bot.ID.String())
+			}
+		}
+
+		logger.Info("🤖 Bot criado", "bot_id", bot.ID.String(), "symbol", bot.Symbol, "account_id", account.ID.String())
+		utils.SendJSON(w, http.StatusCreated, h.newBotResponse(bot))
+	}
+}
+
+// UpdateBotHandler atualiza par, intervalo, estratégia, autonomia e/ou parâmetros do bot,
+// aplicando as mudanças imediatamente se ele estiver em execução
+func (h *botHandle) UpdateBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bot, owner, ok := h.loadOwnedBot(w, r)
+		if !ok {
+			return
+		}
+
+		var updateDTO dto.BotUpdateDTO
+		if err := json.NewDecoder(r.Body).Decode(&updateDTO); err != nil {
+			logger.Warn("Erro ao decodificar JSON", "error", err)
+			utils.SendError(w, http.StatusBadRequest, "Erro ao processar requisição")
+			return
+		}
+		defer r.Body.Close()
+
+		if err := updateDTO.Validate(); err != nil {
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		updateDTO.ApplyTo(bot)
+
+		// Sem config no corpo, a configuração salva precisa continuar válida para a estratégia
+		config := updateDTO.Config
+		if config == nil {
+			if cfg, err := h.configRepo.GetLatest(bot.ID); err == nil && cfg != nil {
+				config = cfg.Config
+			}
+		}
+
+		symbol, err := h.validateBotSettings(bot.Symbol, bot.Interval, bot.StrategyName, config)
+		if err != nil {
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		bot.Symbol = symbol
+
+		if _, err := h.repo.Update(bot); err != nil {
+			logger.Error("Erro ao atualizar bot", err, "bot_id", bot.ID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar bot")
+			return
+		}
+
+		if updateDTO.Config != nil {
+			if _, err := h.configRepo.Save(entity.BotConfig{BotID: bot.ID, Config: updateDTO.Config}); err != nil {
+				logger.Error("Erro ao salvar configuração do bot", err, "bot_id", bot.ID.String())
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao salvar configuração do bot")
+				return
+			}
+		}
+
+		if err := h.manager.Reconcile(*owner, *bot); err != nil {
+			logger.Error("Erro ao aplicar configuração do bot", err, "bot_id", bot.ID.String())
+		}
+
+		logger.Info("🤖 Bot atualizado", "bot_id", bot.ID.String(), "symbol", bot.Symbol)
+		utils.SendJSON(w, http.StatusOK, h.newBotResponse(bot))
+	}
+}
+
+// DeleteBotHandler para o bot (se estiver rodando) e o remove
+func (h *botHandle) DeleteBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bot, _, ok := h.loadOwnedBot(w, r)
+		if !ok {
+			return
+		}
+
+		h.manager.StopBot(bot.ID)
+
+		if err := h.repo.Delete(bot.ID); err != nil {
+			logger.Error("Erro ao deletar bot", err, "bot_id", bot.ID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao deletar bot")
+			return
+		}
+
+		logger.Info("🗑️ Bot deletado", "bot_id", bot.ID.String(), "symbol", bot.Symbol)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// StartBotHandler marca o bot como ativo e inicia seu stream
+func (h *botHandle) StartBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.setActive(w, r, true)
+	}
+}
+
+// StopBotHandler marca o bot como inativo e encerra seu stream
+func (h *botHandle) StopBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.setActive(w, r, false)
 	}
 }
 
+// PauseBotHandler suspende as decisões do bot, mantendo o stream de candles
+func (h *botHandle) PauseBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.setPaused(w, r, true)
+	}
+}
+
+// ResumeBotHandler retoma as decisões de um bot pausado
+func (h *botHandle) ResumeBotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.setPaused(w, r, false)
+	}
+}
+
+func (h *botHandle) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	bot, owner, ok := h.loadOwnedBot(w, r)
+	if !ok {
+		return
+	}
+
+	bot.Active = active
+	if _, err := h.repo.Update(bot); err != nil {
+		logger.Error("Erro ao atualizar bot", err, "bot_id", bot.ID.String())
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar bot")
+		return
+	}
+
+	if active {
+		if !h.manager.IsRunning(bot.ID) {
+			if err := h.manager.StartBot(*owner, *bot); err != nil {
+				logger.Error("Erro ao iniciar bot", err, "bot_id", bot.ID.String())
+				utils.SendError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Erro ao iniciar bot: %v", err))
+				return
+			}
+		}
+	} else {
+		h.manager.StopBot(bot.ID)
+	}
+
+	utils.SendJSON(w, http.StatusOK, h.newBotResponse(bot))
+}
+
+func (h *botHandle) setPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	bot, _, ok := h.loadOwnedBot(w, r)
+	if !ok {
+		return
+	}
+
+	if !h.manager.IsRunning(bot.ID) {
+		utils.SendError(w, http.StatusConflict, "Bot não está em execução")
+		return
+	}
+
+	// ⏯️ Gravado no bot para sobreviver a reinícios do processo
+	bot.Paused = paused
+	if _, err := h.repo.Update(bot); err != nil {
+		logger.Error("Erro ao atualizar bot", err, "bot_id", bot.ID.String())
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao atualizar bot")
+		return
+	}
+
+	if err := h.manager.SetPaused(bot.ID, paused); err != nil {
+		utils.SendError(w, http.StatusConflict, "Bot não está em execução")
+		return
+	}
+
+	utils.SendJSON(w, http.StatusOK, h.newBotResponse(bot))
+}
+
+// loadOwnedBot busca o bot do path e verifica se pertence à conta autenticada (ou se é admin).
+// Retorna também a conta dona do bot, usada para iniciar o stream.
+func (h *botHandle) loadOwnedBot(w http.ResponseWriter, r *http.Request) (*entity.Bot, *entity.Account, bool) {
+	account, ok := middlewares.GetAuthenticatedAccount(r.Context())
+	if !ok {
+		utils.SendError(w, http.StatusUnauthorized, "Não autorizado")
+		return nil, nil, false
+	}
+
+	id := utils.GetUUIDFromRequestPath(r, w, "id")
+	if id == uuid.Nil {
+		return nil, nil, false
+	}
+
+	bot, err := h.repo.GetByID(id)
+	if err != nil {
+		logger.Error("Erro ao buscar bot", err)
+		utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar bot")
+		return nil, nil, false
+	}
+	if bot == nil || !middlewares.IsAdminOrOwner(account, bot.AccountID) {
+		utils.SendError(w, http.StatusNotFound, "Bot não encontrado")
+		return nil, nil, false
+	}
+
+	owner := account
+	if bot.AccountID != account.ID {
+		owner, err = h.accountRepo.GetByID(r.Context(), bot.AccountID)
+		if err != nil {
+			logger.Error("Erro ao buscar conta do bot", err, "account_id", bot.AccountID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar conta do bot")
+			return nil, nil, false
+		}
+	}
+
+	return bot, owner, true
+}
+
+// validateBotSettings confere a estratégia no registro, os parâmetros do config contra ela
+// (inclusive os intervalos adicionais frente ao intervalo do bot) e o símbolo nas informações
+// da exchange, retornando o símbolo normalizado no formato BASE/QUOTE.
+func (h *botHandle) validateBotSettings(symbol, interval, strategyName string, config map[string]any) (string, error) {
+	strategy, err := usecases.GetStrategy(strategyName)
+	if err != nil {
+		return "", err
+	}
+	if err := usecases.ValidateParams(strategy, config); err != nil {
+		return "", err
+	}
+	if err := usecases.ValidateTimeframes(strategy, interval, config); err != nil {
+		return "", err
+	}
+
+	base, quote, err := h.exchange.GetBaseQuote(utils.FormatForBinance(symbol))
+	if err != nil {
+		return "", fmt.Errorf("símbolo %s não encontrado na exchange", symbol)
+	}
+
+	normalized := base + "/" + quote
+	if strings.Contains(symbol, "/") && symbol != normalized {
+		return "", fmt.Errorf("símbolo %s não corresponde ao par da exchange (%s)", symbol, normalized)
+	}
+	return normalized, nil
+}
+
+func (h *botHandle) newBotResponse(bot *entity.Bot) dto.BotResponseDTO {
+	response := dto.NewBotResponseDTO(bot)
+	response.Status = h.manager.Status(bot.ID)
+	return response
+}
+
 func (h *botHandle) GetCandlesHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := utils.GetUUIDFromRequestPath(r, w, "id")
@@ -94,37 +410,106 @@ func (h *botHandle) GetCandlesHandler() http.HandlerFunc {
 			return
 		}
 
-		var result []map[string]interface{}
-		skipped := 0
-		prices := strategy.ClosingPrices()
-		for i, c := range strategy.CandlesWindow {
-			if c.Time == 0 {
-				skipped++
-				continue // ignora candles sem timestamp
-			}
+		candles := strategy.CandlesSnapshot()
+		result := make([]map[string]interface{}, 0, len(candles))
+		prices := make([]float64, len(candles))
+		for i, c := range candles {
+			prices[i] = c.Close
+		}
+		ma9Series := indicators.SMASeries(prices, 9)
+		ma26Series := indicators.SMASeries(prices, 26)
+		for i, c := range candles {
 			ma9 := 0.0
 			ma26 := 0.0
 			if i >= 8 {
-				ma9 = indicators.MovingAverage(prices[:i+1], 9)
+				ma9 = ma9Series[i]
 			}
 			if i >= 25 {
-				ma26 = indicators.MovingAverage(prices[:i+1], 26)
+				ma26 = ma26Series[i]
 			}
 
 			result = append(result, map[string]interface{}{
-				"time":  c.Time,
-				"open":  c.Open,
-				"high":  c.High,
-				"low":   c.Low,
-				"close": c.Close,
-				"ma9":   ma9,
-				"ma26":  ma26,
+				"time":                   c.Time,
+				"open_time":              c.OpenTime,
+				"close_time":             c.CloseTime,
+				"open":                   c.Open,
+				"high":                   c.High,
+				"low":                    c.Low,
+				"close":                  c.Close,
+				"volume":                 c.Volume,
+				"quote_volume":           c.QuoteVolume,
+				"trades":                 c.Trades,
+				"taker_buy_volume":       c.TakerBuyVolume,
+				"taker_buy_quote_volume": c.TakerBuyQuoteVolume,
+				"ma9":                    ma9,
+				"ma26":                   ma26,
 			})
 		}
-		if skipped > 0 {
-			logger.Debug("⏩ Ignorados candles sem timestamp", "qtd", skipped)
-		}
 
 		utils.SendJSON(w, http.StatusOK, result)
 	}
 }
+
+// maxIndicatorCandles limita os candles devolvidos por consulta de indicadores.
+const maxIndicatorCandles = 1000
+
+// GetIndicatorsHandler calcula séries de indicadores sobre os candles gravados do bot (em
+// execução ou não), para overlays do gráfico: GET /bots/{id}/indicators?names=ema:20,rsi:14&from=&to=
+// (from e to em ms, filtrando pelo close_time; até maxIndicatorCandles, os mais recentes). Os
+// candles anteriores ao intervalo aquecem os indicadores; os que não puderam ser aquecidos
+// pelo histórico gravado são listados em incomplete_warmup.
+func (h *botHandle) GetIndicatorsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bot, _, ok := h.loadOwnedBot(w, r)
+		if !ok {
+			return
+		}
+
+		query := r.URL.Query()
+		specs, err := indicators.ParseSpecs(query.Get("names"))
+		if err != nil {
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if len(specs) == 0 {
+			utils.SendError(w, http.StatusBadRequest, "Informe os indicadores em names (ex: ema:20,rsi:14)")
+			return
+		}
+
+		var from, to int64
+		for param, target := range map[string]*int64{"from": &from, "to": &to} {
+			if raw := query.Get(param); raw != "" {
+				if *target, err = strconv.ParseInt(raw, 10, 64); err != nil || *target < 0 {
+					utils.SendError(w, http.StatusBadRequest, fmt.Sprintf("%s inválido: use o timestamp em ms", param))
+					return
+				}
+			}
+		}
+
+		symbol := utils.FormatForBinance(bot.Symbol)
+		candles, err := h.candles.GetRange(symbol, bot.Interval, from, to, maxIndicatorCandles)
+		if err != nil {
+			logger.Error("Erro ao buscar candles do bot", err, "bot_id", bot.ID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar candles do bot")
+			return
+		}
+
+		// Candles anteriores ao intervalo para aquecer o indicador mais exigente
+		warmup := 0
+		for _, spec := range specs {
+			warmup = max(warmup, spec.Warmup()-1)
+		}
+		var history []entity.Candle
+		if len(candles) > 0 && warmup > 0 {
+			if history, err = h.candles.GetBefore(symbol, bot.Interval, candles[0].OpenTime, warmup); err != nil {
+				logger.Error("Erro ao buscar candles de aquecimento", err, "bot_id", bot.ID.String())
+				utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar candles do bot")
+				return
+			}
+		}
+
+		utils.SendJSON(w, http.StatusOK, dto.NewIndicatorSeriesResponseDTO(
+			bot.ID, bot.Symbol, bot.Interval, append(history, candles...), specs, len(history),
+		))
+	}
+}
@@ -0,0 +1,108 @@
+// internal/server/handlers/bot_handler_test.go
+
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/server/handlers"
+	"github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
+	"github.com/jeancarlosdanese/crypto-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type indicatorsResponse struct {
+	Times            []int64                          `json:"times"`
+	Indicators       map[string]map[string][]*float64 `json:"indicators"`
+	IncompleteWarmup []string                         `json:"incomplete_warmup"`
+}
+
+// newIndicatorsServer monta o handler de indicadores de um bot parado (sem BotManager) cujos
+// candles de 1m estão no store: o candle i fecha em (i+1)*60000-1 com preço 100+i.
+func newIndicatorsServer(t *testing.T, candles int) (*http.ServeMux, *entity.Account, uuid.UUID) {
+	logger.InitLogger()
+
+	account := &entity.Account{ID: uuid.New()}
+	bot := entity.Bot{ID: uuid.New(), AccountID: account.ID, Symbol: "BTC/USDT", Interval: "1m", StrategyName: "crossover"}
+	bots := mocks.NewMockBotRepository()
+	bots.Bots[bot.ID] = bot
+
+	store := mocks.NewMockCandleRepository()
+	series := make([]entity.Candle, candles)
+	for i := range series {
+		price := 100 + float64(i)
+		series[i] = entity.Candle{
+			Open: price, High: price, Low: price, Close: price,
+			OpenTime:  int64(i) * 60_000,
+			CloseTime: int64(i+1)*60_000 - 1,
+		}
+	}
+	require.NoError(t, store.SaveMany("BTCUSDT", "1m", series))
+
+	handler := handlers.NewBotHandle(bots, nil, mocks.NewMockAccountRepository(), store, nil, nil)
+	mux := http.NewServeMux()
+	mux.Handle("GET /bots/{id}/indicators", handler.GetIndicatorsHandler())
+	return mux, account, bot.ID
+}
+
+func getIndicators(t *testing.T, mux *http.ServeMux, account *entity.Account, botID uuid.UUID, query string) (int, indicatorsResponse) {
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/bots/%s/indicators?%s", botID, query), nil)
+	req = req.WithContext(context.WithValue(req.Context(), middlewares.AuthAccountKey, account))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	var resp indicatorsResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec.Code, resp
+}
+
+func TestGetIndicatorsWarmsUpFromStore(t *testing.T) {
+	mux, account, botID := newIndicatorsServer(t, 40)
+
+	// Candles 30 a 39: a SMA de 5 é aquecida pelos candles anteriores ao intervalo
+	code, resp := getIndicators(t, mux, account, botID, fmt.Sprintf("names=sma:5&from=%d", 31*60_000-1))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Times, 10)
+	assert.Equal(t, int64(31*60_000-1), resp.Times[0])
+
+	sma := resp.Indicators["sma:5"]["value"]
+	require.Len(t, sma, 10)
+	require.NotNil(t, sma[0])
+	assert.InDelta(t, 128, *sma[0], 1e-9) // média de 126..130
+	assert.Empty(t, resp.IncompleteWarmup)
+}
+
+func TestGetIndicatorsReportsIncompleteWarmup(t *testing.T) {
+	mux, account, botID := newIndicatorsServer(t, 40)
+
+	// Só há 2 candles antes do intervalo: a SMA de 5 não pode ser aquecida, a de 3 pode
+	code, resp := getIndicators(t, mux, account, botID, fmt.Sprintf("names=sma:3,sma:5&from=%d", 3*60_000-1))
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Times, 38)
+	assert.Equal(t, []string{"sma:5"}, resp.IncompleteWarmup)
+	assert.NotNil(t, resp.Indicators["sma:3"]["value"][0])
+	assert.Nil(t, resp.Indicators["sma:5"]["value"][0])
+	assert.NotNil(t, resp.Indicators["sma:5"]["value"][2])
+}
+
+func TestGetIndicatorsRejectsInvalidRequests(t *testing.T) {
+	mux, account, botID := newIndicatorsServer(t, 10)
+
+	code, _ := getIndicators(t, mux, account, botID, "names=sma:0")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = getIndicators(t, mux, account, botID, "names=sma:5&from=abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = getIndicators(t, mux, &entity.Account{ID: uuid.New()}, botID, "names=sma:5")
+	assert.Equal(t, http.StatusNotFound, code)
+}
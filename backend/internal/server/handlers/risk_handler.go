@@ -0,0 +1,143 @@
+// internal/server/handlers/risk_handler.go
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/exits"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/dto"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
+	"github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+type RiskHandle interface {
+	GetRiskLimitsHandler() http.HandlerFunc
+	UpdateRiskLimitsHandler() http.HandlerFunc
+	KillSwitchHandler() http.HandlerFunc
+}
+
+type riskHandle struct {
+	riskGuard *usecases.RiskGuard
+	manager   *runtime.BotManager
+}
+
+func NewRiskHandle(riskGuard *usecases.RiskGuard, manager *runtime.BotManager) RiskHandle {
+	return &riskHandle{
+		riskGuard: riskGuard,
+		manager:   manager,
+	}
+}
+
+// GetRiskLimitsHandler retorna os limites de risco da conta
+func (h *riskHandle) GetRiskLimitsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, ok := h.authorizedAccountID(w, r)
+		if !ok {
+			return
+		}
+
+		limits, err := h.riskGuard.Limits(accountID)
+		if err != nil {
+			logger.Error("Erro ao buscar limites de risco", err, "account_id", accountID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar limites de risco")
+			return
+		}
+		utils.SendJSON(w, http.StatusOK, limits)
+	}
+}
+
+// UpdateRiskLimitsHandler altera os limites de risco da conta
+func (h *riskHandle) UpdateRiskLimitsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, ok := h.authorizedAccountID(w, r)
+		if !ok {
+			return
+		}
+
+		var input dto.RiskLimitsUpdateDTO
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			utils.SendError(w, http.StatusBadRequest, "JSON inválido")
+			return
+		}
+		if err := input.Validate(); err != nil {
+			utils.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		limits, err := h.riskGuard.Limits(accountID)
+		if err != nil {
+			logger.Error("Erro ao buscar limites de risco", err, "account_id", accountID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao buscar limites de risco")
+			return
+		}
+
+		input.ApplyTo(limits)
+		if err := h.riskGuard.SaveLimits(*limits); err != nil {
+			logger.Error("Erro ao salvar limites de risco", err, "account_id", accountID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao salvar limites de risco")
+			return
+		}
+
+		logger.Info("🛡️ Limites de risco atualizados", "account_id", accountID.String())
+		utils.SendJSON(w, http.StatusOK, limits)
+	}
+}
+
+// KillSwitchHandler bloqueia (ou libera) novas entradas de todos os bots da conta e,
+// opcionalmente, encerra as posições abertas (as de bots parados ficam marcadas)
+func (h *riskHandle) KillSwitchHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accountID, ok := h.authorizedAccountID(w, r)
+		if !ok {
+			return
+		}
+
+		// Corpo opcional: sem corpo o kill switch é ligado sem encerrar posições
+		var input dto.KillSwitchDTO
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+			utils.SendError(w, http.StatusBadRequest, "JSON inválido")
+			return
+		}
+
+		limits, err := h.riskGuard.SetKillSwitch(accountID, input.IsEnabled())
+		if err != nil {
+			logger.Error("Erro ao alterar kill switch", err, "account_id", accountID.String())
+			utils.SendError(w, http.StatusInternalServerError, "Erro ao alterar kill switch")
+			return
+		}
+		logger.Warn("🧯 Kill switch alterado", "account_id", accountID.String(), "enabled", limits.KillSwitch)
+
+		response := dto.KillSwitchResponseDTO{Limits: limits}
+		if limits.KillSwitch && input.ClosePositions {
+			response.ClosedPositions, response.FlaggedPositions = h.manager.CloseAccountPositions(accountID, exits.ReasonKillSwitch)
+		}
+		utils.SendJSON(w, http.StatusOK, response)
+	}
+}
+
+// authorizedAccountID lê a conta do path e verifica se a conta autenticada é a dona (ou admin).
+func (h *riskHandle) authorizedAccountID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	account, ok := middlewares.GetAuthenticatedAccount(r.Context())
+	if !ok {
+		utils.SendError(w, http.StatusUnauthorized, "Não autorizado")
+		return uuid.Nil, false
+	}
+
+	accountID := utils.GetUUIDFromRequestPath(r, w, "id")
+	if accountID == uuid.Nil {
+		return uuid.Nil, false
+	}
+	if !middlewares.IsAdminOrOwner(account, accountID) {
+		utils.SendError(w, http.StatusForbidden, "Acesso negado a esta conta")
+		return uuid.Nil, false
+	}
+	return accountID, true
+}
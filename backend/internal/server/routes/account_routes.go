@@ -7,11 +7,17 @@ import (
 
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/server/handlers"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
 )
 
 // RegisterAccountRoutes adiciona as rotas relacionadas a contas
-func RegisterAccountRoutes(mux *http.ServeMux, authMiddleware func(http.Handler) http.HandlerFunc, accountRepo repository.AccountRepository) {
-	handler := handlers.NewAccountHandle(accountRepo)
+func RegisterAccountRoutes(
+	mux *http.ServeMux,
+	authMiddleware func(http.Handler) http.HandlerFunc,
+	accountRepo repository.AccountRepository,
+	exchangeFactory services.ExchangeFactory,
+) {
+	handler := handlers.NewAccountHandle(accountRepo, exchangeFactory)
 
 	mux.Handle("POST /accounts", http.HandlerFunc(handler.CreateAccountHandler()))
 	mux.Handle("GET /accounts", authMiddleware(http.HandlerFunc(handler.GetAllAccountsHandler())))
@@ -6,7 +6,9 @@ import (
 	"net/http"
 
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
 	"github.com/jeancarlosdanese/crypto-bot/internal/server/handlers"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
 )
 
 // RegisterBotRoutes adiciona as rotas relacionadas aos bots
@@ -14,13 +16,25 @@ func RegisterBotRoutes(
 	mux *http.ServeMux,
 	authMiddleware func(http.Handler) http.HandlerFunc,
 	botRepo repository.BotRepository,
+	botConfigRepo repository.BotConfigRepository,
+	accountRepo repository.AccountRepository,
+	candleRepo repository.CandleRepository,
+	exchange services.ExchangeService,
+	manager *runtime.BotManager,
 ) {
-	handler := handlers.NewBotHandle(botRepo)
+	handler := handlers.NewBotHandle(botRepo, botConfigRepo, accountRepo, candleRepo, exchange, manager)
 
 	mux.Handle("GET /bots", authMiddleware(http.HandlerFunc(handler.ListBotsHandle())))
+	mux.Handle("POST /bots", authMiddleware(http.HandlerFunc(handler.CreateBotHandler())))
 	mux.Handle("GET /bots/{id}/candles", authMiddleware(http.HandlerFunc(handler.GetCandlesHandler())))
+	mux.Handle("GET /bots/{id}/indicators", authMiddleware(http.HandlerFunc(handler.GetIndicatorsHandler())))
 	mux.Handle("GET /bots/{id}", authMiddleware(http.HandlerFunc(handler.GetBotByIDHandle())))
+	mux.Handle("PUT /bots/{id}", authMiddleware(http.HandlerFunc(handler.UpdateBotHandler())))
+	mux.Handle("DELETE /bots/{id}", authMiddleware(http.HandlerFunc(handler.DeleteBotHandler())))
 
-	// Futuro:
-	// mux.Handle("POST /bots", authMiddleware(http.HandlerFunc(handler.CreateBotHandler())))
+	// Ciclo de vida
+	mux.Handle("POST /bots/{id}/start", authMiddleware(http.HandlerFunc(handler.StartBotHandler())))
+	mux.Handle("POST /bots/{id}/stop", authMiddleware(http.HandlerFunc(handler.StopBotHandler())))
+	mux.Handle("POST /bots/{id}/pause", authMiddleware(http.HandlerFunc(handler.PauseBotHandler())))
+	mux.Handle("POST /bots/{id}/resume", authMiddleware(http.HandlerFunc(handler.ResumeBotHandler())))
 }
@@ -0,0 +1,25 @@
+// internal/server/routes/risk_routes.go
+
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
+	"github.com/jeancarlosdanese/crypto-bot/internal/server/handlers"
+)
+
+// RegisterRiskRoutes adiciona as rotas de limites de risco e kill switch das contas
+func RegisterRiskRoutes(
+	mux *http.ServeMux,
+	authMiddleware func(http.Handler) http.HandlerFunc,
+	riskGuard *usecases.RiskGuard,
+	manager *runtime.BotManager,
+) {
+	handler := handlers.NewRiskHandle(riskGuard, manager)
+
+	mux.Handle("GET /accounts/{id}/risk-limits", authMiddleware(http.HandlerFunc(handler.GetRiskLimitsHandler())))
+	mux.Handle("PUT /accounts/{id}/risk-limits", authMiddleware(http.HandlerFunc(handler.UpdateRiskLimitsHandler())))
+	mux.Handle("POST /accounts/{id}/kill-switch", authMiddleware(http.HandlerFunc(handler.KillSwitchHandler())))
+}
@@ -5,8 +5,11 @@ package routes
 import (
 	"net/http"
 
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/runtime"
 	"github.com/jeancarlosdanese/crypto-bot/internal/server/middlewares"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
 )
 
 // NewRouter cria e retorna um roteador HTTP configurado.
@@ -14,6 +17,12 @@ func NewRouter(
 	otpRepo repository.AccountOTPRepository,
 	accountRepo repository.AccountRepository,
 	botRepo repository.BotRepository,
+	botConfigRepo repository.BotConfigRepository,
+	candleRepo repository.CandleRepository,
+	exchange services.ExchangeService,
+	exchangeFactory services.ExchangeFactory,
+	riskGuard *usecases.RiskGuard,
+	manager *runtime.BotManager,
 ) *http.ServeMux {
 	mux := http.NewServeMux()
 
@@ -22,8 +31,9 @@ func NewRouter(
 
 	// 🔥 Registrar rotas principais
 	RegisterAuthRoutes(mux, authMiddleware, otpRepo)
-	RegisterAccountRoutes(mux, authMiddleware, accountRepo)
-	RegisterBotRoutes(mux, authMiddleware, botRepo)
+	RegisterAccountRoutes(mux, authMiddleware, accountRepo, exchangeFactory)
+	RegisterRiskRoutes(mux, authMiddleware, riskGuard, manager)
+	RegisterBotRoutes(mux, authMiddleware, botRepo, botConfigRepo, accountRepo, candleRepo, exchange, manager)
 	RegisterWebSocketRoutes(mux, botRepo)
 
 	// 🔥 Rota de Health Check
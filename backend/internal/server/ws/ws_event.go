@@ -3,7 +3,7 @@
 package ws
 
 type Event struct {
-	Type   string      `json:"type"`   // "candle" ou "decision"
+	Type   string      `json:"type"`   // "candle", "decision", "indicators", "config_applied" ou "error"
 	Symbol string      `json:"symbol"` // Ex: "BTCUSDT"
 	Data   interface{} `json:"data"`   // Conteúdo do evento
 }
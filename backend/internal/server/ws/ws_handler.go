@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
 	"github.com/jeancarlosdanese/crypto-bot/internal/auth"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
@@ -54,12 +55,24 @@ func SecureWebSocketHandler(botRepo repository.BotRepository) http.HandlerFunc {
 		}
 		// Verificar se o bot pertence à conta
 		bot, err := botRepo.GetByID(botID)
-		if err != nil || bot.AccountID != accountID {
-			logger.Error("Erro ao buscar bot ou bot não pertence à conta:", err)
+		if err != nil {
+			logger.Error("Erro ao buscar bot:", err)
+			http.Error(w, "bot não pertence à sua conta", http.StatusForbidden)
+			return
+		}
+		if bot == nil || bot.AccountID != accountID {
+			logger.Warn("Bot não encontrado ou não pertence à conta", "bot_id", botID.String())
 			http.Error(w, "bot não pertence à sua conta", http.StatusForbidden)
 			return
 		}
 
+		// Indicadores assinados na conexão (opcional): ?indicators=ema:20,rsi:14
+		specs, err := indicators.ParseSpecs(r.URL.Query().Get("indicators"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			logger.Error("Erro ao fazer upgrade para WebSocket:", err)
@@ -68,6 +81,6 @@ func SecureWebSocketHandler(botRepo repository.BotRepository) http.HandlerFunc {
 
 		logger.Debug("🧩 Cliente conectado via WebSocket", "bot_id", botID.String(), "account_id", accountID)
 
-		AddClient(bot.ID.String(), conn)
+		AddClient(bot.ID.String(), conn, specs)
 	}
 }
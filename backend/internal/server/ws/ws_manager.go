@@ -3,14 +3,30 @@
 package ws
 
 import (
+	"encoding/json"
+	"strings"
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/dto"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 )
 
 type client struct {
 	conn *websocket.Conn
 	send chan Event
+
+	mu         sync.Mutex
+	indicators []indicators.Spec // indicadores assinados (evento "indicators")
+}
+
+// clientMessage é uma mensagem enviada pelo cliente, ex:
+// {"type": "subscribe_indicators", "indicators": ["ema:20", "bb:20:2"]}.
+// Uma lista vazia cancela a assinatura.
+type clientMessage struct {
+	Type       string   `json:"type"`
+	Indicators []string `json:"indicators"`
 }
 
 type symbolHub struct {
@@ -29,10 +45,44 @@ func Publish(symbol string, event Event) {
 	defer hub.lock.RUnlock()
 
 	for c := range hub.clients {
-		c.send <- event
+		c.trySend(event)
 	}
 }
 
+// PublishIndicators envia a cada cliente do símbolo os valores, no candle mais recente, dos
+// indicadores que ele assinou. Cada indicador é calculado uma vez, mesmo com vários assinantes.
+func PublishIndicators(symbol string, candles []entity.Candle) {
+	hub := getHub(symbol)
+	hub.lock.RLock()
+	defer hub.lock.RUnlock()
+
+	cache := make(map[string]map[string]*float64)
+	for c := range hub.clients {
+		specs := c.subscribedIndicators()
+		if len(specs) == 0 {
+			continue
+		}
+
+		values := dto.NewIndicatorValuesDTO(candles, nil)
+		for _, spec := range specs {
+			key := spec.String()
+			if _, ok := cache[key]; !ok {
+				cache[key] = dto.NewIndicatorValuesDTO(candles, []indicators.Spec{spec}).Values[key]
+			}
+			values.Values[key] = cache[key]
+		}
+		c.trySend(Event{Type: "indicators", Data: values})
+	}
+}
+
+// ClientCount retorna quantos clientes estão conectados ao hub do símbolo
+func ClientCount(symbol string) int {
+	hub := getHub(symbol)
+	hub.lock.RLock()
+	defer hub.lock.RUnlock()
+	return len(hub.clients)
+}
+
 // getHub retorna (ou cria) o hub de um símbolo
 func getHub(symbol string) *symbolHub {
 	hubsLock.Lock()
@@ -50,13 +100,14 @@ func getHub(symbol string) *symbolHub {
 	return newHub
 }
 
-// AddClient adiciona um cliente WebSocket ao hub do símbolo
-func AddClient(symbol string, conn *websocket.Conn) {
+// AddClient adiciona um cliente WebSocket ao hub do símbolo, já assinando os indicadores informados
+func AddClient(symbol string, conn *websocket.Conn, specs []indicators.Spec) {
 	hub := getHub(symbol)
 
 	c := &client{
-		conn: conn,
-		send: make(chan Event, 10),
+		conn:       conn,
+		send:       make(chan Event, 10),
+		indicators: specs,
 	}
 
 	hub.lock.Lock()
@@ -64,6 +115,32 @@ func AddClient(symbol string, conn *websocket.Conn) {
 	hub.lock.Unlock()
 
 	go c.writeLoop()
+	go c.readLoop(hub)
+}
+
+// removeClient retira o cliente do hub e encerra o envio de eventos a ele
+func (h *symbolHub) removeClient(c *client) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.clients[c] {
+		delete(h.clients, c)
+		close(c.send)
+	}
+}
+
+// trySend enfileira o evento sem bloquear: clientes lentos (fila cheia) perdem o evento
+func (c *client) trySend(event Event) {
+	select {
+	case c.send <- event:
+	default:
+	}
+}
+
+func (c *client) subscribedIndicators() []indicators.Spec {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.indicators
 }
 
 func (c *client) writeLoop() {
@@ -76,3 +153,35 @@ func (c *client) writeLoop() {
 		}
 	}
 }
+
+// readLoop trata as mensagens do cliente (assinaturas) até a conexão ser encerrada
+func (c *client) readLoop(hub *symbolHub) {
+	defer hub.removeClient(c)
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg clientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.trySend(Event{Type: "error", Data: "mensagem inválida"})
+			continue
+		}
+
+		switch msg.Type {
+		case "subscribe_indicators":
+			specs, err := indicators.ParseSpecs(strings.Join(msg.Indicators, ","))
+			if err != nil {
+				c.trySend(Event{Type: "error", Data: err.Error()})
+				continue
+			}
+			c.mu.Lock()
+			c.indicators = specs
+			c.mu.Unlock()
+		default:
+			c.trySend(Event{Type: "error", Data: "tipo de mensagem desconhecido: " + msg.Type})
+		}
+	}
+}
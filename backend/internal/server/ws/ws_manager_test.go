@@ -0,0 +1,79 @@
+// internal/server/ws/ws_manager_test.go
+
+package ws_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/server/ws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dialHub sobe um servidor que registra cada conexão no hub do símbolo e conecta um cliente a ele.
+func dialHub(t *testing.T, symbol string) *websocket.Conn {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		ws.AddClient(symbol, conn, nil)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	require.Eventually(t, func() bool { return ws.ClientCount(symbol) == 1 }, time.Second, 10*time.Millisecond)
+	return conn
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) map[string]any {
+	var event map[string]any
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	require.NoError(t, conn.ReadJSON(&event))
+	return event
+}
+
+func TestSubscribeIndicatorsProtocol(t *testing.T) {
+	const symbol = "ws-test-subscribe"
+	conn := dialHub(t, symbol)
+
+	// Mensagens inválidas são respondidas com "error"
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "subscribe_indicators", "indicators": []string{"ema:0"}}))
+	assert.Equal(t, "error", readEvent(t, conn)["type"])
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "unknown"}))
+	event := readEvent(t, conn)
+	assert.Equal(t, "error", event["type"])
+	assert.Contains(t, event["data"], "unknown")
+
+	// As mensagens são tratadas em ordem: o erro da seguinte confirma que a assinatura foi aplicada
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "subscribe_indicators", "indicators": []string{"sma:2"}}))
+	require.NoError(t, conn.WriteJSON(map[string]any{"type": "ping"}))
+	assert.Equal(t, "error", readEvent(t, conn)["type"])
+
+	ws.PublishIndicators(symbol, []entity.Candle{{Close: 10, CloseTime: 1000}, {Close: 20, CloseTime: 2000}})
+	event = readEvent(t, conn)
+	require.Equal(t, "indicators", event["type"])
+	data := event["data"].(map[string]any)
+	assert.EqualValues(t, 2000, data["close_time"])
+	assert.Equal(t, map[string]any{"sma:2": map[string]any{"value": 15.0}}, data["values"])
+}
+
+func TestClientRemovedAfterDisconnect(t *testing.T) {
+	const symbol = "ws-test-remove"
+	conn := dialHub(t, symbol)
+
+	conn.Close()
+	assert.Eventually(t, func() bool { return ws.ClientCount(symbol) == 0 }, 2*time.Second, 10*time.Millisecond)
+
+	// Publicar depois da remoção não bloqueia nem reenvia ao cliente removido
+	ws.Publish(symbol, ws.Event{Type: "candle"})
+}
@@ -4,23 +4,34 @@ package binance
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/common"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 	service "github.com/jeancarlosdanese/crypto-bot/internal/services"
 )
 
 var _ service.ExchangeService = (*BinanceService)(nil)
 
+// binanceNoSuchOrder é o código de erro da Binance para ordens inexistentes (NO_SUCH_ORDER).
+const binanceNoSuchOrder = -2013
+
 type BinanceService struct {
-	client *binance.Client
+	client  *binance.Client
+	symbols *SymbolCache
 }
 
-// NewBinanceService cria uma nova instância do BinanceService.
-func NewBinanceService(client *binance.Client) *BinanceService {
-	return &BinanceService{client: client}
+// NewBinanceService cria uma nova instância do BinanceService. O cache de símbolos pode ser
+// compartilhado entre serviços (ver ClientFactory); se nil, um cache próprio é criado.
+func NewBinanceService(client *binance.Client, symbols *SymbolCache) *BinanceService {
+	if symbols == nil {
+		symbols = NewSymbolCache(client)
+	}
+	return &BinanceService{client: client, symbols: symbols}
 }
 
 // GetCurrentPrice retorna o preço atual do símbolo.
@@ -42,21 +53,44 @@ func (s *BinanceService) GetHistoricalCandles(symbol string, interval string, li
 	if err != nil {
 		return nil, err
 	}
-	var candles []entity.Candle
+	candles := make([]entity.Candle, 0, len(klines))
 	for _, k := range klines {
-		open, _ := strconv.ParseFloat(k.Open, 64)
-		high, _ := strconv.ParseFloat(k.High, 64)
-		low, _ := strconv.ParseFloat(k.Low, 64)
-		closeVal, _ := strconv.ParseFloat(k.Close, 64)
-		volume, _ := strconv.ParseFloat(k.Volume, 64)
-		candles = append(candles, entity.Candle{
-			Open:   open,
-			High:   high,
-			Low:    low,
-			Close:  closeVal,
-			Volume: volume,
-			Time:   k.CloseTime / 1000,
-		})
+		candles = append(candles, candleFromKline(k))
+	}
+	return candles, nil
+}
+
+// GetCandlesRange retorna os candles fechados entre start e end, paginando a API de klines
+// (máximo de 1000 por requisição). Usado para baixar históricos longos (ex: backtests).
+func (s *BinanceService) GetCandlesRange(symbol string, interval string, start, end time.Time) ([]entity.Candle, error) {
+	const pageLimit = 1000
+
+	var candles []entity.Candle
+	now := time.Now().UnixMilli()
+	from := start.UnixMilli()
+	for from < end.UnixMilli() {
+		klines, err := s.client.NewKlinesService().
+			Symbol(symbol).
+			Interval(interval).
+			StartTime(from).
+			EndTime(end.UnixMilli()).
+			Limit(pageLimit).
+			Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k := range klines {
+			if k.CloseTime >= now {
+				continue // candle ainda em formação
+			}
+			candles = append(candles, candleFromKline(k))
+		}
+
+		if len(klines) < pageLimit {
+			break
+		}
+		from = klines[len(klines)-1].CloseTime + 1
 	}
 	return candles, nil
 }
@@ -76,40 +110,184 @@ func (s *BinanceService) GetAccountPositions() error {
 	return nil
 }
 
+// GetBaseQuote retorna os ativos base e de cotação do símbolo (a partir do cache de símbolos).
 func (s *BinanceService) GetBaseQuote(symbol string) (string, string, error) {
-	info, err := s.client.NewExchangeInfoService().Do(context.Background())
+	info, err := s.symbols.Get(symbol)
 	if err != nil {
 		return "", "", err
 	}
+	return info.BaseAsset, info.QuoteAsset, nil
+}
+
+// GetSymbolInfo retorna os ativos e filtros de negociação do símbolo.
+func (s *BinanceService) GetSymbolInfo(symbol string) (*entity.SymbolInfo, error) {
+	return s.symbols.Get(symbol)
+}
+
+// PlaceOrder envia uma ordem a mercado ou limitada (GTC). A resposta FULL inclui as
+// execuções, de onde vêm o preço médio e as taxas.
+func (s *BinanceService) PlaceOrder(req entity.OrderRequest) (*entity.Order, error) {
+	req, err := s.prepareOrder(req)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := s.client.NewCreateOrderService().
+		Symbol(req.Symbol).
+		Side(binance.SideType(req.Side)).
+		Type(binance.OrderType(req.Type)).
+		Quantity(strconv.FormatFloat(req.Quantity, 'f', -1, 64)).
+		NewOrderRespType(binance.NewOrderRespTypeFULL)
+
+	if req.Type == entity.OrderTypeLimit {
+		svc = svc.TimeInForce(binance.TimeInForceTypeGTC).
+			Price(strconv.FormatFloat(req.Price, 'f', -1, 64))
+	}
+	if req.ClientOrderID != "" {
+		svc = svc.NewClientOrderID(req.ClientOrderID)
+	}
+
+	res, err := svc.Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	order := &entity.Order{
+		ExchangeOrderID:  strconv.FormatInt(res.OrderID, 10),
+		ClientOrderID:    res.ClientOrderID,
+		Symbol:           res.Symbol,
+		Side:             entity.OrderSide(res.Side),
+		Type:             entity.OrderType(res.Type),
+		Status:           toOrderStatus(res.Status),
+		Quantity:         parseFloat(res.OrigQuantity),
+		Price:            parseFloat(res.Price),
+		ExecutedQuantity: parseFloat(res.ExecutedQuantity),
+		QuoteQuantity:    parseFloat(res.CummulativeQuoteQuantity),
+	}
+	for _, f := range res.Fills {
+		order.Fee += parseFloat(f.Commission)
+		order.FeeAsset = f.CommissionAsset
+	}
+	return order, nil
+}
+
+// prepareOrder aplica os filtros do símbolo (LOT_SIZE, PRICE_FILTER, NOTIONAL, PERCENT_PRICE)
+// antes do envio, evitando ordens que a Binance recusaria.
+func (s *BinanceService) prepareOrder(req entity.OrderRequest) (entity.OrderRequest, error) {
+	info, err := s.symbols.Get(req.Symbol)
+	if err != nil {
+		return req, err
+	}
+	price, err := s.GetCurrentPrice(req.Symbol)
+	if err != nil {
+		return req, fmt.Errorf("erro ao obter preço de %s: %w", req.Symbol, err)
+	}
+	return service.PrepareOrder(info, req, price)
+}
+
+// CancelOrder cancela uma ordem aberta.
+func (s *BinanceService) CancelOrder(symbol, exchangeOrderID string) (*entity.Order, error) {
+	orderID, err := strconv.ParseInt(exchangeOrderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("ID de ordem inválido: %s", exchangeOrderID)
+	}
+
+	res, err := s.client.NewCancelOrderService().Symbol(symbol).OrderID(orderID).Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Order{
+		ExchangeOrderID:  exchangeOrderID,
+		ClientOrderID:    res.OrigClientOrderID,
+		Symbol:           res.Symbol,
+		Side:             entity.OrderSide(res.Side),
+		Type:             entity.OrderType(res.Type),
+		Status:           toOrderStatus(res.Status),
+		Quantity:         parseFloat(res.OrigQuantity),
+		Price:            parseFloat(res.Price),
+		ExecutedQuantity: parseFloat(res.ExecutedQuantity),
+		QuoteQuantity:    parseFloat(res.CummulativeQuoteQuantity),
+	}, nil
+}
+
+// GetOrder consulta o estado da ordem. Se houve execução, as taxas são somadas
+// a partir dos trades da ordem. IDs que não são da Binance (ex: ordens simuladas) e ordens
+// desconhecidas retornam service.ErrOrderNotFound.
+func (s *BinanceService) GetOrder(symbol, exchangeOrderID string) (*entity.Order, error) {
+	orderID, err := strconv.ParseInt(exchangeOrderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ID inválido %s", service.ErrOrderNotFound, exchangeOrderID)
+	}
+
+	res, err := s.client.NewGetOrderService().Symbol(symbol).OrderID(orderID).Do(context.Background())
+	if err != nil {
+		var apiErr *common.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == binanceNoSuchOrder {
+			return nil, fmt.Errorf("%w: %s", service.ErrOrderNotFound, exchangeOrderID)
+		}
+		return nil, err
+	}
 
-	for _, s := range info.Symbols {
-		if s.Symbol == symbol {
-			return s.BaseAsset, s.QuoteAsset, nil
+	order := &entity.Order{
+		ExchangeOrderID:  exchangeOrderID,
+		ClientOrderID:    res.ClientOrderID,
+		Symbol:           res.Symbol,
+		Side:             entity.OrderSide(res.Side),
+		Type:             entity.OrderType(res.Type),
+		Status:           toOrderStatus(res.Status),
+		Quantity:         parseFloat(res.OrigQuantity),
+		Price:            parseFloat(res.Price),
+		ExecutedQuantity: parseFloat(res.ExecutedQuantity),
+		QuoteQuantity:    parseFloat(res.CummulativeQuoteQuantity),
+	}
+
+	if order.ExecutedQuantity > 0 {
+		trades, err := s.client.NewListTradesService().Symbol(symbol).OrderId(orderID).Do(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range trades {
+			order.Fee += parseFloat(t.Commission)
+			order.FeeAsset = t.CommissionAsset
 		}
 	}
-	return "", "", fmt.Errorf("símbolo não encontrado")
+	return order, nil
 }
 
-// PlaceBuyOrder envia uma ordem de compra limitada para o símbolo especificado.
-func (s *BinanceService) PlaceBuyOrder(symbol string, quantity, price float64) (*binance.CreateOrderResponse, error) {
-	return s.client.NewCreateOrderService().
-		Symbol(symbol).
-		Side(binance.SideTypeBuy).
-		Type(binance.OrderTypeLimit).
-		TimeInForce(binance.TimeInForceTypeGTC).
-		Quantity(strconv.FormatFloat(quantity, 'f', -1, 64)).
-		Price(strconv.FormatFloat(price, 'f', -1, 64)).
-		Do(context.Background())
+// GetFreeBalance retorna o saldo disponível do ativo na conta.
+func (s *BinanceService) GetFreeBalance(asset string) (float64, error) {
+	account, err := s.client.NewGetAccountService().Do(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	for _, b := range account.Balances {
+		if b.Asset == asset {
+			return parseFloat(b.Free), nil
+		}
+	}
+	return 0, nil
 }
 
-// PlaceSellOrder envia uma ordem de venda limitada para o símbolo especificado.
-func (s *BinanceService) PlaceSellOrder(symbol string, quantity, price float64) (*binance.CreateOrderResponse, error) {
-	return s.client.NewCreateOrderService().
-		Symbol(symbol).
-		Side(binance.SideTypeSell).
-		Type(binance.OrderTypeLimit).
-		TimeInForce(binance.TimeInForceTypeGTC).
-		Quantity(strconv.FormatFloat(quantity, 'f', -1, 64)).
-		Price(strconv.FormatFloat(price, 'f', -1, 64)).
-		Do(context.Background())
+// toOrderStatus converte o status da Binance. Ordens expiradas contam como canceladas.
+func toOrderStatus(status binance.OrderStatusType) entity.OrderStatus {
+	switch status {
+	case binance.OrderStatusTypeNew, binance.OrderStatusTypePendingCancel:
+		return entity.OrderStatusNew
+	case binance.OrderStatusTypePartiallyFilled:
+		return entity.OrderStatusPartiallyFilled
+	case binance.OrderStatusTypeFilled:
+		return entity.OrderStatusFilled
+	case binance.OrderStatusTypeRejected:
+		return entity.OrderStatusRejected
+	case binance.OrderStatusTypeExpired:
+		return entity.OrderStatusExpired
+	default:
+		return entity.OrderStatusCanceled
+	}
+}
+
+func parseFloat(v string) float64 {
+	f, _ := strconv.ParseFloat(v, 64)
+	return f
 }
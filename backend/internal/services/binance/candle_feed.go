@@ -0,0 +1,256 @@
+// internal/services/binance/candle_feed.go
+
+package binance
+
+import (
+	"time"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+// maxKlinesPerRequest é o limite de klines por requisição REST da Binance.
+const maxKlinesPerRequest = 1000
+
+// candleRangeSource é implementado pelas exchanges que paginam o histórico de klines.
+type candleRangeSource interface {
+	GetCandlesRange(symbol string, interval string, start, end time.Time) ([]entity.Candle, error)
+}
+
+// candleFeed entrega à estratégia a sequência contínua de candles fechados de um par:
+// aquece a janela a partir do store, busca via REST os candles perdidos (reconexões do
+// WebSocket ou mensagens perdidas) e grava no store cada candle entregue. Intervalos agregados
+// (ex: 10m) são montados a partir dos candles de 1m da exchange.
+type candleFeed struct {
+	strategy    *usecases.StrategyUseCase
+	store       repository.CandleRepository // opcional
+	symbol      string                      // formato Binance (ex: BTCUSDT)
+	interval    string
+	barDuration time.Duration
+	aggregated  bool
+	lastOpen    int64 // abertura (ms) do último candle entregue
+}
+
+func newCandleFeed(strategy *usecases.StrategyUseCase, store repository.CandleRepository, symbol, interval string) *candleFeed {
+	barDuration, _ := utils.IntervalDuration(interval)
+	return &candleFeed{
+		strategy:    strategy,
+		store:       store,
+		symbol:      symbol,
+		interval:    interval,
+		barDuration: barDuration,
+		aggregated:  utils.IsAggregatedInterval(interval),
+	}
+}
+
+// warmUp preenche a janela da estratégia com os candles do store, completados pela exchange
+// com os candles fechados depois do último guardado. Se o store estiver vazio ou a lacuna
+// for maior que a janela, usa apenas os últimos candles da exchange. As janelas dos intervalos
+// maiores declarados pela estratégia são semeadas antes, com o histórico da exchange.
+func (f *candleFeed) warmUp() error {
+	f.seedTimeframes()
+	size := f.strategy.CurrentWindowSize()
+
+	var stored []entity.Candle
+	if f.store != nil {
+		var err error
+		if stored, err = f.store.GetLatest(f.symbol, f.interval, size); err != nil {
+			logger.Error("[CandleFeed] Erro ao ler candles do store", err, "symbol", f.symbol, "interval", f.interval)
+			stored = nil
+		}
+	}
+	if len(stored) > 0 {
+		f.lastOpen = stored[len(stored)-1].OpenTime
+	}
+
+	fetched, err := f.fetchSince(f.lastOpen, size)
+	if err != nil {
+		return err
+	}
+	if len(stored) > 0 && len(fetched) > 0 && f.isGap(f.lastOpen, fetched[0].OpenTime) {
+		logger.Info("[CandleFeed] Lacuna maior que a janela, descartando candles do store",
+			"symbol", f.symbol, "interval", f.interval, "stored", len(stored))
+		stored = nil
+	}
+
+	for _, c := range stored {
+		f.strategy.UpdateCandle(c)
+	}
+	f.deliver(fetched)
+
+	logger.Info("[CandleFeed] Janela aquecida",
+		"symbol", f.symbol,
+		"interval", f.interval,
+		"store", len(stored),
+		"exchange", len(fetched),
+	)
+	return nil
+}
+
+// seedTimeframes preenche as janelas dos intervalos maiores com os candles fechados da
+// exchange. Os candles de Bot.Interval do aquecimento completam o período em formação.
+func (f *candleFeed) seedTimeframes() {
+	for interval, size := range f.strategy.Timeframes() {
+		candles, err := newCandleFeed(f.strategy, nil, f.symbol, interval).fetchSince(0, size)
+		if err != nil {
+			logger.Error("[CandleFeed] Erro ao buscar candles do intervalo maior", err, "symbol", f.symbol, "interval", interval)
+			continue
+		}
+		f.strategy.SeedTimeframe(interval, candles)
+		logger.Info("[CandleFeed] Janela de intervalo maior aquecida", "symbol", f.symbol, "interval", interval, "candles", len(candles))
+	}
+}
+
+// accept recebe um candle fechado do WebSocket. Candles repetidos são descartados (false);
+// se houver lacuna desde o último candle entregue, os candles perdidos são buscados e
+// entregues antes dele.
+func (f *candleFeed) accept(candle entity.Candle) bool {
+	if candle.OpenTime <= f.lastOpen {
+		return false
+	}
+	if f.lastOpen > 0 && f.isGap(f.lastOpen, candle.OpenTime) {
+		missing, err := f.fetchSince(f.lastOpen, maxKlinesPerRequest-1)
+		if err != nil {
+			logger.Error("[CandleFeed] Erro ao buscar candles perdidos", err, "symbol", f.symbol, "interval", f.interval)
+		}
+		var before []entity.Candle
+		for _, c := range missing {
+			if c.OpenTime < candle.OpenTime {
+				before = append(before, c)
+			}
+		}
+		f.replay(before)
+		logger.Warn("[CandleFeed] Lacuna no stream preenchida", "symbol", f.symbol, "interval", f.interval, "candles", len(before))
+	}
+
+	f.deliver([]entity.Candle{candle})
+	return true
+}
+
+// backfill busca os candles fechados desde o último entregue (ex: após uma reconexão) e os
+// entrega em ordem, passando apenas pela política de saída (ver replay). Se o último ainda é
+// recente (fechado há menos de um intervalo), ele é apenas entregue e fresh é true: fica para a
+// avaliação completa de quem chamou. Sinais de entrada de candles antigos são descartados.
+func (f *candleFeed) backfill() (last entity.Candle, fresh bool, err error) {
+	missing, err := f.fetchSince(f.lastOpen, maxKlinesPerRequest-1)
+	if err != nil || len(missing) == 0 {
+		return entity.Candle{}, false, err
+	}
+
+	last = missing[len(missing)-1]
+	fresh = time.Now().UnixMilli()-last.CloseTime <= f.barDuration.Milliseconds()
+	if fresh {
+		f.replay(missing[:len(missing)-1])
+		f.deliver([]entity.Candle{last})
+	} else {
+		f.replay(missing)
+	}
+	logger.Info("[CandleFeed] Candles recuperados após reconexão",
+		"symbol", f.symbol, "interval", f.interval, "candles", len(missing), "fresh", fresh)
+	return last, fresh, nil
+}
+
+// replay entrega candles recuperados um a um, aplicando a política de saída da estratégia
+// a cada candle antes do próximo, sem abrir novas posições.
+func (f *candleFeed) replay(candles []entity.Candle) {
+	if len(candles) == 0 {
+		return
+	}
+	for _, c := range candles {
+		f.strategy.UpdateCandle(c)
+		f.strategy.EvaluateExits(c.CloseTime)
+	}
+	f.lastOpen = candles[len(candles)-1].OpenTime
+	f.save(candles)
+}
+
+// deliver alimenta a estratégia e grava os candles no store.
+func (f *candleFeed) deliver(candles []entity.Candle) {
+	if len(candles) == 0 {
+		return
+	}
+	for _, c := range candles {
+		f.strategy.UpdateCandle(c)
+	}
+	f.lastOpen = candles[len(candles)-1].OpenTime
+	f.save(candles)
+}
+
+// save grava os candles entregues no store, se houver.
+func (f *candleFeed) save(candles []entity.Candle) {
+	if f.store == nil {
+		return
+	}
+	if err := f.store.SaveMany(f.symbol, f.interval, candles); err != nil {
+		logger.Error("[CandleFeed] Erro ao gravar candles", err, "symbol", f.symbol, "interval", f.interval)
+	}
+}
+
+// fetchSince busca na exchange os candles fechados abertos depois de lastOpen (todos os
+// últimos, se lastOpen for 0), limitados aos maxCandles mais recentes, em ordem cronológica.
+func (f *candleFeed) fetchSince(lastOpen int64, maxCandles int) ([]entity.Candle, error) {
+	now := time.Now().UnixMilli()
+	limit := maxCandles
+	if lastOpen > 0 && f.barDuration > 0 {
+		limit = min(limit, int((now-lastOpen)/f.barDuration.Milliseconds()))
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	var (
+		klines []entity.Candle
+		err    error
+	)
+	if f.aggregated {
+		klines, err = f.fetchAggregated(limit, now)
+	} else {
+		// +1: o último kline retornado ainda está em formação
+		klines, err = f.strategy.CurrentExchange().GetHistoricalCandles(f.symbol, f.interval, min(limit+1, maxKlinesPerRequest))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]entity.Candle, 0, len(klines))
+	for _, c := range klines {
+		if c.OpenTime > lastOpen && c.CloseTime < now {
+			candles = append(candles, c)
+		}
+	}
+	return candles, nil
+}
+
+// fetchAggregated busca os candles de 1m que cobrem os últimos limit candles do intervalo
+// agregado e os agrega. Sem histórico paginado na exchange, fica limitado aos últimos 1000
+// candles de 1m.
+func (f *candleFeed) fetchAggregated(limit int, now int64) ([]entity.Candle, error) {
+	exchange := f.strategy.CurrentExchange()
+
+	var (
+		base []entity.Candle
+		err  error
+	)
+	if source, ok := exchange.(candleRangeSource); ok {
+		start := time.UnixMilli(now).Add(-time.Duration(limit+1) * f.barDuration)
+		base, err = source.GetCandlesRange(f.symbol, marketdata.BaseInterval, start, time.UnixMilli(now))
+	} else {
+		perBar := int(f.barDuration / time.Minute)
+		base, err = exchange.GetHistoricalCandles(f.symbol, marketdata.BaseInterval, min((limit+1)*perBar, maxKlinesPerRequest))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return marketdata.Aggregate(marketdata.BaseInterval, f.interval, base)
+}
+
+// isGap indica se há candles faltando entre duas aberturas consecutivas. A tolerância de
+// meio intervalo cobre intervalos de duração variável (1M).
+func (f *candleFeed) isGap(previousOpen, nextOpen int64) bool {
+	step := f.barDuration.Milliseconds()
+	return step > 0 && nextOpen-previousOpen > step+step/2
+}
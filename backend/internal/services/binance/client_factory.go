@@ -0,0 +1,102 @@
+// internal/services/binance/client_factory.go
+
+package binance
+
+import (
+	"crypto/sha256"
+	"sync"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	service "github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+var _ service.ExchangeFactory = (*ClientFactory)(nil)
+
+// ClientFactory cria um BinanceService por conta a partir de Account.BinanceAPIKey e
+// Account.BinanceAPISecret. Os serviços ficam em cache enquanto as chaves da conta não mudam,
+// seja pela API, por cmd/rotate-secrets ou por edição direta no banco.
+type ClientFactory struct {
+	mu      sync.Mutex
+	clients map[uuid.UUID]accountClient
+	public  *BinanceService
+	symbols *SymbolCache
+}
+
+// NewClientFactory cria a fábrica com um cliente público (sem credenciais) para dados de mercado.
+// Todos os serviços criados compartilham o mesmo cache de símbolos.
+func NewClientFactory() *ClientFactory {
+	client := binance.NewClient("", "")
+	symbols := NewSymbolCache(client)
+	return &ClientFactory{
+		clients: make(map[uuid.UUID]accountClient),
+		public:  NewBinanceService(client, symbols),
+		symbols: symbols,
+	}
+}
+
+// Symbols retorna o cache de símbolos compartilhado.
+func (f *ClientFactory) Symbols() *SymbolCache {
+	return f.symbols
+}
+
+// Public retorna o serviço sem credenciais, suficiente para dados de mercado e exchange info.
+func (f *ClientFactory) Public() *BinanceService {
+	return f.public
+}
+
+// accountClient é o serviço de uma conta e a impressão digital das chaves usadas para criá-lo.
+type accountClient struct {
+	svc         *BinanceService
+	fingerprint [sha256.Size]byte
+}
+
+// ForAccount retorna o serviço da conta, criando-o na primeira chamada ou quando as chaves
+// da conta mudaram desde a criação. Contas sem chaves cadastradas recebem um cliente sem
+// credenciais: dados de mercado funcionam, mas operações autenticadas serão recusadas pela
+// Binance.
+func (f *ClientFactory) ForAccount(account *entity.Account) (service.ExchangeService, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	apiKey := derefString(account.BinanceAPIKey)
+	apiSecret := derefString(account.BinanceAPISecret)
+	fingerprint := sha256.Sum256([]byte(apiKey + "\x00" + apiSecret))
+
+	cached, ok := f.clients[account.ID]
+	if ok && cached.fingerprint == fingerprint {
+		return cached.svc, nil
+	}
+	if ok {
+		logger.Info("🔑 Chaves da conta alteradas, recriando cliente Binance", "account_id", account.ID.String())
+	}
+
+	if apiKey == "" || apiSecret == "" {
+		logger.Warn("Conta sem credenciais da Binance, usando cliente somente leitura", "account_id", account.ID.String())
+	}
+
+	svc := NewBinanceService(binance.NewClient(apiKey, apiSecret), f.symbols)
+	f.clients[account.ID] = accountClient{svc: svc, fingerprint: fingerprint}
+	logger.Debug("Cliente Binance criado para a conta", "account_id", account.ID.String())
+	return svc, nil
+}
+
+// Invalidate descarta o serviço em cache da conta; o próximo ForAccount usa as chaves atualizadas.
+func (f *ClientFactory) Invalidate(accountID uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.clients[accountID]; ok {
+		delete(f.clients, accountID)
+		logger.Info("🔑 Cliente Binance invalidado", "account_id", accountID.String())
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,32 @@
+// internal/services/binance/client_factory_test.go
+
+package binance_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/binance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFactoryRecreatesClientWhenKeysChange(t *testing.T) {
+	factory := binance.NewClientFactory()
+	key, secret := "key-1", "secret-1"
+	account := &entity.Account{ID: uuid.New(), BinanceAPIKey: &key, BinanceAPISecret: &secret}
+
+	first, err := factory.ForAccount(account)
+	require.NoError(t, err)
+	cached, err := factory.ForAccount(account)
+	require.NoError(t, err)
+	assert.Same(t, first, cached)
+
+	// Chaves rotacionadas fora da API (ex: cmd/rotate-secrets) chegam na conta recarregada do banco
+	rotated := "secret-2"
+	account.BinanceAPISecret = &rotated
+	recreated, err := factory.ForAccount(account)
+	require.NoError(t, err)
+	assert.NotSame(t, first, recreated)
+}
@@ -0,0 +1,45 @@
+// internal/services/binance/kline.go
+
+package binance
+
+import (
+	"github.com/adshao/go-binance/v2"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// candleFromKline converte um kline da API REST.
+func candleFromKline(k *binance.Kline) entity.Candle {
+	return entity.Candle{
+		Open:                parseFloat(k.Open),
+		High:                parseFloat(k.High),
+		Low:                 parseFloat(k.Low),
+		Close:               parseFloat(k.Close),
+		Volume:              parseFloat(k.Volume),
+		QuoteVolume:         parseFloat(k.QuoteAssetVolume),
+		Trades:              k.TradeNum,
+		TakerBuyVolume:      parseFloat(k.TakerBuyBaseAssetVolume),
+		TakerBuyQuoteVolume: parseFloat(k.TakerBuyQuoteAssetVolume),
+		Time:                k.CloseTime / 1000,
+		OpenTime:            k.OpenTime,
+		CloseTime:           k.CloseTime,
+	}
+}
+
+// candleFromWsKline converte o kline do WebSocket com os valores finais enviados pela
+// exchange (máxima, mínima e volumes do candle inteiro, não acumulados localmente).
+func candleFromWsKline(k binance.WsKline) entity.Candle {
+	return entity.Candle{
+		Open:                parseFloat(k.Open),
+		High:                parseFloat(k.High),
+		Low:                 parseFloat(k.Low),
+		Close:               parseFloat(k.Close),
+		Volume:              parseFloat(k.Volume),
+		QuoteVolume:         parseFloat(k.QuoteVolume),
+		Trades:              k.TradeNum,
+		TakerBuyVolume:      parseFloat(k.ActiveBuyVolume),
+		TakerBuyQuoteVolume: parseFloat(k.ActiveBuyQuoteVolume),
+		Time:                k.EndTime / 1000,
+		OpenTime:            k.StartTime,
+		CloseTime:           k.EndTime,
+	}
+}
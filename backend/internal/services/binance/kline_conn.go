@@ -0,0 +1,188 @@
+// internal/services/binance/kline_conn.go
+
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	klineConnReadTimeout  = 2 * time.Minute // sem mensagens nem pings nesse tempo, a conexão é dada como caída
+	klineConnWriteTimeout = 10 * time.Second
+)
+
+// KlineConn é uma conexão combinada de klines aberta. Pares (símbolo -> intervalo) podem ser
+// incluídos e removidos com SUBSCRIBE/UNSUBSCRIBE sem derrubar os demais streams.
+type KlineConn interface {
+	Subscribe(pairs map[string]string) error
+	Unsubscribe(pairs map[string]string) error
+	Done() <-chan struct{} // fechado quando a conexão cai ou é encerrada
+	Close()
+}
+
+// KlineDialFunc abre uma conexão combinada de klines com os pares iniciais.
+type KlineDialFunc func(pairs map[string]string, handler binance.WsKlineHandler, errHandler binance.ErrHandler) (KlineConn, error)
+
+// DialCombinedKlines abre uma conexão combinada de klines na Binance (ou na testnet, se
+// binance.UseTestnet estiver ativo).
+func DialCombinedKlines(pairs map[string]string, handler binance.WsKlineHandler, errHandler binance.ErrHandler) (KlineConn, error) {
+	endpoint := binance.BaseCombinedMainURL
+	if binance.UseTestnet {
+		endpoint = binance.BaseCombinedTestnetURL
+	}
+	return CombinedKlineDialer(endpoint)(pairs, handler, errHandler)
+}
+
+// CombinedKlineDialer retorna um KlineDialFunc para o endpoint de streams combinados
+// informado (terminado em "?streams=").
+func CombinedKlineDialer(endpoint string) KlineDialFunc {
+	return func(pairs map[string]string, handler binance.WsKlineHandler, errHandler binance.ErrHandler) (KlineConn, error) {
+		dialer := websocket.Dialer{
+			Proxy:             http.ProxyFromEnvironment,
+			HandshakeTimeout:  45 * time.Second,
+			EnableCompression: true,
+		}
+		ws, _, err := dialer.Dial(endpoint+strings.Join(klineStreamNames(pairs), "/"), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		conn := &combinedKlineConn{ws: ws, done: make(chan struct{})}
+		go conn.read(handler, errHandler)
+		return conn, nil
+	}
+}
+
+// combinedKlineConn implementa KlineConn sobre o protocolo de streams combinados da Binance.
+type combinedKlineConn struct {
+	ws *websocket.Conn
+
+	writeMu sync.Mutex
+	nextID  int64
+
+	done      chan struct{}
+	closeOnce sync.Once
+	closing   bool // protegido por writeMu: encerrada por Close, erros de leitura não são reportados
+}
+
+// combinedMessage é uma mensagem da conexão combinada: um evento de stream ou a resposta a
+// um SUBSCRIBE/UNSUBSCRIBE.
+type combinedMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	ID     *int64          `json:"id"`
+	Error  *struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+func (c *combinedKlineConn) Subscribe(pairs map[string]string) error {
+	return c.send("SUBSCRIBE", pairs)
+}
+
+func (c *combinedKlineConn) Unsubscribe(pairs map[string]string) error {
+	return c.send("UNSUBSCRIBE", pairs)
+}
+
+func (c *combinedKlineConn) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *combinedKlineConn) Close() {
+	c.writeMu.Lock()
+	c.closing = true
+	c.writeMu.Unlock()
+	c.ws.Close()
+	<-c.done
+}
+
+// send envia um pedido de (des)assinatura. A resposta chega assíncrona pela leitura; erros
+// da Binance são reportados ao errHandler.
+func (c *combinedKlineConn) send(method string, pairs map[string]string) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	c.nextID++
+	c.ws.SetWriteDeadline(time.Now().Add(klineConnWriteTimeout))
+	return c.ws.WriteJSON(map[string]any{
+		"method": method,
+		"params": klineStreamNames(pairs),
+		"id":     c.nextID,
+	})
+}
+
+// read entrega os klines recebidos até a conexão cair ou ser encerrada. Os pings da Binance
+// renovam o prazo de leitura e são respondidos com pong.
+func (c *combinedKlineConn) read(handler binance.WsKlineHandler, errHandler binance.ErrHandler) {
+	defer c.closeOnce.Do(func() { close(c.done) })
+	defer c.ws.Close()
+
+	c.ws.SetReadDeadline(time.Now().Add(klineConnReadTimeout))
+	c.ws.SetPingHandler(func(data string) error {
+		c.ws.SetReadDeadline(time.Now().Add(klineConnReadTimeout))
+		return c.ws.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(klineConnWriteTimeout))
+	})
+
+	for {
+		_, raw, err := c.ws.ReadMessage()
+		if err != nil {
+			c.writeMu.Lock()
+			closing := c.closing
+			c.writeMu.Unlock()
+			if !closing {
+				errHandler(err)
+			}
+			return
+		}
+		c.ws.SetReadDeadline(time.Now().Add(klineConnReadTimeout))
+
+		var msg combinedMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			errHandler(err)
+			continue
+		}
+		if msg.Error != nil {
+			errHandler(fmt.Errorf("pedido %d recusado pela Binance: %d %s", derefID(msg.ID), msg.Error.Code, msg.Error.Msg))
+			continue
+		}
+		if msg.Stream == "" {
+			continue // confirmação de SUBSCRIBE/UNSUBSCRIBE
+		}
+
+		event := new(binance.WsKlineEvent)
+		if err := json.Unmarshal(msg.Data, event); err != nil {
+			errHandler(err)
+			continue
+		}
+		event.Symbol = strings.ToUpper(strings.Split(msg.Stream, "@")[0])
+		handler(event)
+	}
+}
+
+// klineStreamNames monta os nomes dos streams de kline (ex: btcusdt@kline_1m).
+func klineStreamNames(pairs map[string]string) []string {
+	names := make([]string, 0, len(pairs))
+	for symbol, interval := range pairs {
+		names = append(names, fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval))
+	}
+	return names
+}
+
+func derefID(id *int64) int64 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
@@ -0,0 +1,435 @@
+// internal/services/binance/market_hub.go
+
+package binance
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/marketdata"
+	"github.com/jeancarlosdanese/crypto-bot/internal/utils"
+)
+
+const (
+	maxStreamsPerConn   = 200 // a Binance aceita até 1024 streams por conexão; a URL limita antes
+	hubSubscriberBuffer = 64  // candles enfileirados por assinante antes de descartar
+	hubChangeDelay      = time.Second
+	hubReconnectDelay   = 5 * time.Second
+	hubMaxUptime        = 23*time.Hour + 55*time.Minute // a Binance encerra conexões após 24h
+)
+
+// MarketHub mantém uma única assinatura upstream por (símbolo, intervalo), compartilhada
+// por todos os bots interessados, e multiplexa vários símbolos em poucas conexões usando
+// os streams combinados da Binance. Pares incluídos ou removidos depois da conexão aberta são
+// (des)assinados nela mesma, sem reconectar os demais. Os candles fechados são entregues a cada assinante
+// por canal. Intervalos que a Binance não oferece (ex: 10m, 3h) são montados a partir do
+// stream de 1m do símbolo, compartilhado com os demais assinantes.
+type MarketHub struct {
+	dial        KlineDialFunc
+	changeDelay time.Duration
+
+	mu      sync.Mutex
+	streams map[streamKey]*hubStream
+	conns   map[*hubConn]struct{}
+	nextID  int
+	closed  bool
+}
+
+type streamKey struct {
+	symbol   string // formato Binance (ex: BTCUSDT)
+	interval string
+}
+
+func (k streamKey) String() string {
+	return k.symbol + "@" + k.interval
+}
+
+// hubStream é um (símbolo, intervalo) assinado, com a contagem de assinantes.
+type hubStream struct {
+	key         streamKey
+	conn        *hubConn
+	subscribers map[*Subscription]struct{}
+}
+
+// hubConn é uma conexão combinada. Uma conexão aceita apenas um intervalo por símbolo.
+type hubConn struct {
+	id      int
+	pairs   map[string]string // símbolo -> intervalo (protegido por MarketHub.mu)
+	changed chan struct{}     // pares incluídos ou removidos: atualizar a conexão aberta
+	done    chan struct{}     // conexão encerrada (sem assinantes ou hub fechado)
+}
+
+// Subscription recebe os candles fechados de um (símbolo, intervalo). C é fechado quando a
+// assinatura ou o hub são encerrados. Reconnected sinaliza que a conexão upstream caiu e
+// voltou: candles fechados nesse meio tempo não foram entregues.
+type Subscription struct {
+	Symbol      string
+	Interval    string
+	C           <-chan entity.Candle
+	Reconnected <-chan struct{}
+
+	key         streamKey
+	aggregator  *marketdata.Aggregator // nil para intervalos nativos
+	candles     chan entity.Candle
+	reconnected chan struct{}
+	hub         *MarketHub
+	once        sync.Once
+}
+
+// NewMarketHub cria o hub. Se dial for nil, usa DialCombinedKlines.
+func NewMarketHub(dial KlineDialFunc) *MarketHub {
+	if dial == nil {
+		dial = DialCombinedKlines
+	}
+	return &MarketHub{
+		dial:        dial,
+		changeDelay: hubChangeDelay,
+		streams:     make(map[streamKey]*hubStream),
+		conns:       make(map[*hubConn]struct{}),
+	}
+}
+
+// Subscribe assina os candles fechados do par (BTC/USDT ou BTCUSDT) no intervalo. A primeira
+// assinatura de um (símbolo, intervalo) o inclui em uma conexão upstream; as seguintes
+// apenas compartilham os candles recebidos.
+func (h *MarketHub) Subscribe(symbol, interval string) (*Subscription, error) {
+	if !utils.IsSupportedInterval(interval) {
+		return nil, fmt.Errorf("intervalo inválido: %s", interval)
+	}
+	var aggregator *marketdata.Aggregator
+	if utils.IsAggregatedInterval(interval) {
+		var err error
+		if aggregator, err = marketdata.NewAggregator(marketdata.BaseInterval, interval); err != nil {
+			return nil, err
+		}
+	}
+	key := streamKey{symbol: utils.FormatForBinance(symbol), interval: marketdata.SourceInterval(interval)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return nil, fmt.Errorf("market hub encerrado")
+	}
+
+	stream, ok := h.streams[key]
+	if !ok {
+		stream = &hubStream{
+			key:         key,
+			conn:        h.assignConnLocked(key),
+			subscribers: make(map[*Subscription]struct{}),
+		}
+		h.streams[key] = stream
+		logger.Info("[MarketHub] Stream assinado", "stream", key.String(), "conexao", stream.conn.id)
+	}
+
+	candles := make(chan entity.Candle, hubSubscriberBuffer)
+	reconnected := make(chan struct{}, 1)
+	sub := &Subscription{
+		Symbol:      key.symbol,
+		Interval:    interval,
+		C:           candles,
+		Reconnected: reconnected,
+		key:         key,
+		aggregator:  aggregator,
+		candles:     candles,
+		reconnected: reconnected,
+		hub:         h,
+	}
+	stream.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// Close encerra a assinatura. O último assinante de um (símbolo, intervalo) o remove da
+// conexão upstream; conexões sem streams são fechadas.
+func (s *Subscription) Close() {
+	s.once.Do(func() { s.hub.unsubscribe(s) })
+}
+
+// Stats retorna quantos streams e conexões upstream estão ativos.
+func (h *MarketHub) Stats() (streams, conns int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.streams), len(h.conns)
+}
+
+// Close encerra todas as conexões e assinaturas.
+func (h *MarketHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	for conn := range h.conns {
+		close(conn.done)
+	}
+	for _, stream := range h.streams {
+		for sub := range stream.subscribers {
+			close(sub.candles)
+		}
+	}
+	h.conns = make(map[*hubConn]struct{})
+	h.streams = make(map[streamKey]*hubStream)
+}
+
+func (h *MarketHub) unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := sub.key
+	stream, ok := h.streams[key]
+	if !ok {
+		return // hub já encerrado
+	}
+	if _, ok := stream.subscribers[sub]; !ok {
+		return
+	}
+	delete(stream.subscribers, sub)
+	close(sub.candles)
+	if len(stream.subscribers) > 0 {
+		return
+	}
+
+	// Último assinante: o par sai da conexão com um UNSUBSCRIBE; as demais streams seguem
+	// sem reconectar.
+	delete(h.streams, key)
+	conn := stream.conn
+	delete(conn.pairs, key.symbol)
+	logger.Info("[MarketHub] Stream sem assinantes removido", "stream", key.String(), "conexao", conn.id)
+
+	if len(conn.pairs) == 0 {
+		close(conn.done)
+		delete(h.conns, conn)
+		return
+	}
+	conn.signalChanged()
+}
+
+// assignConnLocked inclui o par na primeira conexão com espaço (e sem o símbolo em outro
+// intervalo) ou em uma nova conexão.
+func (h *MarketHub) assignConnLocked(key streamKey) *hubConn {
+	for conn := range h.conns {
+		if _, used := conn.pairs[key.symbol]; !used && len(conn.pairs) < maxStreamsPerConn {
+			conn.pairs[key.symbol] = key.interval
+			conn.signalChanged()
+			return conn
+		}
+	}
+
+	h.nextID++
+	conn := &hubConn{
+		id:      h.nextID,
+		pairs:   map[string]string{key.symbol: key.interval},
+		changed: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	h.conns[conn] = struct{}{}
+	go h.run(conn)
+	return conn
+}
+
+// signalChanged avisa a conexão que seus pares mudaram.
+func (c *hubConn) signalChanged() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+// run mantém a conexão combinada: conecta com os pares atuais, aplica na conexão aberta os
+// pares incluídos ou removidos e reconecta quando ela cai ou atinge o tempo máximo. Só as
+// reconexões avisam os assinantes de possíveis candles perdidos.
+func (h *MarketHub) run(conn *hubConn) {
+	heartbeat := time.NewTicker(5 * time.Minute)
+	defer heartbeat.Stop()
+
+	for connected := false; ; {
+		// Aguarda as mudanças cessarem (ex: vários bots iniciando) antes de conectar
+		if !h.settle(conn) {
+			return
+		}
+
+		active := h.pairs(conn)
+		upstream, err := h.dial(active, h.dispatch, func(err error) {
+			logger.Error("[MarketHub] Erro no WebSocket", err, "conexao", conn.id)
+		})
+		if err != nil {
+			logger.Warn("[MarketHub] Erro ao conectar. Tentando reconectar...", "conexao", conn.id, "erro", err.Error(), "espera", hubReconnectDelay)
+			if !h.sleep(conn, hubReconnectDelay) {
+				return
+			}
+			continue
+		}
+
+		logger.Info("[MarketHub] Conectado", "conexao", conn.id, "streams", len(active))
+		if connected {
+			h.notifyReconnected(conn)
+		}
+		connected = true
+
+		startTime := time.Now()
+		timer := time.NewTimer(hubMaxUptime)
+		stopped := false
+		for waiting := true; waiting; {
+			select {
+			case <-upstream.Done():
+				logger.Warn("[MarketHub] Conexão encerrada. Reconectando...", "conexao", conn.id)
+				waiting = false
+			case <-timer.C:
+				logger.Warn("[MarketHub] Reconectando após tempo máximo de conexão", "conexao", conn.id)
+				waiting = false
+			case <-conn.changed:
+				if !h.settle(conn) {
+					stopped, waiting = true, false
+					break
+				}
+				if err := h.resubscribe(conn, upstream, active); err != nil {
+					logger.Warn("[MarketHub] Erro ao atualizar assinaturas. Reconectando...", "conexao", conn.id, "erro", err.Error())
+					waiting = false
+				}
+			case <-heartbeat.C:
+				uptime := time.Since(startTime).Round(time.Second)
+				logger.Debug("[MarketHub] Conexão viva", "conexao", conn.id, "uptime", uptime.String())
+			case <-conn.done:
+				stopped, waiting = true, false
+			}
+		}
+		timer.Stop()
+		upstream.Close()
+
+		if stopped {
+			logger.Info("[MarketHub] Conexão encerrada", "conexao", conn.id)
+			return
+		}
+		if !h.sleep(conn, hubReconnectDelay) {
+			return
+		}
+	}
+}
+
+// resubscribe (des)assina na conexão aberta os pares que mudaram desde a última assinatura e
+// atualiza active. Um símbolo que trocou de intervalo é removido antes de ser incluído.
+func (h *MarketHub) resubscribe(conn *hubConn, upstream KlineConn, active map[string]string) error {
+	wanted := h.pairs(conn)
+	added, removed := make(map[string]string), make(map[string]string)
+	for symbol, interval := range wanted {
+		if active[symbol] != interval {
+			added[symbol] = interval
+		}
+	}
+	for symbol, interval := range active {
+		if wanted[symbol] != interval {
+			removed[symbol] = interval
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	if err := upstream.Unsubscribe(removed); err != nil {
+		return err
+	}
+	for symbol := range removed {
+		delete(active, symbol)
+	}
+	if err := upstream.Subscribe(added); err != nil {
+		return err
+	}
+	for symbol, interval := range added {
+		active[symbol] = interval
+	}
+
+	logger.Info("[MarketHub] Assinaturas atualizadas", "conexao", conn.id, "incluidos", len(added), "removidos", len(removed), "streams", len(active))
+	return nil
+}
+
+// settle aguarda changeDelay sem novas mudanças na conexão. Retorna false se ela foi encerrada.
+func (h *MarketHub) settle(conn *hubConn) bool {
+	timer := time.NewTimer(h.changeDelay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-conn.changed:
+			timer.Reset(h.changeDelay)
+		case <-timer.C:
+			return true
+		case <-conn.done:
+			return false
+		}
+	}
+}
+
+// sleep aguarda d. Retorna false se a conexão foi encerrada.
+func (h *MarketHub) sleep(conn *hubConn, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-conn.done:
+		return false
+	}
+}
+
+func (h *MarketHub) pairs(conn *hubConn) map[string]string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pairs := make(map[string]string, len(conn.pairs))
+	for symbol, interval := range conn.pairs {
+		pairs[symbol] = interval
+	}
+	return pairs
+}
+
+// dispatch entrega o candle fechado aos assinantes do stream, agregando-o para os assinantes
+// de intervalos agregados. Assinantes com a fila cheia perdem o candle (o consumidor detecta
+// a lacuna) para não travar a conexão compartilhada.
+func (h *MarketHub) dispatch(event *binance.WsKlineEvent) {
+	k := event.Kline
+	if !k.IsFinal {
+		return
+	}
+	key := streamKey{symbol: utils.FormatForBinance(event.Symbol), interval: k.Interval}
+	candle := candleFromWsKline(k)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	stream, ok := h.streams[key]
+	if !ok {
+		return // par removido, UNSUBSCRIBE ainda não aplicado
+	}
+	for sub := range stream.subscribers {
+		out := candle
+		if sub.aggregator != nil {
+			var ok bool
+			if out, ok = sub.aggregator.Add(candle); !ok {
+				continue
+			}
+		}
+		select {
+		case sub.candles <- out:
+		default:
+			logger.Warn("[MarketHub] Assinante lento, candle descartado", "stream", key.String(), "interval", sub.Interval, "open_time", out.OpenTime)
+		}
+	}
+}
+
+// notifyReconnected avisa os assinantes da conexão que candles podem ter sido perdidos.
+func (h *MarketHub) notifyReconnected(conn *hubConn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, stream := range h.streams {
+		if stream.conn != conn {
+			continue
+		}
+		for sub := range stream.subscribers {
+			select {
+			case sub.reconnected <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// internal/services/binance/market_hub_test.go
+
+package binance_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gobinance "github.com/adshao/go-binance/v2"
+	"github.com/gorilla/websocket"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/binance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain inicia o logger uma única vez: as conexões do hub logam em goroutines próprias.
+func TestMain(m *testing.M) {
+	logger.InitLogger()
+	os.Exit(m.Run())
+}
+
+// fakeCombinedServer simula a conexão combinada da Binance, guardando cada conexão aberta.
+type fakeCombinedServer struct {
+	mu    sync.Mutex
+	conns []*fakeConn
+}
+
+type fakeConn struct {
+	mu      sync.Mutex
+	pairs   map[string]string
+	handler gobinance.WsKlineHandler
+	done    chan struct{}
+	once    sync.Once
+}
+
+func (s *fakeCombinedServer) dial(pairs map[string]string, handler gobinance.WsKlineHandler, _ gobinance.ErrHandler) (binance.KlineConn, error) {
+	conn := &fakeConn{pairs: make(map[string]string), handler: handler, done: make(chan struct{})}
+	conn.Subscribe(pairs)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conns = append(s.conns, conn)
+	return conn, nil
+}
+
+// dials retorna quantas conexões foram abertas.
+func (s *fakeCombinedServer) dials() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conns)
+}
+
+// open retorna as conexões ainda não encerradas.
+func (s *fakeCombinedServer) open() []*fakeConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var open []*fakeConn
+	for _, c := range s.conns {
+		select {
+		case <-c.done:
+		default:
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+func (c *fakeConn) Subscribe(pairs map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for symbol, interval := range pairs {
+		c.pairs[symbol] = interval
+	}
+	return nil
+}
+
+func (c *fakeConn) Unsubscribe(pairs map[string]string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for symbol := range pairs {
+		delete(c.pairs, symbol)
+	}
+	return nil
+}
+
+func (c *fakeConn) Done() <-chan struct{} { return c.done }
+
+func (c *fakeConn) Close() { c.once.Do(func() { close(c.done) }) }
+
+// subscribed retorna uma cópia dos pares assinados na conexão.
+func (c *fakeConn) subscribed() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pairs := make(map[string]string, len(c.pairs))
+	for symbol, interval := range c.pairs {
+		pairs[symbol] = interval
+	}
+	return pairs
+}
+
+func (c *fakeConn) send(symbol, interval string, openTime int64, final bool) {
+	c.handler(&gobinance.WsKlineEvent{
+		Symbol: symbol,
+		Kline: gobinance.WsKline{
+			StartTime: openTime,
+			EndTime:   openTime + 59_999,
+			Interval:  interval,
+			Open:      "100",
+			High:      "110",
+			Low:       "90",
+			Close:     "105",
+			Volume:    "3",
+			IsFinal:   final,
+
+			QuoteVolume:          "315",
+			TradeNum:             12,
+			ActiveBuyVolume:      "2",
+			ActiveBuyQuoteVolume: "210",
+		},
+	})
+}
+
+func TestMarketHubSharesUpstreamStreams(t *testing.T) {
+	server := &fakeCombinedServer{}
+	hub := binance.NewMarketHub(server.dial)
+	defer hub.Close()
+
+	first, err := hub.Subscribe("BTC/USDT", "1m")
+	require.NoError(t, err)
+	second, err := hub.Subscribe("BTCUSDT", "1m")
+	require.NoError(t, err)
+	eth, err := hub.Subscribe("ETH/USDT", "1m")
+	require.NoError(t, err)
+	btc5m, err := hub.Subscribe("BTC/USDT", "5m")
+	require.NoError(t, err)
+
+	// BTCUSDT@1m e ETHUSDT@1m compartilham a conexão; BTCUSDT@5m exige outra (um intervalo por símbolo)
+	streams, conns := hub.Stats()
+	assert.Equal(t, 3, streams)
+	assert.Equal(t, 2, conns)
+
+	require.Eventually(t, func() bool { return len(server.open()) == 2 }, 3*time.Second, 20*time.Millisecond)
+	var combined *fakeConn
+	for _, c := range server.open() {
+		if len(c.subscribed()) == 2 {
+			combined = c
+		}
+	}
+	require.NotNil(t, combined)
+	assert.Equal(t, map[string]string{"BTCUSDT": "1m", "ETHUSDT": "1m"}, combined.subscribed())
+
+	// Apenas klines finais são entregues, a todos os assinantes do par
+	combined.send("BTCUSDT", "1m", 60_000, false)
+	combined.send("BTCUSDT", "1m", 60_000, true)
+	for _, sub := range []*binance.Subscription{first, second} {
+		select {
+		case candle := <-sub.C:
+			assert.Equal(t, int64(60_000), candle.OpenTime)
+			assert.Equal(t, int64(119_999), candle.CloseTime)
+			assert.Equal(t, int64(119), candle.Time)
+			assert.Equal(t, 105.0, candle.Close)
+			assert.Equal(t, 315.0, candle.QuoteVolume)
+			assert.Equal(t, int64(12), candle.Trades)
+			assert.Equal(t, 2.0, candle.TakerBuyVolume)
+			assert.Equal(t, 210.0, candle.TakerBuyQuoteVolume)
+		case <-time.After(time.Second):
+			t.Fatal("candle não entregue")
+		}
+	}
+	assert.Empty(t, eth.C)
+	assert.Empty(t, btc5m.C)
+
+	// O par continua assinado enquanto houver assinantes
+	first.Close()
+	streams, _ = hub.Stats()
+	assert.Equal(t, 3, streams)
+	_, ok := <-first.C
+	assert.False(t, ok, "canal fechado ao encerrar a assinatura")
+
+	second.Close()
+	btc5m.Close()
+	streams, conns = hub.Stats()
+	assert.Equal(t, 1, streams)
+	assert.Equal(t, 1, conns, "conexão sem streams é encerrada")
+	require.Eventually(t, func() bool { return len(server.open()) == 1 }, 3*time.Second, 20*time.Millisecond)
+}
+
+func TestMarketHubAggregatesCustomIntervals(t *testing.T) {
+	server := &fakeCombinedServer{}
+	hub := binance.NewMarketHub(server.dial)
+	defer hub.Close()
+
+	minute, err := hub.Subscribe("BTC/USDT", "1m")
+	require.NoError(t, err)
+	sevenMin, err := hub.Subscribe("BTC/USDT", "7m")
+	require.NoError(t, err)
+	assert.Equal(t, "7m", sevenMin.Interval)
+
+	// 7m não é oferecido pela Binance: compartilha o stream de 1m
+	streams, conns := hub.Stats()
+	assert.Equal(t, 1, streams)
+	assert.Equal(t, 1, conns)
+
+	require.Eventually(t, func() bool { return len(server.open()) == 1 }, 3*time.Second, 20*time.Millisecond)
+	upstream := server.open()[0]
+	assert.Equal(t, map[string]string{"BTCUSDT": "1m"}, upstream.subscribed())
+
+	// 00:00 a 00:06 completam o primeiro período de 7m (alinhado ao UTC)
+	for i := int64(0); i < 7; i++ {
+		upstream.send("BTCUSDT", "1m", i*60_000, true)
+	}
+	assert.Len(t, minute.C, 7)
+	require.Len(t, sevenMin.C, 1)
+	candle := <-sevenMin.C
+	assert.Equal(t, int64(0), candle.OpenTime)
+	assert.Equal(t, int64(7*60_000-1), candle.CloseTime)
+	assert.Equal(t, 21.0, candle.Volume)
+	assert.Equal(t, int64(84), candle.Trades)
+	assert.Equal(t, 1470.0, candle.TakerBuyQuoteVolume)
+}
+
+func TestMarketHubRejectsInvalidInterval(t *testing.T) {
+	hub := binance.NewMarketHub((&fakeCombinedServer{}).dial)
+	defer hub.Close()
+
+	_, err := hub.Subscribe("BTC/USDT", "90s")
+	assert.Error(t, err)
+
+	hub.Close()
+	_, err = hub.Subscribe("BTC/USDT", "1m")
+	assert.Error(t, err)
+}
+
+func TestMarketHubUpdatesOpenConnection(t *testing.T) {
+	server := &fakeCombinedServer{}
+	hub := binance.NewMarketHub(server.dial)
+	defer hub.Close()
+
+	btc, err := hub.Subscribe("BTC/USDT", "1m")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(server.open()) == 1 }, 3*time.Second, 20*time.Millisecond)
+	upstream := server.open()[0]
+
+	// Novos pares são assinados na conexão aberta, sem reconectar nem sinalizar lacunas
+	eth, err := hub.Subscribe("ETH/USDT", "1m")
+	require.NoError(t, err)
+	sol, err := hub.Subscribe("SOL/USDT", "1m")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(upstream.subscribed()) == 3 }, 3*time.Second, 20*time.Millisecond)
+
+	eth.Close()
+	require.Eventually(t, func() bool { return len(upstream.subscribed()) == 2 }, 3*time.Second, 20*time.Millisecond)
+	assert.Equal(t, map[string]string{"BTCUSDT": "1m", "SOLUSDT": "1m"}, upstream.subscribed())
+	assert.Equal(t, 1, server.dials())
+	assert.Empty(t, btc.Reconnected)
+	assert.Empty(t, sol.Reconnected)
+
+	// Só a queda da conexão provoca a reconexão e o aviso de candles possivelmente perdidos
+	upstream.Close()
+	for _, sub := range []*binance.Subscription{btc, sol} {
+		select {
+		case <-sub.Reconnected:
+		case <-time.After(10 * time.Second):
+			t.Fatal("reconexão não sinalizada")
+		}
+	}
+	assert.Equal(t, 2, server.dials())
+	assert.Equal(t, map[string]string{"BTCUSDT": "1m", "SOLUSDT": "1m"}, server.open()[0].subscribed())
+}
+
+func TestCombinedKlineDialerSubscribesLive(t *testing.T) {
+	requests := make(chan map[string]any, 4)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "btcusdt@kline_1m", r.URL.Query().Get("streams"))
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+
+		ws.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@kline_1m","data":{"e":"kline","s":"BTCUSDT","k":{"t":60000,"T":119999,"i":"1m","o":"100","h":"110","l":"90","c":"105","v":"3","x":true}}}`))
+		for {
+			var req map[string]any
+			if err := ws.ReadJSON(&req); err != nil {
+				return
+			}
+			requests <- req
+			ws.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":1}`))
+		}
+	}))
+	defer server.Close()
+
+	events := make(chan *gobinance.WsKlineEvent, 1)
+	dial := binance.CombinedKlineDialer("ws" + strings.TrimPrefix(server.URL, "http") + "/stream?streams=")
+	conn, err := dial(map[string]string{"BTCUSDT": "1m"}, func(event *gobinance.WsKlineEvent) { events <- event }, func(err error) {
+		t.Errorf("erro inesperado: %v", err)
+	})
+	require.NoError(t, err)
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "BTCUSDT", event.Symbol)
+		assert.Equal(t, "105", event.Kline.Close)
+		assert.True(t, event.Kline.IsFinal)
+	case <-time.After(time.Second):
+		t.Fatal("kline não entregue")
+	}
+
+	require.NoError(t, conn.Subscribe(map[string]string{"ETHUSDT": "5m"}))
+	require.NoError(t, conn.Unsubscribe(map[string]string{"BTCUSDT": "1m"}))
+	for _, want := range []struct{ method, stream string }{{"SUBSCRIBE", "ethusdt@kline_5m"}, {"UNSUBSCRIBE", "btcusdt@kline_1m"}} {
+		select {
+		case req := <-requests:
+			assert.Equal(t, want.method, req["method"])
+			assert.Equal(t, []any{want.stream}, req["params"])
+		case <-time.After(time.Second):
+			t.Fatalf("%s não recebido", want.method)
+		}
+	}
+
+	conn.Close()
+	select {
+	case <-conn.Done():
+	default:
+		t.Fatal("Done não fechado após Close")
+	}
+}
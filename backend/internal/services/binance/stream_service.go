@@ -3,13 +3,12 @@
 package binance
 
 import (
-	"strconv"
-	"time"
+	"sync"
 
-	"github.com/adshao/go-binance/v2"
 	"github.com/jeancarlosdanese/crypto-bot/internal/app/indicators"
 	"github.com/jeancarlosdanese/crypto-bot/internal/app/usecases"
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
 	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
 	serverws "github.com/jeancarlosdanese/crypto-bot/internal/server/ws"
 	"github.com/jeancarlosdanese/crypto-bot/internal/services"
@@ -24,154 +23,155 @@ type BinanceStreamService interface {
 }
 
 type binanceStreamService struct {
-	strategy       *usecases.StrategyUseCase
-	binanceService *BinanceService
-	active         map[string]chan struct{}
+	strategy *usecases.StrategyUseCase
+	hub      *MarketHub
+	candles  repository.CandleRepository // opcional: store de candles (aquecimento e lacunas)
+	mu       sync.Mutex
+	active   map[string]chan struct{}
+	closed   bool // StopAll chamado: novos Start são ignorados
 }
 
-func NewBinanceStreamService(strategy *usecases.StrategyUseCase, binanceService *BinanceService) BinanceStreamService {
+// NewBinanceStreamService cria o stream de klines da estratégia. Os candles fechados vêm
+// do market hub, compartilhado entre os bots; os dados REST (histórico) são obtidos pela
+// exchange da conta do bot (StrategyUseCase.CurrentExchange). Com um store de candles, a
+// janela é aquecida a partir dele e cada candle fechado é gravado; candles perdidos em
+// reconexões são buscados via REST e entregues em ordem.
+func NewBinanceStreamService(strategy *usecases.StrategyUseCase, hub *MarketHub, candles repository.CandleRepository) BinanceStreamService {
 	return &binanceStreamService{
-		strategy:       strategy,
-		binanceService: binanceService,
-		active:         make(map[string]chan struct{}),
+		strategy: strategy,
+		hub:      hub,
+		candles:  candles,
+		active:   make(map[string]chan struct{}),
 	}
 }
 
 func (b *binanceStreamService) Start(symbol, interval string) error {
 	symbol = utils.FormatForBinance(symbol)
+	bot := b.strategy.CurrentBot()
 	logger.Info("[StreamService] Iniciando monitoramento",
-		"bot_id", b.strategy.Bot.ID,
-		"par", b.strategy.Bot.Symbol, // Ex: BTC/USDT
+		"bot_id", bot.ID,
+		"par", bot.Symbol, // Ex: BTC/USDT
 		"interval", interval,
 	)
 
-	candles, err := b.binanceService.GetHistoricalCandles(symbol, interval, b.strategy.WindowSize)
-	if err != nil {
+	feed := newCandleFeed(b.strategy, b.candles, symbol, interval)
+	if err := feed.warmUp(); err != nil {
 		logger.Error("[StreamService] Erro ao obter candles históricos", err, "symbol", symbol)
 		return err
 	}
 
-	for _, c := range candles {
-		b.strategy.UpdateCandle(c)
-	}
-
 	b.strategy.CalibrateLastEntry()
 	stopChan := make(chan struct{})
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		logger.Info("[StreamService] Stream encerrado antes de conectar", "symbol", symbol)
+		return nil
+	}
 	b.active[symbol] = stopChan
+	b.mu.Unlock()
 
-	go func() {
-		var current entity.Candle
-		reconnectDelay := 5 * time.Second
-		const maxUptime = 23*time.Hour + 55*time.Minute
-		heartbeatTicker := time.NewTicker(5 * time.Minute)
-		defer heartbeatTicker.Stop()
+	sub, err := b.hub.Subscribe(symbol, interval)
+	if err != nil {
+		b.mu.Lock()
+		delete(b.active, symbol)
+		b.mu.Unlock()
+		logger.Error("[StreamService] Erro ao assinar candles", err, "symbol", symbol)
+		return err
+	}
 
+	go func() {
+		defer sub.Close()
 		for {
-			timer := time.NewTimer(maxUptime)
-
-			wsHandler := func(event *binance.WsKlineEvent) {
-				k := event.Kline
-				open, _ := strconv.ParseFloat(k.Open, 64)
-				high, _ := strconv.ParseFloat(k.High, 64)
-				low, _ := strconv.ParseFloat(k.Low, 64)
-				closeVal, _ := strconv.ParseFloat(k.Close, 64)
-				volume, _ := strconv.ParseFloat(k.Volume, 64)
-
-				if current.Open == 0 {
-					current = entity.Candle{
-						Open:   open,
-						High:   high,
-						Low:    low,
-						Close:  closeVal,
-						Volume: volume,
-					}
-				} else {
-					if high > current.High {
-						current.High = high
-					}
-					if low < current.Low {
-						current.Low = low
-					}
-					current.Close = closeVal
+			select {
+			case candle, ok := <-sub.C:
+				if !ok {
+					logger.Info("[StreamService] Assinatura encerrada pelo market hub", "symbol", symbol)
+					return
 				}
-
-				if k.IsFinal {
-					b.strategy.UpdateCandle(current)
-
-					// 🔹 Calcular médias
-					prices := b.strategy.ClosingPrices()
-					ma9 := indicators.MovingAverage(prices, 9)
-					ma26 := indicators.MovingAverage(prices, 26)
-
-					// 🔥 Publicar candle com médias
-					serverws.Publish(b.strategy.Bot.ID.String(), serverws.Event{
-						Type: "candle",
-						Data: map[string]interface{}{
-							"time":  k.EndTime / 1000,
-							"open":  current.Open,
-							"high":  current.High,
-							"low":   current.Low,
-							"close": current.Close,
-							"ma9":   ma9,
-							"ma26":  ma26,
-						},
-					})
-
-					// timestamp do candle finalizado (já vem como int64 da Binance)
-					decision := b.strategy.EvaluateCrossover(k.EndTime)
-
-					if decision != "HOLD" {
-						logger.Info("[StreamService] Decisão tomada",
-							"symbol", symbol,
-							"interval", interval,
-							"decision", decision,
-						)
-					}
-
-					current = entity.Candle{}
+				if feed.accept(candle) {
+					b.evaluate(symbol, interval, candle)
 				}
-			}
-
-			errHandler := func(err error) {
-				logger.Error("[StreamService] Erro no WebSocket", err, "symbol", symbol)
-			}
-
-			done, _, err := binance.WsKlineServe(symbol, interval, wsHandler, errHandler)
-			if err != nil {
-				logger.Warn("[StreamService] Erro ao conectar. Tentando reconectar...", "symbol", symbol, "espera", reconnectDelay)
-				time.Sleep(reconnectDelay)
-				continue
-			}
 
-			startTime := time.Now()
-
-			run := true
-			for run {
-				select {
-				case <-done:
-					logger.Warn("[StreamService] Conexão encerrada. Reconnectando...", "symbol", symbol)
-					run = false
-				case <-timer.C:
-					logger.Warn("[StreamService] Reconectando após tempo máximo de conexão", "symbol", symbol)
-					run = false
-				case <-heartbeatTicker.C:
-					uptime := time.Since(startTime).Round(time.Second)
-					logger.Debug("[StreamService] Conexão viva", "symbol", symbol, "uptime", uptime.String())
-				case <-stopChan:
-					logger.Info("[StreamService] Stream parada manualmente", "symbol", symbol)
-					timer.Stop()
-					return
+			// 🕳️ Candles fechados enquanto a conexão upstream estava caída
+			case <-sub.Reconnected:
+				last, fresh, err := feed.backfill()
+				switch {
+				case err != nil:
+					logger.Error("[StreamService] Erro ao recuperar candles após reconexão", err, "symbol", symbol)
+				case fresh:
+					b.evaluate(symbol, interval, last)
+				case last.OpenTime > 0:
+					// Candle antigo: já passou pela política de saída, só atualiza o gráfico
+					b.publish(last)
 				}
-			}
 
-			timer.Stop()
-			time.Sleep(reconnectDelay)
+			case <-stopChan:
+				logger.Info("[StreamService] Stream parada manualmente", "symbol", symbol)
+				return
+			}
 		}
 	}()
 
 	return nil
 }
 
+// evaluate publica o candle fechado (já entregue à estratégia) e avalia a estratégia.
+func (b *binanceStreamService) evaluate(symbol, interval string, candle entity.Candle) {
+	b.publish(candle)
+
+	// timestamp do candle finalizado (ms)
+	decision := b.strategy.Evaluate(candle.CloseTime)
+
+	if decision != "HOLD" {
+		logger.Info("[StreamService] Decisão tomada",
+			"symbol", symbol,
+			"interval", interval,
+			"strategy", b.strategy.StrategyName(),
+			"decision", decision,
+		)
+	}
+}
+
+// publish envia aos clientes do bot o candle fechado, com médias e indicadores da janela.
+// Bot e janela são lidos por cópia, pois a configuração pode mudar a quente (ApplyConfig).
+func (b *binanceStreamService) publish(candle entity.Candle) {
+	botID := b.strategy.CurrentBot().ID.String()
+	window := b.strategy.CandlesSnapshot()
+
+	// 🔹 Calcular médias
+	prices := make([]float64, len(window))
+	for i, c := range window {
+		prices[i] = c.Close
+	}
+	ma9 := indicators.MovingAverage(prices, 9)
+	ma26 := indicators.MovingAverage(prices, 26)
+
+	// 🔥 Publicar candle com médias
+	serverws.Publish(botID, serverws.Event{
+		Type: "candle",
+		Data: map[string]interface{}{
+			"time":                   candle.Time,
+			"open_time":              candle.OpenTime,
+			"close_time":             candle.CloseTime,
+			"open":                   candle.Open,
+			"high":                   candle.High,
+			"low":                    candle.Low,
+			"close":                  candle.Close,
+			"volume":                 candle.Volume,
+			"quote_volume":           candle.QuoteVolume,
+			"trades":                 candle.Trades,
+			"taker_buy_volume":       candle.TakerBuyVolume,
+			"taker_buy_quote_volume": candle.TakerBuyQuoteVolume,
+			"ma9":                    ma9,
+			"ma26":                   ma26,
+		},
+	})
+
+	// 📈 Valores dos indicadores assinados pelos clientes do gráfico
+	serverws.PublishIndicators(botID, window)
+}
+
 // StartMany inicia múltiplas streams de forma assíncrona
 // pairs é um mapa onde a chave é o símbolo e o valor é o intervalo
 // Exemplo:
@@ -192,6 +192,10 @@ func (b *binanceStreamService) StartMany(pairs map[string]string) error {
 }
 
 func (b *binanceStreamService) Stop(symbol string) {
+	symbol = utils.FormatForBinance(symbol)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	if ch, ok := b.active[symbol]; ok {
 		close(ch)
 		delete(b.active, symbol)
@@ -200,7 +204,15 @@ func (b *binanceStreamService) Stop(symbol string) {
 }
 
 func (b *binanceStreamService) StopAll() {
+	b.mu.Lock()
+	b.closed = true
+	symbols := make([]string, 0, len(b.active))
 	for symbol := range b.active {
+		symbols = append(symbols, symbol)
+	}
+	b.mu.Unlock()
+
+	for _, symbol := range symbols {
 		b.Stop(symbol)
 	}
 }
@@ -0,0 +1,175 @@
+// internal/services/binance/symbol_cache.go
+
+package binance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+)
+
+const (
+	defaultSymbolRefreshInterval = time.Hour
+	minSymbolRefreshGap          = time.Minute // evita rebaixar o ExchangeInfo a cada símbolo desconhecido
+)
+
+// SymbolCache mantém em memória os ativos e filtros de todos os símbolos da Binance,
+// baixando o ExchangeInfo uma vez e atualizando-o periodicamente (ver Run).
+type SymbolCache struct {
+	client   *binance.Client
+	interval time.Duration
+
+	mu       sync.RWMutex
+	symbols  map[string]*entity.SymbolInfo
+	loadedAt time.Time
+}
+
+// NewSymbolCache cria o cache. O intervalo de atualização vem de SYMBOL_INFO_REFRESH_INTERVAL
+// (ex.: "1h"), com padrão de 1 hora.
+func NewSymbolCache(client *binance.Client) *SymbolCache {
+	interval := defaultSymbolRefreshInterval
+	if v := os.Getenv("SYMBOL_INFO_REFRESH_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			interval = d
+		} else {
+			logger.Warn("SYMBOL_INFO_REFRESH_INTERVAL inválido, usando padrão", "valor", v, "padrao", interval)
+		}
+	}
+	return &SymbolCache{
+		client:   client,
+		interval: interval,
+		symbols:  make(map[string]*entity.SymbolInfo),
+	}
+}
+
+// Get retorna os dados do símbolo (ex: BTCUSDT). O cache é carregado na primeira chamada
+// e recarregado quando o símbolo não é encontrado (listagens novas).
+func (c *SymbolCache) Get(symbol string) (*entity.SymbolInfo, error) {
+	c.mu.RLock()
+	info, ok := c.symbols[symbol]
+	stale := time.Since(c.loadedAt) > minSymbolRefreshGap
+	c.mu.RUnlock()
+
+	if ok {
+		return info, nil
+	}
+	if stale {
+		if err := c.Refresh(); err != nil {
+			return nil, err
+		}
+		c.mu.RLock()
+		info, ok = c.symbols[symbol]
+		c.mu.RUnlock()
+		if ok {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("símbolo não encontrado: %s", symbol)
+}
+
+// Refresh baixa o ExchangeInfo e substitui o cache.
+func (c *SymbolCache) Refresh() error {
+	info, err := c.client.NewExchangeInfoService().Do(context.Background())
+	if err != nil {
+		return fmt.Errorf("erro ao obter exchange info: %w", err)
+	}
+
+	symbols := make(map[string]*entity.SymbolInfo, len(info.Symbols))
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		symbols[s.Symbol] = toSymbolInfo(s)
+	}
+
+	c.mu.Lock()
+	c.symbols = symbols
+	c.loadedAt = time.Now()
+	c.mu.Unlock()
+
+	logger.Debug("Exchange info atualizado", "symbols", len(symbols))
+	return nil
+}
+
+// Run atualiza o cache a cada intervalo, até o contexto ser cancelado.
+func (c *SymbolCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(); err != nil {
+				logger.Error("Erro ao atualizar exchange info", err)
+			}
+		}
+	}
+}
+
+func toSymbolInfo(s *binance.Symbol) *entity.SymbolInfo {
+	info := &entity.SymbolInfo{
+		Symbol:     s.Symbol,
+		Status:     s.Status,
+		BaseAsset:  s.BaseAsset,
+		QuoteAsset: s.QuoteAsset,
+	}
+
+	if f := s.LotSizeFilter(); f != nil {
+		info.StepSize = parseFloat(f.StepSize)
+		info.MinQty = parseFloat(f.MinQuantity)
+		info.MaxQty = parseFloat(f.MaxQuantity)
+	}
+	if f := s.MarketLotSizeFilter(); f != nil {
+		info.MarketStepSize = parseFloat(f.StepSize)
+		info.MarketMinQty = parseFloat(f.MinQuantity)
+		info.MarketMaxQty = parseFloat(f.MaxQuantity)
+	}
+	if f := s.PriceFilter(); f != nil {
+		info.TickSize = parseFloat(f.TickSize)
+		info.MinPrice = parseFloat(f.MinPrice)
+		info.MaxPrice = parseFloat(f.MaxPrice)
+	}
+	if f := s.NotionalFilter(); f != nil {
+		info.MinNotional = parseFloat(f.MinNotional)
+		info.ApplyMinNotionalToMarket = f.ApplyMinToMarket
+		info.MaxNotional = parseFloat(f.MaxNotional)
+		info.ApplyMaxNotionalToMarket = f.ApplyMaxToMarket
+	}
+	if f := s.PercentPriceBySideFilter(); f != nil {
+		info.BidMultiplierUp = parseFloat(f.BidMultiplierUp)
+		info.BidMultiplierDown = parseFloat(f.BidMultiplierDown)
+		info.AskMultiplierUp = parseFloat(f.AskMultiplierUp)
+		info.AskMultiplierDown = parseFloat(f.AskMultiplierDown)
+	}
+
+	// Filtros antigos, ainda presentes em alguns símbolos
+	for _, f := range s.Filters {
+		switch f["filterType"] {
+		case "MIN_NOTIONAL":
+			if info.MinNotional == 0 {
+				info.MinNotional = filterFloat(f, "minNotional")
+				info.ApplyMinNotionalToMarket, _ = f["applyToMarket"].(bool)
+			}
+		case "PERCENT_PRICE":
+			if info.BidMultiplierUp == 0 {
+				up, down := filterFloat(f, "multiplierUp"), filterFloat(f, "multiplierDown")
+				info.BidMultiplierUp, info.AskMultiplierUp = up, up
+				info.BidMultiplierDown, info.AskMultiplierDown = down, down
+			}
+		}
+	}
+	return info
+}
+
+func filterFloat(filter map[string]interface{}, key string) float64 {
+	v, _ := filter[key].(string)
+	f, _ := strconv.ParseFloat(v, 64)
+	return f
+}
@@ -0,0 +1,15 @@
+// internal/services/exchange_factory.go
+
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// ExchangeFactory constrói (e mantém em cache) um ExchangeService por conta,
+// usando as credenciais armazenadas na própria conta.
+type ExchangeFactory interface {
+	ForAccount(account *entity.Account) (ExchangeService, error)
+	Invalidate(accountID uuid.UUID)
+}
@@ -6,9 +6,13 @@ import (
 	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
 )
 
+// ExchangeService reúne dados de mercado e envio de ordens de uma exchange.
 type ExchangeService interface {
+	OrderService
+
 	GetAccountPositions() error
 	GetCurrentPrice(symbol string) (float64, error)
 	GetHistoricalCandles(symbol string, interval string, limit int) ([]entity.Candle, error)
 	GetBaseQuote(symbol string) (string, string, error)
+	GetSymbolInfo(symbol string) (*entity.SymbolInfo, error)
 }
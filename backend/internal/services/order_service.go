@@ -0,0 +1,27 @@
+// internal/services/order_service.go
+
+package services
+
+import (
+	"errors"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// ErrOrderNotFound indica uma ordem desconhecida pela exchange (ex: ordem simulada perdida
+// no reinício ou criada em outro modo de operação).
+var ErrOrderNotFound = errors.New("ordem não encontrada")
+
+// OrderService envia e acompanha ordens numa exchange (real ou simulada).
+type OrderService interface {
+	PlaceOrder(req entity.OrderRequest) (*entity.Order, error)
+	CancelOrder(symbol, exchangeOrderID string) (*entity.Order, error)
+	GetOrder(symbol, exchangeOrderID string) (*entity.Order, error)
+	GetFreeBalance(asset string) (float64, error)
+}
+
+// CandleListener é implementado por exchanges que precisam acompanhar os candles
+// recebidos pelos bots (ex: paper trading executando ordens limitadas).
+type CandleListener interface {
+	OnCandle(symbol string, candle entity.Candle)
+}
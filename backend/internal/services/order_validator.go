@@ -0,0 +1,157 @@
+// internal/services/order_validator.go
+
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// ErrOrderFilter indica uma ordem recusada pelos filtros do símbolo antes do envio.
+var ErrOrderFilter = errors.New("ordem fora dos filtros do símbolo")
+
+// PrepareOrder ajusta a ordem aos filtros do símbolo e recusa o que a exchange rejeitaria.
+// A quantidade é arredondada para baixo no step size; o preço de ordens limitadas é
+// arredondado no tick size (para baixo na compra, para cima na venda). refPrice é o
+// preço atual, usado no notional de ordens a mercado e no filtro de preço percentual.
+func PrepareOrder(info *entity.SymbolInfo, req entity.OrderRequest, refPrice float64) (entity.OrderRequest, error) {
+	if info == nil {
+		return req, nil
+	}
+
+	market := req.Type == entity.OrderTypeMarket
+	stepSize, minQty, maxQty := lotSize(info, market)
+
+	req.Quantity = FloorToStep(req.Quantity, stepSize)
+	if req.Quantity <= 0 {
+		return req, fmt.Errorf("%w: quantidade menor que o step size %v", ErrOrderFilter, stepSize)
+	}
+	if minQty > 0 && req.Quantity < minQty {
+		return req, fmt.Errorf("%w: quantidade %v abaixo do mínimo %v", ErrOrderFilter, req.Quantity, minQty)
+	}
+	if maxQty > 0 && req.Quantity > maxQty {
+		return req, fmt.Errorf("%w: quantidade %v acima do máximo %v", ErrOrderFilter, req.Quantity, maxQty)
+	}
+
+	price := refPrice
+	if !market {
+		if req.Side == entity.OrderSideSell {
+			req.Price = CeilToStep(req.Price, info.TickSize)
+		} else {
+			req.Price = FloorToStep(req.Price, info.TickSize)
+		}
+		price = req.Price
+
+		if info.MinPrice > 0 && price < info.MinPrice {
+			return req, fmt.Errorf("%w: preço %v abaixo do mínimo %v", ErrOrderFilter, price, info.MinPrice)
+		}
+		if info.MaxPrice > 0 && price > info.MaxPrice {
+			return req, fmt.Errorf("%w: preço %v acima do máximo %v", ErrOrderFilter, price, info.MaxPrice)
+		}
+		if err := checkPercentPrice(info, req, refPrice); err != nil {
+			return req, err
+		}
+	}
+
+	if price <= 0 {
+		return req, nil
+	}
+	notional := req.Quantity * price
+	if info.MinNotional > 0 && (!market || info.ApplyMinNotionalToMarket) && notional < info.MinNotional {
+		return req, fmt.Errorf("%w: valor %.8f abaixo do mínimo %v", ErrOrderFilter, notional, info.MinNotional)
+	}
+	if info.MaxNotional > 0 && (!market || info.ApplyMaxNotionalToMarket) && notional > info.MaxNotional {
+		return req, fmt.Errorf("%w: valor %.8f acima do máximo %v", ErrOrderFilter, notional, info.MaxNotional)
+	}
+	return req, nil
+}
+
+// lotSize retorna os filtros de quantidade (LOT_SIZE ou, a mercado, MARKET_LOT_SIZE).
+func lotSize(info *entity.SymbolInfo, market bool) (stepSize, minQty, maxQty float64) {
+	stepSize, minQty, maxQty = info.StepSize, info.MinQty, info.MaxQty
+	if market {
+		if info.MarketStepSize > 0 {
+			stepSize = info.MarketStepSize
+		}
+		if info.MarketMinQty > 0 {
+			minQty = info.MarketMinQty
+		}
+		if info.MarketMaxQty > 0 {
+			maxQty = info.MarketMaxQty
+		}
+	}
+	return stepSize, minQty, maxQty
+}
+
+// FloorToMarketLot arredonda a quantidade para baixo no step size das ordens a mercado,
+// para que a posição possa ser vendida inteira. Sem filtros (info nil), não altera.
+func FloorToMarketLot(info *entity.SymbolInfo, quantity float64) float64 {
+	if info == nil {
+		return quantity
+	}
+	stepSize, _, _ := lotSize(info, true)
+	return FloorToStep(quantity, stepSize)
+}
+
+// IsDust informa se a quantidade não pode ser vendida a mercado a price por estar abaixo
+// do step size, da quantidade mínima ou do valor mínimo do símbolo.
+func IsDust(info *entity.SymbolInfo, quantity, price float64) bool {
+	if info == nil {
+		return false
+	}
+	stepSize, minQty, _ := lotSize(info, true)
+	quantity = FloorToStep(quantity, stepSize)
+	if quantity <= 0 || (minQty > 0 && quantity < minQty) {
+		return true
+	}
+	return price > 0 && info.MinNotional > 0 && info.ApplyMinNotionalToMarket && quantity*price < info.MinNotional
+}
+
+func checkPercentPrice(info *entity.SymbolInfo, req entity.OrderRequest, refPrice float64) error {
+	if refPrice <= 0 {
+		return nil
+	}
+	up, down := info.BidMultiplierUp, info.BidMultiplierDown
+	if req.Side == entity.OrderSideSell {
+		up, down = info.AskMultiplierUp, info.AskMultiplierDown
+	}
+	if up > 0 && req.Price > refPrice*up {
+		return fmt.Errorf("%w: preço %v acima de %.2f%% do preço atual", ErrOrderFilter, req.Price, up*100)
+	}
+	if down > 0 && req.Price < refPrice*down {
+		return fmt.Errorf("%w: preço %v abaixo de %.2f%% do preço atual", ErrOrderFilter, req.Price, down*100)
+	}
+	return nil
+}
+
+// FloorToStep arredonda value para baixo no múltiplo de step mais próximo.
+func FloorToStep(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	// A tolerância evita que 0.3/0.1 = 2.9999999 vire 2 steps
+	return trimToStep(math.Floor(value/step+1e-9)*step, step)
+}
+
+// CeilToStep arredonda value para cima no múltiplo de step mais próximo.
+func CeilToStep(value, step float64) float64 {
+	if step <= 0 {
+		return value
+	}
+	return trimToStep(math.Ceil(value/step-1e-9)*step, step)
+}
+
+// trimToStep remove o ruído de ponto flutuante, limitando as casas decimais às do step.
+func trimToStep(value, step float64) float64 {
+	decimals := 0
+	if s := strconv.FormatFloat(step, 'f', -1, 64); strings.Contains(s, ".") {
+		decimals = len(s) - strings.Index(s, ".") - 1
+	}
+	pow := math.Pow10(decimals)
+	return math.Round(value*pow) / pow
+}
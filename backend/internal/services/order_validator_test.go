@@ -0,0 +1,79 @@
+// internal/services/order_validator_test.go
+
+package services_test
+
+import (
+	"testing"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var btcusdt = &entity.SymbolInfo{
+	Symbol:            "BTCUSDT",
+	StepSize:          0.00001,
+	MinQty:            0.00001,
+	TickSize:          0.01,
+	MinNotional:       5,
+	BidMultiplierUp:   5,
+	BidMultiplierDown: 0.2,
+	AskMultiplierUp:   5,
+	AskMultiplierDown: 0.2,
+}
+
+func TestPrepareOrderRoundsToFilters(t *testing.T) {
+	req, err := services.PrepareOrder(btcusdt, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeLimit,
+		Quantity: 0.0012345678, Price: 60000.129,
+	}, 60000)
+	require.NoError(t, err)
+	assert.Equal(t, 0.00123, req.Quantity)
+	assert.Equal(t, 60000.12, req.Price)
+
+	req, err = services.PrepareOrder(btcusdt, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideSell, Type: entity.OrderTypeLimit,
+		Quantity: 0.3, Price: 60000.121,
+	}, 60000)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, req.Quantity)
+	assert.Equal(t, 60000.13, req.Price)
+}
+
+func TestPrepareOrderRejectsOutsideFilters(t *testing.T) {
+	// Abaixo do notional mínimo
+	_, err := services.PrepareOrder(btcusdt, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeLimit, Quantity: 0.00005, Price: 60000,
+	}, 60000)
+	assert.ErrorIs(t, err, services.ErrOrderFilter)
+
+	// Market sem applyMinToMarket não verifica o notional mínimo
+	_, err = services.PrepareOrder(btcusdt, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeMarket, Quantity: 0.00005,
+	}, 60000)
+	assert.NoError(t, err)
+
+	// Quantidade menor que o step
+	_, err = services.PrepareOrder(btcusdt, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeMarket, Quantity: 0.000001,
+	}, 60000)
+	assert.ErrorIs(t, err, services.ErrOrderFilter)
+
+	// Preço fora do PERCENT_PRICE
+	_, err = services.PrepareOrder(btcusdt, entity.OrderRequest{
+		Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeLimit, Quantity: 1, Price: 1000,
+	}, 60000)
+	assert.ErrorIs(t, err, services.ErrOrderFilter)
+}
+
+func TestIsDust(t *testing.T) {
+	info := &entity.SymbolInfo{StepSize: 0.001, MinQty: 0.002, MinNotional: 5, ApplyMinNotionalToMarket: true}
+
+	assert.True(t, services.IsDust(info, 0.0004, 100), "abaixo do step size")
+	assert.True(t, services.IsDust(info, 0.0015, 100000), "abaixo da quantidade mínima")
+	assert.True(t, services.IsDust(info, 0.04, 100), "abaixo do valor mínimo")
+	assert.False(t, services.IsDust(info, 0.06, 100))
+	assert.False(t, services.IsDust(nil, 0.0001, 100), "sem filtros nada é resíduo")
+	assert.Equal(t, 0.979, services.FloorToMarketLot(info, 0.97902))
+}
@@ -0,0 +1,50 @@
+// internal/services/paper/factory.go
+
+package paper
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+// Factory mantém uma PaperExchange por conta, compartilhada por todos os bots
+// da conta em modo paper (os saldos virtuais são da conta, não do bot).
+type Factory struct {
+	market services.ExchangeService
+	cfg    Config
+	ledger repository.PaperLedgerRepository
+
+	mu        sync.Mutex
+	exchanges map[uuid.UUID]*PaperExchange
+}
+
+// NewFactory cria a fábrica. market fornece os dados de mercado (não precisa de credenciais).
+func NewFactory(market services.ExchangeService, cfg Config, ledger repository.PaperLedgerRepository) *Factory {
+	return &Factory{
+		market:    market,
+		cfg:       cfg,
+		ledger:    ledger,
+		exchanges: make(map[uuid.UUID]*PaperExchange),
+	}
+}
+
+// ForAccount retorna a exchange simulada da conta, criando-a na primeira chamada.
+func (f *Factory) ForAccount(account *entity.Account) (*PaperExchange, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if p, ok := f.exchanges[account.ID]; ok {
+		return p, nil
+	}
+
+	p, err := NewPaperExchange(account.ID, f.market, f.cfg, f.ledger)
+	if err != nil {
+		return nil, err
+	}
+	f.exchanges[account.ID] = p
+	return p, nil
+}
@@ -0,0 +1,511 @@
+// internal/services/paper/paper_exchange.go
+
+package paper
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/repository"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services"
+)
+
+var (
+	_ services.ExchangeService = (*PaperExchange)(nil)
+	_ services.OrderService    = (*PaperExchange)(nil)
+	_ services.CandleListener  = (*PaperExchange)(nil)
+)
+
+// ErrInsufficientBalance indica saldo virtual insuficiente para a ordem.
+var ErrInsufficientBalance = errors.New("saldo insuficiente")
+
+// Config define as taxas, o slippage e os saldos iniciais da simulação.
+// Taxas e slippage são frações (0.001 = 0,1%).
+type Config struct {
+	TakerFee        float64
+	MakerFee        float64
+	Slippage        float64
+	InitialBalances map[string]float64
+}
+
+// DefaultConfig usa as taxas padrão da Binance Spot e 10.000 USDT de saldo inicial.
+func DefaultConfig() Config {
+	return Config{
+		TakerFee:        0.001,
+		MakerFee:        0.001,
+		Slippage:        0.0005,
+		InitialBalances: map[string]float64{"USDT": 10000},
+	}
+}
+
+// ConfigFromEnv lê PAPER_TAKER_FEE, PAPER_MAKER_FEE, PAPER_SLIPPAGE e PAPER_INITIAL_USDT,
+// mantendo os valores padrão para as variáveis ausentes.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig()
+	readFloat := func(name string, target *float64) {
+		if v := os.Getenv(name); v != "" {
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+				*target = f
+			} else {
+				logger.Warn("Variável de paper trading inválida, usando padrão", "variavel", name, "valor", v)
+			}
+		}
+	}
+	readFloat("PAPER_TAKER_FEE", &cfg.TakerFee)
+	readFloat("PAPER_MAKER_FEE", &cfg.MakerFee)
+	readFloat("PAPER_SLIPPAGE", &cfg.Slippage)
+
+	initial := cfg.InitialBalances["USDT"]
+	readFloat("PAPER_INITIAL_USDT", &initial)
+	cfg.InitialBalances = map[string]float64{"USDT": initial}
+	return cfg
+}
+
+type balance struct {
+	free   float64
+	locked float64
+}
+
+// PaperExchange simula uma exchange para uma conta: mantém saldos virtuais, executa
+// ordens a mercado no último preço conhecido (com slippage e taxa taker) e ordens
+// limitadas quando um candle cruza o preço (taxa maker). Dados de mercado vêm do
+// ExchangeService real. Cada variação de saldo é gravada no ledger; ordens limitadas
+// pendentes ficam apenas em memória.
+type PaperExchange struct {
+	market    services.ExchangeService
+	accountID uuid.UUID
+	cfg       Config
+	ledger    repository.PaperLedgerRepository // opcional (nil em backtests)
+
+	mu        sync.Mutex
+	balances  map[string]*balance
+	orders    map[string]*entity.Order
+	open      []*entity.Order
+	lastPrice map[string]float64
+	assets    map[string][2]string // symbol -> base, quote
+	seq       int64
+}
+
+// NewPaperExchange cria a exchange simulada da conta, restaurando os saldos do ledger.
+// Sem lançamentos anteriores, a conta recebe os saldos iniciais da configuração.
+func NewPaperExchange(accountID uuid.UUID, market services.ExchangeService, cfg Config, ledger repository.PaperLedgerRepository) (*PaperExchange, error) {
+	p := &PaperExchange{
+		market:    market,
+		accountID: accountID,
+		cfg:       cfg,
+		ledger:    ledger,
+		balances:  make(map[string]*balance),
+		orders:    make(map[string]*entity.Order),
+		lastPrice: make(map[string]float64),
+		assets:    make(map[string][2]string),
+	}
+
+	stored := map[string]float64{}
+	if ledger != nil {
+		var err error
+		if stored, err = ledger.GetBalances(accountID); err != nil {
+			return nil, fmt.Errorf("erro ao carregar ledger de paper trading: %w", err)
+		}
+	}
+
+	if len(stored) == 0 {
+		for asset, amount := range cfg.InitialBalances {
+			p.credit(asset, amount, "", "", entity.PaperLedgerDeposit)
+		}
+	} else {
+		for asset, amount := range stored {
+			p.balances[asset] = &balance{free: amount}
+		}
+	}
+	return p, nil
+}
+
+// ===== Dados de mercado (delegados à exchange real) =====
+
+func (p *PaperExchange) GetAccountPositions() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for asset, b := range p.balances {
+		logger.Info("📄 Saldo paper", "account_id", p.accountID.String(), "asset", asset, "free", b.free, "locked", b.locked)
+	}
+	return nil
+}
+
+func (p *PaperExchange) GetCurrentPrice(symbol string) (float64, error) {
+	p.mu.Lock()
+	price, ok := p.lastPrice[symbol]
+	p.mu.Unlock()
+	if ok {
+		return price, nil
+	}
+	return p.market.GetCurrentPrice(symbol)
+}
+
+func (p *PaperExchange) GetHistoricalCandles(symbol string, interval string, limit int) ([]entity.Candle, error) {
+	return p.market.GetHistoricalCandles(symbol, interval, limit)
+}
+
+// GetCandlesRange repassa a busca paginada de candles ao mercado, quando ele a oferece.
+func (p *PaperExchange) GetCandlesRange(symbol string, interval string, start, end time.Time) ([]entity.Candle, error) {
+	source, ok := p.market.(interface {
+		GetCandlesRange(symbol string, interval string, start, end time.Time) ([]entity.Candle, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("o mercado não oferece histórico paginado")
+	}
+	return source.GetCandlesRange(symbol, interval, start, end)
+}
+
+func (p *PaperExchange) GetBaseQuote(symbol string) (string, string, error) {
+	return p.market.GetBaseQuote(symbol)
+}
+
+func (p *PaperExchange) GetSymbolInfo(symbol string) (*entity.SymbolInfo, error) {
+	return p.market.GetSymbolInfo(symbol)
+}
+
+// ===== Ordens =====
+
+// PlaceOrder executa ordens a mercado imediatamente e registra ordens limitadas,
+// reservando o saldo necessário até a execução ou o cancelamento. Como na exchange real,
+// a ordem passa antes pelos filtros do símbolo.
+func (p *PaperExchange) PlaceOrder(req entity.OrderRequest) (*entity.Order, error) {
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("quantidade inválida: %v", req.Quantity)
+	}
+	if req.Type == entity.OrderTypeLimit && req.Price <= 0 {
+		return nil, fmt.Errorf("preço inválido para ordem limitada: %v", req.Price)
+	}
+
+	base, quote, err := p.baseQuote(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	marketPrice, err := p.GetCurrentPrice(req.Symbol)
+	if err != nil && req.Type == entity.OrderTypeMarket {
+		return nil, fmt.Errorf("erro ao obter preço de %s: %w", req.Symbol, err)
+	}
+
+	info, err := p.market.GetSymbolInfo(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if req, err = services.PrepareOrder(info, req, marketPrice); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seq++
+	now := time.Now()
+	order := &entity.Order{
+		ID:              uuid.New(),
+		AccountID:       p.accountID,
+		ExchangeOrderID: fmt.Sprintf("paper-%d-%d", now.UnixMilli(), p.seq),
+		ClientOrderID:   req.ClientOrderID,
+		Symbol:          req.Symbol,
+		Side:            req.Side,
+		Type:            req.Type,
+		Status:          entity.OrderStatusNew,
+		Quantity:        req.Quantity,
+		Price:           req.Price,
+		Paper:           true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	switch req.Type {
+	case entity.OrderTypeMarket:
+		fillPrice := marketPrice * (1 + p.cfg.Slippage)
+		if req.Side == entity.OrderSideSell {
+			fillPrice = marketPrice * (1 - p.cfg.Slippage)
+		}
+		if !p.hasFree(order, base, quote, fillPrice) {
+			order.Status = entity.OrderStatusRejected
+			p.orders[order.ExchangeOrderID] = order
+			return order, ErrInsufficientBalance
+		}
+		p.fill(order, base, quote, fillPrice, p.cfg.TakerFee)
+
+	case entity.OrderTypeLimit:
+		if !p.hasFree(order, base, quote, req.Price) {
+			order.Status = entity.OrderStatusRejected
+			p.orders[order.ExchangeOrderID] = order
+			return order, ErrInsufficientBalance
+		}
+		p.lock(order, base, quote)
+		p.open = append(p.open, order)
+
+	default:
+		return nil, fmt.Errorf("tipo de ordem não suportado: %s", req.Type)
+	}
+
+	p.orders[order.ExchangeOrderID] = order
+	logger.Info("📄 Ordem paper registrada",
+		"account_id", p.accountID.String(),
+		"order_id", order.ExchangeOrderID,
+		"symbol", order.Symbol,
+		"side", order.Side,
+		"type", order.Type,
+		"status", order.Status,
+		"quantity", order.Quantity,
+		"avg_price", order.AvgPrice(),
+	)
+	result := *order
+	return &result, nil
+}
+
+// CancelOrder cancela uma ordem limitada pendente e libera o saldo reservado.
+func (p *PaperExchange) CancelOrder(symbol, exchangeOrderID string) (*entity.Order, error) {
+	base, quote, err := p.baseQuote(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, ok := p.orders[exchangeOrderID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", services.ErrOrderNotFound, exchangeOrderID)
+	}
+	if order.Status.IsFinal() {
+		return nil, fmt.Errorf("ordem %s já finalizada (%s)", exchangeOrderID, order.Status)
+	}
+
+	p.unlock(order, base, quote)
+	p.removeOpen(order)
+	order.Status = entity.OrderStatusCanceled
+	order.UpdatedAt = time.Now()
+
+	result := *order
+	return &result, nil
+}
+
+// GetOrder retorna o estado atual da ordem.
+func (p *PaperExchange) GetOrder(symbol, exchangeOrderID string) (*entity.Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	order, ok := p.orders[exchangeOrderID]
+	if !ok || order.Symbol != symbol {
+		return nil, fmt.Errorf("%w: %s", services.ErrOrderNotFound, exchangeOrderID)
+	}
+	result := *order
+	return &result, nil
+}
+
+// GetFreeBalance retorna o saldo virtual disponível (não reservado) do ativo.
+func (p *PaperExchange) GetFreeBalance(asset string) (float64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if b, ok := p.balances[asset]; ok {
+		return b.free, nil
+	}
+	return 0, nil
+}
+
+// OnCandle atualiza o último preço do símbolo e executa as ordens limitadas cruzadas
+// pelo candle. Se o candle abrir além do preço limite, a execução ocorre na abertura.
+func (p *PaperExchange) OnCandle(symbol string, candle entity.Candle) {
+	p.mu.Lock()
+	p.lastPrice[symbol] = candle.Close
+
+	var pending []*entity.Order
+	for _, o := range p.open {
+		if o.Symbol == symbol {
+			pending = append(pending, o)
+		}
+	}
+	p.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	base, quote, err := p.baseQuote(symbol)
+	if err != nil {
+		logger.Error("[Paper] Erro ao obter ativos do símbolo", err, "symbol", symbol)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, o := range pending {
+		if o.Status.IsFinal() {
+			continue
+		}
+
+		var fillPrice float64
+		switch {
+		case o.Side == entity.OrderSideBuy && candle.Low <= o.Price:
+			fillPrice = min(o.Price, candle.Open)
+		case o.Side == entity.OrderSideSell && candle.High >= o.Price:
+			fillPrice = max(o.Price, candle.Open)
+		default:
+			continue
+		}
+
+		p.unlock(o, base, quote)
+		p.removeOpen(o)
+		p.fill(o, base, quote, fillPrice, p.cfg.MakerFee)
+
+		logger.Info("📄 Ordem limitada paper executada",
+			"account_id", p.accountID.String(),
+			"order_id", o.ExchangeOrderID,
+			"symbol", symbol,
+			"side", o.Side,
+			"price", fillPrice,
+		)
+	}
+}
+
+// ===== Internos (chamados com p.mu travado, exceto baseQuote) =====
+
+func (p *PaperExchange) baseQuote(symbol string) (string, string, error) {
+	p.mu.Lock()
+	pair, ok := p.assets[symbol]
+	p.mu.Unlock()
+	if ok {
+		return pair[0], pair[1], nil
+	}
+
+	base, quote, err := p.market.GetBaseQuote(symbol)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao obter ativos de %s: %w", symbol, err)
+	}
+
+	p.mu.Lock()
+	p.assets[symbol] = [2]string{base, quote}
+	p.mu.Unlock()
+	return base, quote, nil
+}
+
+func (p *PaperExchange) hasFree(o *entity.Order, base, quote string, price float64) bool {
+	if o.Side == entity.OrderSideBuy {
+		return p.free(quote) >= o.Quantity*price
+	}
+	return p.free(base) >= o.Quantity
+}
+
+func (p *PaperExchange) free(asset string) float64 {
+	if b, ok := p.balances[asset]; ok {
+		return b.free
+	}
+	return 0
+}
+
+func (p *PaperExchange) account(asset string) *balance {
+	b, ok := p.balances[asset]
+	if !ok {
+		b = &balance{}
+		p.balances[asset] = b
+	}
+	return b
+}
+
+// lock reserva o saldo de uma ordem limitada.
+func (p *PaperExchange) lock(o *entity.Order, base, quote string) {
+	asset, amount := base, o.Quantity
+	if o.Side == entity.OrderSideBuy {
+		asset, amount = quote, o.Quantity*o.Price
+	}
+	b := p.account(asset)
+	b.free -= amount
+	b.locked += amount
+}
+
+// unlock devolve o saldo reservado de uma ordem limitada.
+func (p *PaperExchange) unlock(o *entity.Order, base, quote string) {
+	asset, amount := base, o.Quantity
+	if o.Side == entity.OrderSideBuy {
+		asset, amount = quote, o.Quantity*o.Price
+	}
+	b := p.account(asset)
+	b.free += amount
+	b.locked -= amount
+}
+
+// fill executa a ordem inteira ao preço informado. Como na Binance, a taxa é
+// cobrada no ativo recebido (base na compra, cotação na venda).
+func (p *PaperExchange) fill(o *entity.Order, base, quote string, price, feeRate float64) {
+	quoteAmount := o.Quantity * price
+
+	if o.Side == entity.OrderSideBuy {
+		fee := o.Quantity * feeRate
+		p.debit(quote, quoteAmount, o)
+		p.credit(base, o.Quantity, o.ExchangeOrderID, o.Symbol, entity.PaperLedgerTrade)
+		p.debitFee(base, fee, o)
+		o.Fee, o.FeeAsset = fee, base
+	} else {
+		fee := quoteAmount * feeRate
+		p.debit(base, o.Quantity, o)
+		p.credit(quote, quoteAmount, o.ExchangeOrderID, o.Symbol, entity.PaperLedgerTrade)
+		p.debitFee(quote, fee, o)
+		o.Fee, o.FeeAsset = fee, quote
+	}
+
+	o.Status = entity.OrderStatusFilled
+	o.ExecutedQuantity = o.Quantity
+	o.QuoteQuantity = quoteAmount
+	o.UpdatedAt = time.Now()
+}
+
+func (p *PaperExchange) credit(asset string, amount float64, orderID, symbol, reason string) {
+	b := p.account(asset)
+	b.free += amount
+	p.record(asset, amount, orderID, symbol, reason)
+}
+
+func (p *PaperExchange) debit(asset string, amount float64, o *entity.Order) {
+	b := p.account(asset)
+	b.free -= amount
+	p.record(asset, -amount, o.ExchangeOrderID, o.Symbol, entity.PaperLedgerTrade)
+}
+
+func (p *PaperExchange) debitFee(asset string, fee float64, o *entity.Order) {
+	if fee == 0 {
+		return
+	}
+	b := p.account(asset)
+	b.free -= fee
+	p.record(asset, -fee, o.ExchangeOrderID, o.Symbol, entity.PaperLedgerFee)
+}
+
+func (p *PaperExchange) record(asset string, amount float64, orderID, symbol, reason string) {
+	if p.ledger == nil {
+		return
+	}
+	b := p.balances[asset]
+	err := p.ledger.Save(entity.PaperLedgerEntry{
+		AccountID: p.accountID,
+		OrderID:   orderID,
+		Symbol:    symbol,
+		Asset:     asset,
+		Amount:    amount,
+		Balance:   b.free + b.locked,
+		Reason:    reason,
+	})
+	if err != nil {
+		logger.Error("[Paper] Erro ao gravar ledger", err, "account_id", p.accountID.String(), "asset", asset)
+	}
+}
+
+func (p *PaperExchange) removeOpen(o *entity.Order) {
+	for i, open := range p.open {
+		if open == o {
+			p.open = append(p.open[:i], p.open[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// internal/services/paper/paper_exchange_test.go
+
+package paper_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+	"github.com/jeancarlosdanese/crypto-bot/internal/logger"
+	"github.com/jeancarlosdanese/crypto-bot/internal/services/paper"
+	"github.com/jeancarlosdanese/crypto-bot/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newExchange(t *testing.T) *paper.PaperExchange {
+	logger.InitLogger()
+	market := &mocks.MockExchangeService{Prices: map[string]float64{"BTCUSDT": 100}}
+	cfg := paper.Config{
+		TakerFee:        0.001,
+		MakerFee:        0.0005,
+		Slippage:        0.01,
+		InitialBalances: map[string]float64{"USDT": 1000},
+	}
+	p, err := paper.NewPaperExchange(uuid.New(), market, cfg, nil)
+	require.NoError(t, err)
+	return p
+}
+
+func TestMarketOrderAppliesSlippageAndFee(t *testing.T) {
+	p := newExchange(t)
+
+	order, err := p.PlaceOrder(entity.OrderRequest{Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeMarket, Quantity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusFilled, order.Status)
+	assert.InDelta(t, 101, order.AvgPrice(), 1e-9)
+	assert.InDelta(t, 0.002, order.Fee, 1e-9)
+	assert.Equal(t, "BTC", order.FeeAsset)
+
+	usdt, _ := p.GetFreeBalance("USDT")
+	btc, _ := p.GetFreeBalance("BTC")
+	assert.InDelta(t, 798, usdt, 1e-9)
+	assert.InDelta(t, 1.998, btc, 1e-9)
+
+	_, err = p.PlaceOrder(entity.OrderRequest{Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeMarket, Quantity: 100})
+	assert.ErrorIs(t, err, paper.ErrInsufficientBalance)
+}
+
+func TestLimitOrderFillsWhenCandleCrossesPrice(t *testing.T) {
+	p := newExchange(t)
+
+	order, err := p.PlaceOrder(entity.OrderRequest{Symbol: "BTCUSDT", Side: entity.OrderSideBuy, Type: entity.OrderTypeLimit, Quantity: 1, Price: 95})
+	require.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusNew, order.Status)
+
+	usdt, _ := p.GetFreeBalance("USDT")
+	assert.InDelta(t, 905, usdt, 1e-9, "saldo reservado pela ordem limitada")
+
+	// Candle não alcança o preço limite
+	p.OnCandle("BTCUSDT", entity.Candle{Open: 100, High: 102, Low: 96, Close: 98})
+	order, _ = p.GetOrder("BTCUSDT", order.ExchangeOrderID)
+	assert.Equal(t, entity.OrderStatusNew, order.Status)
+
+	// Candle cruza o preço limite
+	p.OnCandle("BTCUSDT", entity.Candle{Open: 97, High: 98, Low: 94, Close: 96})
+	order, _ = p.GetOrder("BTCUSDT", order.ExchangeOrderID)
+	assert.Equal(t, entity.OrderStatusFilled, order.Status)
+	assert.InDelta(t, 95, order.AvgPrice(), 1e-9)
+	assert.InDelta(t, 0.0005, order.Fee, 1e-9)
+
+	_, err = p.CancelOrder("BTCUSDT", order.ExchangeOrderID)
+	assert.Error(t, err)
+}
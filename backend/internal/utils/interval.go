@@ -0,0 +1,82 @@
+// internal/utils/interval.go
+
+package utils
+
+import "time"
+
+// binanceIntervals lista os intervalos de kline aceitos pela Binance.
+var binanceIntervals = map[string]bool{
+	"1s": true, "1m": true, "3m": true, "5m": true, "15m": true, "30m": true,
+	"1h": true, "2h": true, "4h": true, "6h": true, "8h": true, "12h": true,
+	"1d": true, "3d": true, "1w": true, "1M": true,
+}
+
+// IsValidInterval verifica se o intervalo é suportado pela Binance (ex: "1m", "4h").
+func IsValidInterval(interval string) bool {
+	return binanceIntervals[interval]
+}
+
+// maxAggregatedInterval limita os intervalos agregados: semanas e meses não têm duração fixa
+// em minutos alinhada à Binance (semana a partir de segunda, meses de 28 a 31 dias).
+const maxAggregatedInterval = 7 * 24 * time.Hour
+
+// IsAggregatedInterval verifica se o intervalo não é oferecido pela Binance, mas pode ser
+// montado a partir de candles de 1m: múltiplos de minutos, horas ou dias menores que uma
+// semana (ex: "2m", "10m", "3h", "2d").
+func IsAggregatedInterval(interval string) bool {
+	if IsValidInterval(interval) {
+		return false
+	}
+	d, ok := parseInterval(interval)
+	if !ok {
+		return false
+	}
+	switch interval[len(interval)-1] {
+	case 'm', 'h', 'd':
+		return d < maxAggregatedInterval
+	}
+	return false
+}
+
+// IsSupportedInterval verifica se o intervalo é nativo da Binance ou agregável a partir de 1m.
+func IsSupportedInterval(interval string) bool {
+	return IsValidInterval(interval) || IsAggregatedInterval(interval)
+}
+
+// IntervalDuration retorna a duração de um intervalo nativo ou agregado ("1M" é tratado como 30 dias).
+func IntervalDuration(interval string) (time.Duration, bool) {
+	if !IsSupportedInterval(interval) {
+		return 0, false
+	}
+	return parseInterval(interval)
+}
+
+// parseInterval interpreta intervalos no formato <n><unidade> (s, m, h, d, w ou M).
+func parseInterval(interval string) (time.Duration, bool) {
+	if len(interval) < 2 || len(interval) > 6 || interval[0] == '0' {
+		return 0, false
+	}
+	unit := interval[len(interval)-1]
+	var n time.Duration
+	for _, c := range interval[:len(interval)-1] {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + time.Duration(c-'0')
+	}
+	switch unit {
+	case 's':
+		return n * time.Second, true
+	case 'm':
+		return n * time.Minute, true
+	case 'h':
+		return n * time.Hour, true
+	case 'd':
+		return n * 24 * time.Hour, true
+	case 'w':
+		return n * 7 * 24 * time.Hour, true
+	case 'M':
+		return n * 30 * 24 * time.Hour, true
+	}
+	return 0, false
+}
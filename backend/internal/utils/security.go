@@ -14,3 +14,12 @@ func GenerateAPIKeyPtr() *string {
 	key := hex.EncodeToString(bytes)
 	return &key
 }
+
+// MaskSecret retorna uma dica exibível do segredo, com apenas os últimos 4 caracteres (ex: "****x9Kz")
+func MaskSecret(secret string) string {
+	const visible = 4
+	if len(secret) <= visible*2 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-visible:]
+}
@@ -0,0 +1,6 @@
+-- migrations/0003_encrypt_account_secrets.sql
+
+-- O segredo da Binance passa a ser gravado cifrado (enc:v1:<key_id>:<dek>:<segredo>),
+-- maior que os 100 caracteres originais. Valores em texto puro existentes são
+-- cifrados com: go run ./cmd/rotate-secrets
+ALTER TABLE "public"."accounts" ALTER COLUMN "binance_api_secret" TYPE text;
@@ -0,0 +1,17 @@
+-- migrations/0004_create_paper_ledger_table.sql
+
+-- Ledger de saldos virtuais (paper trading), um lançamento por variação de saldo
+CREATE TABLE "public"."paper_ledger" (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    "seq" bigserial NOT NULL,
+    "account_id" uuid NOT NULL,
+    "order_id" varchar(64),
+    "symbol" varchar(20),
+    "asset" varchar(20) NOT NULL,
+    "amount" numeric(28,12) NOT NULL,
+    "balance" numeric(28,12) NOT NULL,
+    "reason" varchar(20) NOT NULL,
+    "created_at" timestamp DEFAULT now(),
+    CONSTRAINT "paper_ledger_account_id_fkey" FOREIGN KEY ("account_id") REFERENCES "public"."accounts"("id") ON DELETE CASCADE
+);
+CREATE INDEX paper_ledger_account_asset_idx ON public.paper_ledger USING btree (account_id, asset, seq);
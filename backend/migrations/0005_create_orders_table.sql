@@ -0,0 +1,29 @@
+-- migrations/0005_create_orders_table.sql
+
+-- Ordens enviadas pelos bots autônomos (reais ou paper)
+CREATE TABLE "public"."orders" (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    "account_id" uuid NOT NULL,
+    "bot_id" uuid NOT NULL,
+    "exchange_order_id" varchar(64),
+    "client_order_id" varchar(64),
+    "symbol" varchar(20) NOT NULL,
+    "side" varchar(4) NOT NULL,
+    "type" varchar(10) NOT NULL,
+    "status" varchar(20) NOT NULL,
+    "quantity" numeric(28,12) NOT NULL,
+    "price" numeric(28,12) DEFAULT 0,
+    "executed_quantity" numeric(28,12) DEFAULT 0,
+    "quote_quantity" numeric(28,12) DEFAULT 0,
+    "fee" numeric(28,12) DEFAULT 0,
+    "fee_asset" varchar(20),
+    "paper" boolean DEFAULT false,
+    "created_at" timestamp DEFAULT now(),
+    "updated_at" timestamp DEFAULT now(),
+    CONSTRAINT "orders_account_id_fkey" FOREIGN KEY ("account_id") REFERENCES "public"."accounts"("id") ON DELETE CASCADE,
+    CONSTRAINT "orders_bot_id_fkey" FOREIGN KEY ("bot_id") REFERENCES "public"."bots"("id") ON DELETE CASCADE
+);
+CREATE INDEX orders_bot_id_status_idx ON public.orders USING btree (bot_id, status);
+
+-- Quantidade efetivamente executada na entrada (necessária para a ordem de saída)
+ALTER TABLE "public"."positions" ADD COLUMN "quantity" numeric(28,12) NOT NULL DEFAULT 1;
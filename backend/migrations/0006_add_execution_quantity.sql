@@ -0,0 +1,4 @@
+-- migrations/0006_add_execution_quantity.sql
+
+-- Quantidade negociada em cada execução; o lucro passa a ser em moeda de cotação (preço x quantidade)
+ALTER TABLE "public"."executions" ADD COLUMN "quantity" numeric(28,12) NOT NULL DEFAULT 1;
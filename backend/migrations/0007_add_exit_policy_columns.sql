@@ -0,0 +1,9 @@
+-- migrations/0007_add_exit_policy_columns.sql
+
+-- Acompanhamento da política de saída da posição aberta (máxima desde a entrada e stop vigente)
+ALTER TABLE "public"."positions" ADD COLUMN "highest_price" numeric(28,12) NOT NULL DEFAULT 0;
+ALTER TABLE "public"."positions" ADD COLUMN "stop_price" numeric(28,12) NOT NULL DEFAULT 0;
+ALTER TABLE "public"."positions" ADD COLUMN "stop_reason" varchar(20) NOT NULL DEFAULT '';
+
+-- Motivo do encerramento (signal, stop_loss, atr_stop, trailing_stop, break_even, take_profit, max_holding)
+ALTER TABLE "public"."executions" ADD COLUMN "exit_reason" varchar(20) NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+-- migrations/0008_create_account_risk_limits_table.sql
+
+-- Limites de risco por conta (0 desativa o limite) e kill switch
+CREATE TABLE "public"."account_risk_limits" (
+    "account_id" uuid PRIMARY KEY,
+    "max_open_positions" int NOT NULL DEFAULT 0,
+    "max_total_exposure" numeric(28,12) NOT NULL DEFAULT 0,
+    "max_asset_exposure" numeric(28,12) NOT NULL DEFAULT 0,
+    "max_daily_loss" numeric(28,12) NOT NULL DEFAULT 0,
+    "max_consecutive_losses" int NOT NULL DEFAULT 0,
+    "kill_switch" boolean NOT NULL DEFAULT false,
+    "kill_switch_at" timestamp,
+    "updated_at" timestamp DEFAULT now(),
+    CONSTRAINT "account_risk_limits_account_id_fkey" FOREIGN KEY ("account_id") REFERENCES "public"."accounts"("id") ON DELETE CASCADE
+);
+
+-- Consulta das execuções do dia por conta
+CREATE INDEX executions_bot_id_created_at_idx ON public.executions USING btree (bot_id, created_at);
@@ -0,0 +1,16 @@
+-- migrations/0009_create_candles_table.sql
+
+-- Candles fechados recebidos pelos streams (aquecimento no início e preenchimento de lacunas)
+CREATE TABLE "public"."candles" (
+    "symbol" varchar(20) NOT NULL,
+    "interval" varchar(5) NOT NULL,
+    "open_time" bigint NOT NULL,
+    "close_time" bigint NOT NULL,
+    "open" numeric(28,12) NOT NULL,
+    "high" numeric(28,12) NOT NULL,
+    "low" numeric(28,12) NOT NULL,
+    "close" numeric(28,12) NOT NULL,
+    "volume" numeric(28,12) NOT NULL,
+    "created_at" timestamp DEFAULT now(),
+    PRIMARY KEY ("symbol", "interval", "open_time")
+);
@@ -0,0 +1,8 @@
+-- migrations/0010_add_candle_volume_columns.sql
+
+-- Metadados completos dos klines: volume em cotação, negócios e volume comprado a mercado
+ALTER TABLE "public"."candles"
+    ADD COLUMN "quote_volume" numeric(28,12) NOT NULL DEFAULT 0,
+    ADD COLUMN "trades" bigint NOT NULL DEFAULT 0,
+    ADD COLUMN "taker_buy_volume" numeric(28,12) NOT NULL DEFAULT 0,
+    ADD COLUMN "taker_buy_quote_volume" numeric(28,12) NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- migrations/0011_add_bot_paused_column.sql
+
+-- Pausa do bot persistida, para que reinícios do processo não retomem a avaliação
+ALTER TABLE "public"."bots" ADD COLUMN "paused" boolean NOT NULL DEFAULT false;
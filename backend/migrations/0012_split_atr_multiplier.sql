@@ -0,0 +1,11 @@
+-- migrations/0012_split_atr_multiplier.sql
+
+-- atr_multiplier era usado tanto pelo stop da política de saída quanto pelo sizing
+-- fixed_fractional; as configurações existentes passam a ter as duas chaves separadas
+UPDATE "public"."bot_configs"
+SET "config_json" = ("config_json" - 'atr_multiplier')
+    || jsonb_build_object(
+        'stop_atr_multiplier', "config_json" -> 'atr_multiplier',
+        'sizing_atr_multiplier', "config_json" -> 'atr_multiplier'
+    )
+WHERE "config_json" ? 'atr_multiplier';
@@ -0,0 +1,4 @@
+-- migrations/0013_add_position_close_requested.sql
+
+-- Encerramento forçado pendente (ex: kill switch), separado do stop da política de saída
+ALTER TABLE "public"."positions" ADD COLUMN "close_requested" varchar(20) NOT NULL DEFAULT '';
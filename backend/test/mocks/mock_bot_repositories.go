@@ -0,0 +1,126 @@
+// test/mocks/mock_bot_repositories.go
+
+package mocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// MockAccountRepository guarda as contas em memória, por ID.
+type MockAccountRepository struct {
+	Accounts map[uuid.UUID]entity.Account
+}
+
+func NewMockAccountRepository() *MockAccountRepository {
+	return &MockAccountRepository{Accounts: make(map[uuid.UUID]entity.Account)}
+}
+
+func (m *MockAccountRepository) Create(ctx context.Context, account *entity.Account) (*entity.Account, error) {
+	m.Accounts[account.ID] = *account
+	return account, nil
+}
+
+func (m *MockAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	account, ok := m.Accounts[id]
+	if !ok {
+		return nil, errors.New("conta não encontrada")
+	}
+	return &account, nil
+}
+
+func (m *MockAccountRepository) GetAll(ctx context.Context) ([]*entity.Account, error) {
+	accounts := make([]*entity.Account, 0, len(m.Accounts))
+	for _, account := range m.Accounts {
+		accounts = append(accounts, &account)
+	}
+	return accounts, nil
+}
+
+func (m *MockAccountRepository) UpdateByID(ctx context.Context, id uuid.UUID, jsonData []byte) (*entity.Account, error) {
+	return nil, errors.New("não suportado")
+}
+
+func (m *MockAccountRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	delete(m.Accounts, id)
+	return nil
+}
+
+// MockBotRepository guarda os bots em memória, por ID.
+type MockBotRepository struct {
+	Bots map[uuid.UUID]entity.Bot
+}
+
+func NewMockBotRepository() *MockBotRepository {
+	return &MockBotRepository{Bots: make(map[uuid.UUID]entity.Bot)}
+}
+
+func (m *MockBotRepository) Create(bot *entity.Bot) (*entity.Bot, error) {
+	m.Bots[bot.ID] = *bot
+	return bot, nil
+}
+
+func (m *MockBotRepository) GetByID(id uuid.UUID) (*entity.Bot, error) {
+	bot, ok := m.Bots[id]
+	if !ok {
+		return nil, nil
+	}
+	return &bot, nil
+}
+
+func (m *MockBotRepository) GetByAccountID(accountID uuid.UUID) ([]entity.Bot, error) {
+	var bots []entity.Bot
+	for _, bot := range m.Bots {
+		if bot.AccountID == accountID {
+			bots = append(bots, bot)
+		}
+	}
+	return bots, nil
+}
+
+func (m *MockBotRepository) GetActive() ([]entity.Bot, error) {
+	var bots []entity.Bot
+	for _, bot := range m.Bots {
+		if bot.Active {
+			bots = append(bots, bot)
+		}
+	}
+	return bots, nil
+}
+
+func (m *MockBotRepository) Update(bot *entity.Bot) (*entity.Bot, error) {
+	m.Bots[bot.ID] = *bot
+	return bot, nil
+}
+
+func (m *MockBotRepository) Delete(id uuid.UUID) error {
+	delete(m.Bots, id)
+	return nil
+}
+
+// MockBotConfigRepository guarda apenas a configuração mais recente de cada bot. Cada Save
+// gera uma nova versão (novo ID), como em bot_configs.
+type MockBotConfigRepository struct {
+	Configs map[uuid.UUID]entity.BotConfig
+}
+
+func NewMockBotConfigRepository() *MockBotConfigRepository {
+	return &MockBotConfigRepository{Configs: make(map[uuid.UUID]entity.BotConfig)}
+}
+
+func (m *MockBotConfigRepository) GetLatest(botID uuid.UUID) (*entity.BotConfig, error) {
+	config, ok := m.Configs[botID]
+	if !ok {
+		return nil, nil
+	}
+	return &config, nil
+}
+
+func (m *MockBotConfigRepository) Save(config entity.BotConfig) (*entity.BotConfig, error) {
+	config.ID = uuid.New()
+	m.Configs[config.BotID] = config
+	return &config, nil
+}
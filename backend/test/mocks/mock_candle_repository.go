@@ -0,0 +1,68 @@
+// test/mocks/mock_candle_repository.go
+
+package mocks
+
+import (
+	"sort"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// MockCandleRepository guarda os candles em memória por símbolo e intervalo, ordenados pela abertura.
+type MockCandleRepository struct {
+	Candles map[string][]entity.Candle // chave: símbolo + "@" + intervalo
+}
+
+func NewMockCandleRepository() *MockCandleRepository {
+	return &MockCandleRepository{Candles: make(map[string][]entity.Candle)}
+}
+
+func (m *MockCandleRepository) SaveMany(symbol, interval string, candles []entity.Candle) error {
+	key := symbol + "@" + interval
+	byOpen := make(map[int64]entity.Candle)
+	for _, c := range m.Candles[key] {
+		byOpen[c.OpenTime] = c
+	}
+	for _, c := range candles {
+		byOpen[c.OpenTime] = c
+	}
+
+	merged := make([]entity.Candle, 0, len(byOpen))
+	for _, c := range byOpen {
+		merged = append(merged, c)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].OpenTime < merged[j].OpenTime })
+	m.Candles[key] = merged
+	return nil
+}
+
+func (m *MockCandleRepository) GetLatest(symbol, interval string, limit int) ([]entity.Candle, error) {
+	return m.GetRange(symbol, interval, 0, 0, limit)
+}
+
+func (m *MockCandleRepository) GetRange(symbol, interval string, from, to int64, limit int) ([]entity.Candle, error) {
+	var candles []entity.Candle
+	for _, c := range m.Candles[symbol+"@"+interval] {
+		if (from == 0 || c.CloseTime >= from) && (to == 0 || c.CloseTime <= to) {
+			candles = append(candles, c)
+		}
+	}
+	return latest(candles, limit), nil
+}
+
+func (m *MockCandleRepository) GetBefore(symbol, interval string, openTime int64, limit int) ([]entity.Candle, error) {
+	var candles []entity.Candle
+	for _, c := range m.Candles[symbol+"@"+interval] {
+		if c.OpenTime < openTime {
+			candles = append(candles, c)
+		}
+	}
+	return latest(candles, limit), nil
+}
+
+func latest(candles []entity.Candle, limit int) []entity.Candle {
+	if len(candles) > limit {
+		return candles[len(candles)-limit:]
+	}
+	return candles
+}
@@ -0,0 +1,81 @@
+// test/mocks/mock_exchange_service.go
+
+package mocks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// MockExchangeService fornece dados de mercado fixos. Símbolos terminados em
+// USDT são separados em base/cotação (ex: BTCUSDT -> BTC, USDT).
+type MockExchangeService struct {
+	Prices  map[string]float64
+	Candles []entity.Candle
+	Symbols map[string]*entity.SymbolInfo // Filtros por símbolo; sem entrada, nenhum filtro
+	Err     error
+
+	// FillOrder responde às ordens enviadas; sem ela, toda ordem é recusada
+	FillOrder func(req entity.OrderRequest) (*entity.Order, error)
+}
+
+func (m *MockExchangeService) GetAccountPositions() error {
+	return m.Err
+}
+
+func (m *MockExchangeService) GetCurrentPrice(symbol string) (float64, error) {
+	if m.Err != nil {
+		return 0, m.Err
+	}
+	price, ok := m.Prices[symbol]
+	if !ok {
+		return 0, fmt.Errorf("preço não encontrado: %s", symbol)
+	}
+	return price, nil
+}
+
+func (m *MockExchangeService) GetHistoricalCandles(symbol string, interval string, limit int) ([]entity.Candle, error) {
+	if limit < len(m.Candles) {
+		return m.Candles[len(m.Candles)-limit:], m.Err
+	}
+	return m.Candles, m.Err
+}
+
+func (m *MockExchangeService) GetBaseQuote(symbol string) (string, string, error) {
+	if base, ok := strings.CutSuffix(symbol, "USDT"); ok && base != "" {
+		return base, "USDT", nil
+	}
+	return "", "", fmt.Errorf("símbolo não encontrado")
+}
+
+func (m *MockExchangeService) GetSymbolInfo(symbol string) (*entity.SymbolInfo, error) {
+	if info, ok := m.Symbols[symbol]; ok {
+		return info, nil
+	}
+	base, quote, err := m.GetBaseQuote(symbol)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.SymbolInfo{Symbol: symbol, BaseAsset: base, QuoteAsset: quote}, nil
+}
+
+func (m *MockExchangeService) PlaceOrder(req entity.OrderRequest) (*entity.Order, error) {
+	if m.FillOrder != nil {
+		return m.FillOrder(req)
+	}
+	return nil, fmt.Errorf("ordens não suportadas no mock")
+}
+
+func (m *MockExchangeService) CancelOrder(symbol, exchangeOrderID string) (*entity.Order, error) {
+	return nil, fmt.Errorf("ordens não suportadas no mock")
+}
+
+func (m *MockExchangeService) GetOrder(symbol, exchangeOrderID string) (*entity.Order, error) {
+	return nil, fmt.Errorf("ordens não suportadas no mock")
+}
+
+func (m *MockExchangeService) GetFreeBalance(asset string) (float64, error) {
+	return 0, m.Err
+}
@@ -0,0 +1,50 @@
+// test/mocks/mock_order_repository.go
+
+package mocks
+
+import (
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// MockOrderRepository guarda as ordens em memória, indexadas pelo ID.
+type MockOrderRepository struct {
+	Orders map[uuid.UUID]entity.Order
+}
+
+func NewMockOrderRepository() *MockOrderRepository {
+	return &MockOrderRepository{Orders: make(map[uuid.UUID]entity.Order)}
+}
+
+func (m *MockOrderRepository) Save(order entity.Order) (*entity.Order, error) {
+	if order.ID == uuid.Nil {
+		order.ID = uuid.New()
+	}
+	m.Orders[order.ID] = order
+	return &order, nil
+}
+
+func (m *MockOrderRepository) Update(order entity.Order) error {
+	m.Orders[order.ID] = order
+	return nil
+}
+
+func (m *MockOrderRepository) GetOpenByBot(botID uuid.UUID) ([]entity.Order, error) {
+	var open []entity.Order
+	for _, o := range m.Orders {
+		if o.BotID == botID && !o.Status.IsFinal() {
+			open = append(open, o)
+		}
+	}
+	return open, nil
+}
+
+func (m *MockOrderRepository) GetOpenByAccount(accountID uuid.UUID) ([]entity.Order, error) {
+	var open []entity.Order
+	for _, o := range m.Orders {
+		if o.AccountID == accountID && !o.Status.IsFinal() {
+			open = append(open, o)
+		}
+	}
+	return open, nil
+}
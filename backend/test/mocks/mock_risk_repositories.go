@@ -0,0 +1,113 @@
+// test/mocks/mock_risk_repositories.go
+
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jeancarlosdanese/crypto-bot/internal/domain/entity"
+)
+
+// MockRiskLimitsRepository guarda os limites de risco em memória, por conta.
+type MockRiskLimitsRepository struct {
+	Limits map[uuid.UUID]entity.RiskLimits
+}
+
+func NewMockRiskLimitsRepository() *MockRiskLimitsRepository {
+	return &MockRiskLimitsRepository{Limits: make(map[uuid.UUID]entity.RiskLimits)}
+}
+
+func (m *MockRiskLimitsRepository) Get(accountID uuid.UUID) (*entity.RiskLimits, error) {
+	limits, ok := m.Limits[accountID]
+	if !ok {
+		return nil, nil
+	}
+	return &limits, nil
+}
+
+func (m *MockRiskLimitsRepository) Save(limits entity.RiskLimits) error {
+	m.Limits[limits.AccountID] = limits
+	return nil
+}
+
+// MockPositionRepository guarda as posições abertas em memória. Todas pertencem a uma única conta.
+type MockPositionRepository struct {
+	Positions map[uuid.UUID]entity.OpenPosition
+}
+
+func NewMockPositionRepository() *MockPositionRepository {
+	return &MockPositionRepository{Positions: make(map[uuid.UUID]entity.OpenPosition)}
+}
+
+func (m *MockPositionRepository) Save(position entity.OpenPosition) error {
+	position.CloseRequested = m.Positions[position.BotID].CloseRequested
+	m.Positions[position.BotID] = position
+	return nil
+}
+
+func (m *MockPositionRepository) RequestClose(botID uuid.UUID, reason string) error {
+	if position, ok := m.Positions[botID]; ok {
+		position.CloseRequested = reason
+		m.Positions[botID] = position
+	}
+	return nil
+}
+
+func (m *MockPositionRepository) Delete(botID uuid.UUID) error {
+	delete(m.Positions, botID)
+	return nil
+}
+
+func (m *MockPositionRepository) Get(botID uuid.UUID) (*entity.OpenPosition, error) {
+	position, ok := m.Positions[botID]
+	if !ok {
+		return nil, nil
+	}
+	return &position, nil
+}
+
+func (m *MockPositionRepository) GetAll() ([]entity.OpenPosition, error) {
+	var positions []entity.OpenPosition
+	for _, p := range m.Positions {
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+func (m *MockPositionRepository) GetByAccount(uuid.UUID) ([]entity.OpenPosition, error) {
+	return m.GetAll()
+}
+
+// MockExecutionLogRepository guarda as execuções em memória, na ordem de gravação.
+// Todas pertencem a uma única conta.
+type MockExecutionLogRepository struct {
+	Executions []entity.ExecutionLog
+}
+
+func (m *MockExecutionLogRepository) Save(log entity.ExecutionLog) error {
+	m.Executions = append(m.Executions, log)
+	return nil
+}
+
+func (m *MockExecutionLogRepository) GetAll() ([]entity.ExecutionLog, error) {
+	return m.Executions, nil
+}
+
+func (m *MockExecutionLogRepository) GetLatestByAccount(_ uuid.UUID, limit int) ([]entity.ExecutionLog, error) {
+	var logs []entity.ExecutionLog
+	for i := len(m.Executions) - 1; i >= 0 && len(logs) < limit; i-- {
+		logs = append(logs, m.Executions[i])
+	}
+	return logs, nil
+}
+
+func (m *MockExecutionLogRepository) GetByAccountSince(_ uuid.UUID, since time.Time) ([]entity.ExecutionLog, error) {
+	var logs []entity.ExecutionLog
+	for i := len(m.Executions) - 1; i >= 0; i-- {
+		if !m.Executions[i].CreatedAt.Before(since) {
+			logs = append(logs, m.Executions[i])
+		}
+	}
+	return logs, nil
+}